@@ -0,0 +1,228 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/hasheddan/kc-provider-github/apis/org/v1beta1"
+)
+
+// AnnotationKeyV1Beta1Parameters is the annotation in which the parameters of
+// a v1beta1 resource that cannot be represented in v1alpha1 are preserved, so
+// that they are not lost when it is read and written as v1alpha1.
+const AnnotationKeyV1Beta1Parameters = "org.github.hasheddan.io/v1beta1-parameters"
+
+const (
+	errPreserve = "cannot preserve v1beta1 parameters"
+	errRestore  = "cannot restore v1beta1 parameters"
+)
+
+// teamParameters are the parameters of a v1beta1 Team that a v1alpha1 Team
+// does not have.
+type teamParameters struct {
+	Name                *string `json:"name,omitempty"`
+	NotificationSetting *string `json:"notificationSetting,omitempty"`
+	LDAPDN              *string `json:"ldapDN,omitempty"`
+}
+
+// membershipParameters are the parameters of a v1beta1 Membership that a
+// v1alpha1 Membership does not have.
+type membershipParameters struct {
+	Role             *string         `json:"role,omitempty"`
+	ReinviteOnExpiry *bool           `json:"reinviteOnExpiry,omitempty"`
+	UserRef          *xpv1.Reference `json:"userRef,omitempty"`
+	UserSelector     *xpv1.Selector  `json:"userSelector,omitempty"`
+}
+
+// preserve the supplied parameters in an annotation of the supplied object
+// metadata, unless they are all unset.
+func preserve(om *metav1.ObjectMeta, p interface{}) error {
+	b, err := json.Marshal(p)
+	if err != nil {
+		return errors.Wrap(err, errPreserve)
+	}
+	if string(b) == "{}" {
+		return nil
+	}
+	if om.Annotations == nil {
+		om.Annotations = map[string]string{}
+	}
+	om.Annotations[AnnotationKeyV1Beta1Parameters] = string(b)
+	return nil
+}
+
+// restore the parameters preserved in an annotation of the supplied object
+// metadata, removing the annotation.
+func restore(om *metav1.ObjectMeta, p interface{}) error {
+	v, ok := om.Annotations[AnnotationKeyV1Beta1Parameters]
+	if !ok {
+		return nil
+	}
+	delete(om.Annotations, AnnotationKeyV1Beta1Parameters)
+	if len(om.Annotations) == 0 {
+		om.Annotations = nil
+	}
+	return errors.Wrap(json.Unmarshal([]byte(v), p), errRestore)
+}
+
+var _ conversion.Convertible = &Team{}
+
+// ConvertTo converts this Team to the hub (v1beta1) version.
+func (in *Team) ConvertTo(hub conversion.Hub) error {
+	dst := hub.(*v1beta1.Team)
+	dst.ObjectMeta = *in.ObjectMeta.DeepCopy()
+	dst.Spec.ResourceSpec = *in.Spec.ResourceSpec.DeepCopy()
+	dst.Status.ResourceStatus = *in.Status.ResourceStatus.DeepCopy()
+
+	src := in.Spec.ForProvider.DeepCopy()
+	dst.Spec.ForProvider = v1beta1.TeamParameters{
+		Org:            src.Org,
+		OrgRef:         src.OrgRef,
+		OrgSelector:    src.OrgSelector,
+		Description:    src.Description,
+		Privacy:        src.Privacy,
+		Parent:         src.Parent,
+		ParentRef:      src.ParentRef,
+		ParentSelector: src.ParentSelector,
+	}
+	dst.Status.AtProvider = v1beta1.TeamObservation{NodeID: in.Status.AtProvider.NodeID}
+
+	p := &teamParameters{}
+	if err := restore(&dst.ObjectMeta, p); err != nil {
+		return err
+	}
+	dst.Spec.ForProvider.Name = p.Name
+	dst.Spec.ForProvider.NotificationSetting = p.NotificationSetting
+	dst.Spec.ForProvider.LDAPDN = p.LDAPDN
+	return nil
+}
+
+// ConvertFrom converts the hub (v1beta1) version to this Team.
+func (in *Team) ConvertFrom(hub conversion.Hub) error {
+	src := hub.(*v1beta1.Team)
+	in.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	in.Spec.ResourceSpec = *src.Spec.ResourceSpec.DeepCopy()
+	in.Status.ResourceStatus = *src.Status.ResourceStatus.DeepCopy()
+
+	fp := src.Spec.ForProvider.DeepCopy()
+	in.Spec.ForProvider = TeamParameters{
+		Org:            fp.Org,
+		OrgRef:         fp.OrgRef,
+		OrgSelector:    fp.OrgSelector,
+		Description:    fp.Description,
+		Privacy:        fp.Privacy,
+		Parent:         fp.Parent,
+		ParentRef:      fp.ParentRef,
+		ParentSelector: fp.ParentSelector,
+	}
+	in.Status.AtProvider = TeamObservation{NodeID: src.Status.AtProvider.NodeID}
+
+	return preserve(&in.ObjectMeta, &teamParameters{
+		Name:                fp.Name,
+		NotificationSetting: fp.NotificationSetting,
+		LDAPDN:              fp.LDAPDN,
+	})
+}
+
+var _ conversion.Convertible = &Membership{}
+
+// ConvertTo converts this Membership to the hub (v1beta1) version.
+func (in *Membership) ConvertTo(hub conversion.Hub) error {
+	dst := hub.(*v1beta1.Membership)
+	dst.ObjectMeta = *in.ObjectMeta.DeepCopy()
+	dst.Spec.ResourceSpec = *in.Spec.ResourceSpec.DeepCopy()
+	dst.Status.ResourceStatus = *in.Status.ResourceStatus.DeepCopy()
+
+	src := in.Spec.ForProvider.DeepCopy()
+	dst.Spec.ForProvider = v1beta1.MembershipParameters{
+		Org:          src.Org,
+		OrgRef:       src.OrgRef,
+		OrgSelector:  src.OrgSelector,
+		User:         src.User,
+		Team:         src.Team,
+		TeamRef:      src.TeamRef,
+		TeamSelector: src.TeamSelector,
+	}
+	dst.Status.AtProvider = v1beta1.MembershipObservation{State: in.Status.AtProvider.State}
+
+	p := &membershipParameters{}
+	if err := restore(&dst.ObjectMeta, p); err != nil {
+		return err
+	}
+	dst.Spec.ForProvider.Role = p.Role
+	dst.Spec.ForProvider.ReinviteOnExpiry = p.ReinviteOnExpiry
+	dst.Spec.ForProvider.UserRef = p.UserRef
+	dst.Spec.ForProvider.UserSelector = p.UserSelector
+	return nil
+}
+
+// ConvertFrom converts the hub (v1beta1) version to this Membership.
+func (in *Membership) ConvertFrom(hub conversion.Hub) error {
+	src := hub.(*v1beta1.Membership)
+	in.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	in.Spec.ResourceSpec = *src.Spec.ResourceSpec.DeepCopy()
+	in.Status.ResourceStatus = *src.Status.ResourceStatus.DeepCopy()
+
+	fp := src.Spec.ForProvider.DeepCopy()
+	in.Spec.ForProvider = MembershipParameters{
+		Org:          fp.Org,
+		OrgRef:       fp.OrgRef,
+		OrgSelector:  fp.OrgSelector,
+		User:         fp.User,
+		Team:         fp.Team,
+		TeamRef:      fp.TeamRef,
+		TeamSelector: fp.TeamSelector,
+	}
+	in.Status.AtProvider = MembershipObservation{State: src.Status.AtProvider.State}
+
+	return preserve(&in.ObjectMeta, &membershipParameters{
+		Role:             fp.Role,
+		ReinviteOnExpiry: fp.ReinviteOnExpiry,
+		UserRef:          fp.UserRef,
+		UserSelector:     fp.UserSelector,
+	})
+}
+
+var _ conversion.Convertible = &Organization{}
+
+// ConvertTo converts this Organization to the hub (v1beta1) version.
+func (in *Organization) ConvertTo(hub conversion.Hub) error {
+	dst := hub.(*v1beta1.Organization)
+	dst.ObjectMeta = *in.ObjectMeta.DeepCopy()
+	dst.Spec.ResourceSpec = *in.Spec.ResourceSpec.DeepCopy()
+	dst.Status.ResourceStatus = *in.Status.ResourceStatus.DeepCopy()
+	dst.Spec.ForProvider = v1beta1.OrganizationParameters(*in.Spec.ForProvider.DeepCopy())
+	dst.Status.AtProvider = v1beta1.OrganizationObservation(*in.Status.AtProvider.DeepCopy())
+	return nil
+}
+
+// ConvertFrom converts the hub (v1beta1) version to this Organization.
+func (in *Organization) ConvertFrom(hub conversion.Hub) error {
+	src := hub.(*v1beta1.Organization)
+	in.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	in.Spec.ResourceSpec = *src.Spec.ResourceSpec.DeepCopy()
+	in.Status.ResourceStatus = *src.Status.ResourceStatus.DeepCopy()
+	in.Spec.ForProvider = OrganizationParameters(*src.Spec.ForProvider.DeepCopy())
+	in.Status.AtProvider = OrganizationObservation(*src.Status.AtProvider.DeepCopy())
+	return nil
+}
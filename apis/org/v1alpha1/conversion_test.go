@@ -0,0 +1,154 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/hasheddan/kc-provider-github/apis/org/v1beta1"
+)
+
+func TestTeamConversion(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		hub    *v1beta1.Team
+	}{
+		"Common": {
+			reason: "A v1beta1 Team that only uses v1alpha1 fields should survive a round trip.",
+			hub: &v1beta1.Team{
+				ObjectMeta: metav1.ObjectMeta{Name: "platform", Annotations: map[string]string{"crossplane.io/external-name": "platform"}},
+				Spec: v1beta1.TeamSpec{
+					ResourceSpec: xpv1.ResourceSpec{ProviderConfigReference: &xpv1.Reference{Name: "default"}},
+					ForProvider: v1beta1.TeamParameters{
+						Org:       "crossplane",
+						Privacy:   pointer.String("closed"),
+						ParentRef: &xpv1.Reference{Name: "engineering"},
+					},
+				},
+				Status: v1beta1.TeamStatus{AtProvider: v1beta1.TeamObservation{NodeID: "T_platform"}},
+			},
+		},
+		"V1Beta1Only": {
+			reason: "The name, notification setting and LDAP DN of a v1beta1 Team should survive a round trip.",
+			hub: &v1beta1.Team{
+				ObjectMeta: metav1.ObjectMeta{Name: "platform"},
+				Spec: v1beta1.TeamSpec{
+					ForProvider: v1beta1.TeamParameters{
+						Org:                 "crossplane",
+						Name:                pointer.String("Platform"),
+						NotificationSetting: pointer.String(v1beta1.NotificationsDisabled),
+						LDAPDN:              pointer.String("cn=platform,ou=groups,dc=example,dc=org"),
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			spoke := &Team{}
+			if err := spoke.ConvertFrom(tc.hub); err != nil {
+				t.Fatalf("\n%s\nConvertFrom(...): %v", tc.reason, err)
+			}
+			got := &v1beta1.Team{}
+			if err := spoke.ConvertTo(got); err != nil {
+				t.Fatalf("\n%s\nConvertTo(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.hub, got); diff != "" {
+				t.Errorf("\n%s\nConvertTo(ConvertFrom(...)): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestMembershipConversion(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		hub    *v1beta1.Membership
+	}{
+		"Common": {
+			reason: "A v1beta1 Membership that only uses v1alpha1 fields should survive a round trip.",
+			hub: &v1beta1.Membership{
+				ObjectMeta: metav1.ObjectMeta{Name: "hubot"},
+				Spec: v1beta1.MembershipSpec{
+					ForProvider: v1beta1.MembershipParameters{Org: "crossplane", User: "hubot", Team: pointer.String("platform")},
+				},
+				Status: v1beta1.MembershipStatus{AtProvider: v1beta1.MembershipObservation{State: "active"}},
+			},
+		},
+		"Role": {
+			reason: "The role of a v1beta1 Membership should survive a round trip.",
+			hub: &v1beta1.Membership{
+				ObjectMeta: metav1.ObjectMeta{Name: "hubot", Labels: map[string]string{"team": "platform"}},
+				Spec: v1beta1.MembershipSpec{
+					ForProvider: v1beta1.MembershipParameters{Org: "crossplane", User: "hubot", Team: pointer.String("platform"), Role: pointer.String(v1beta1.RoleMaintainer)},
+				},
+			},
+		},
+		"UserRef": {
+			reason: "The user reference of a v1beta1 Membership should survive a round trip.",
+			hub: &v1beta1.Membership{
+				ObjectMeta: metav1.ObjectMeta{Name: "hubot"},
+				Spec: v1beta1.MembershipSpec{
+					ForProvider: v1beta1.MembershipParameters{Org: "crossplane", User: "hubot", UserRef: &xpv1.Reference{Name: "hubot"}, Team: pointer.String("platform")},
+				},
+			},
+		},
+		"ReinviteOnExpiry": {
+			reason: "Whether a v1beta1 Membership re-invites its user should survive a round trip.",
+			hub: &v1beta1.Membership{
+				ObjectMeta: metav1.ObjectMeta{Name: "hubot"},
+				Spec: v1beta1.MembershipSpec{
+					ForProvider: v1beta1.MembershipParameters{Org: "crossplane", User: "hubot", Team: pointer.String("platform"), ReinviteOnExpiry: pointer.Bool(true)},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			spoke := &Membership{}
+			if err := spoke.ConvertFrom(tc.hub); err != nil {
+				t.Fatalf("\n%s\nConvertFrom(...): %v", tc.reason, err)
+			}
+			got := &v1beta1.Membership{}
+			if err := spoke.ConvertTo(got); err != nil {
+				t.Fatalf("\n%s\nConvertTo(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.hub, got); diff != "" {
+				t.Errorf("\n%s\nConvertTo(ConvertFrom(...)): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestPreserve(t *testing.T) {
+	spoke := &Membership{}
+	if err := spoke.ConvertFrom(&v1beta1.Membership{Spec: v1beta1.MembershipSpec{ForProvider: v1beta1.MembershipParameters{Role: pointer.String(v1beta1.RoleMaintainer)}}}); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{AnnotationKeyV1Beta1Parameters: `{"role":"maintainer"}`}
+	if diff := cmp.Diff(want, spoke.GetAnnotations()); diff != "" {
+		t.Errorf("ConvertFrom(...): parameters that v1alpha1 cannot represent should be preserved in an annotation: -want, +got:\n%s", diff)
+	}
+}
@@ -27,7 +27,20 @@ import (
 // MembershipParameters are the configurable fields of a Membership.
 type MembershipParameters struct {
 	// The name of the organization to which the user should be added.
-	Org string `json:"org"`
+	// Defaults to the default organization of the ProviderConfig.
+	// +crossplane:generate:reference:type=github.com/hasheddan/kc-provider-github/apis/org/v1alpha1.Organization
+	// +crossplane:generate:reference:refFieldName=OrgRef
+	// +crossplane:generate:reference:selectorFieldName=OrgSelector
+	// +optional
+	Org string `json:"org,omitempty"`
+
+	// OrgRef refers to an Organization resource.
+	// +optional
+	OrgRef *xpv1.Reference `json:"orgRef,omitempty"`
+
+	// OrgSelector selects one Organization resource.
+	// +optional
+	OrgSelector *xpv1.Selector `json:"orgSelector,omitempty"`
 
 	// The name of the used to be granted membership.
 	User string `json:"user"`
@@ -64,11 +77,13 @@ type MembershipStatus struct {
 
 // +kubebuilder:object:root=true
 
-// A Membership is an example API type
+// A Membership is the membership of a user in a team. Deprecated: use the
+// v1beta1 Membership.
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:resource:scope=Cluster
+// +kubebuilder:deprecatedversion:warning="org.github.hasheddan.io/v1alpha1 Membership is deprecated; use org.github.hasheddan.io/v1beta1 Membership"
 type Membership struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -26,15 +26,43 @@ import (
 
 // TeamParameters are the configurable fields of a Team.
 type TeamParameters struct {
-	// The name of the organization this team belongs to.
-	Org string `json:"org"`
+	// The name of the organization this team belongs to. Defaults to the
+	// default organization of the ProviderConfig.
+	// +crossplane:generate:reference:type=github.com/hasheddan/kc-provider-github/apis/org/v1alpha1.Organization
+	// +crossplane:generate:reference:refFieldName=OrgRef
+	// +crossplane:generate:reference:selectorFieldName=OrgSelector
+	// +optional
+	Org string `json:"org,omitempty"`
+
+	// OrgRef refers to an Organization resource.
+	// +optional
+	OrgRef *xpv1.Reference `json:"orgRef,omitempty"`
+
+	// OrgSelector selects one Organization resource.
+	// +optional
+	OrgSelector *xpv1.Selector `json:"orgSelector,omitempty"`
 
 	// A description about the team.
 	Description *string `json:"description,omitempty"`
 
-	// The visibility of the team.
+	// The visibility of the team. Nested teams must be closed.
 	// +kubebuilder:validation:Enum=secret;closed
 	Privacy *string `json:"privacy,omitempty"`
+
+	// Parent is the slug of the team's parent team, if it is nested.
+	// +crossplane:generate:reference:type=github.com/hasheddan/kc-provider-github/apis/org/v1alpha1.Team
+	// +crossplane:generate:reference:refFieldName=ParentRef
+	// +crossplane:generate:reference:selectorFieldName=ParentSelector
+	// +optional
+	Parent *string `json:"parent,omitempty"`
+
+	// ParentRef refers to the Team resource of the team's parent team.
+	// +optional
+	ParentRef *xpv1.Reference `json:"parentRef,omitempty"`
+
+	// ParentSelector selects the Team resource of the team's parent team.
+	// +optional
+	ParentSelector *xpv1.Selector `json:"parentSelector,omitempty"`
 }
 
 // TeamObservation are the observable fields of a Team.
@@ -56,11 +84,12 @@ type TeamStatus struct {
 
 // +kubebuilder:object:root=true
 
-// A Team is an example API type
+// A Team is a team of an organization. Deprecated: use the v1beta1 Team.
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:resource:scope=Cluster
+// +kubebuilder:deprecatedversion:warning="org.github.hasheddan.io/v1alpha1 Team is deprecated; use org.github.hasheddan.io/v1beta1 Team"
 type Team struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -0,0 +1,174 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"reflect"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Repositories of an organization in which GitHub Actions is enabled.
+const (
+	EnabledRepositoriesAll      = "all"
+	EnabledRepositoriesNone     = "none"
+	EnabledRepositoriesSelected = "selected"
+)
+
+// Actions and reusable workflows that repositories of an organization may use.
+const (
+	AllowedActionsAll       = "all"
+	AllowedActionsLocalOnly = "local_only"
+	AllowedActionsSelected  = "selected"
+)
+
+// SelectedActions are the actions and reusable workflows that repositories
+// may use when only selected actions are allowed. Actions and reusable
+// workflows in the organization's own repositories are always allowed.
+type SelectedActions struct {
+	// Whether actions created by GitHub are allowed.
+	// +optional
+	GitHubOwnedAllowed *bool `json:"githubOwnedAllowed,omitempty"`
+
+	// Whether actions by verified creators in the GitHub Marketplace are
+	// allowed.
+	// +optional
+	VerifiedAllowed *bool `json:"verifiedAllowed,omitempty"`
+
+	// Patterns matching the actions and reusable workflows that are allowed,
+	// for example monalisa/octocat@* or monalisa/*.
+	// +optional
+	PatternsAllowed []string `json:"patternsAllowed,omitempty"`
+}
+
+// ActionsOrganizationPermissionsParameters are the configurable fields of an
+// ActionsOrganizationPermissions.
+type ActionsOrganizationPermissionsParameters struct {
+	// The name of the organization. Defaults to the default organization of
+	// the ProviderConfig.
+	// +crossplane:generate:reference:type=Organization
+	// +crossplane:generate:reference:refFieldName=OrgRef
+	// +crossplane:generate:reference:selectorFieldName=OrgSelector
+	// +optional
+	Org string `json:"org,omitempty"`
+
+	// OrgRef refers to an Organization resource.
+	// +optional
+	OrgRef *xpv1.Reference `json:"orgRef,omitempty"`
+
+	// OrgSelector selects one Organization resource.
+	// +optional
+	OrgSelector *xpv1.Selector `json:"orgSelector,omitempty"`
+
+	// The repositories in which GitHub Actions is enabled.
+	// +kubebuilder:validation:Enum=all;none;selected
+	EnabledRepositories string `json:"enabledRepositories"`
+
+	// The names of the repositories in which GitHub Actions is enabled when
+	// enabledRepositories is selected.
+	// +crossplane:generate:reference:type=Repository
+	// +crossplane:generate:reference:refFieldName=SelectedRepositoryRefs
+	// +crossplane:generate:reference:selectorFieldName=SelectedRepositorySelector
+	// +optional
+	SelectedRepositories []string `json:"selectedRepositories,omitempty"`
+
+	// SelectedRepositoryRefs refer to Repository resources.
+	// +optional
+	SelectedRepositoryRefs []xpv1.Reference `json:"selectedRepositoryRefs,omitempty"`
+
+	// SelectedRepositorySelector selects Repository resources.
+	// +optional
+	SelectedRepositorySelector *xpv1.Selector `json:"selectedRepositorySelector,omitempty"`
+
+	// The actions and reusable workflows that repositories may use. Defaults
+	// to all.
+	// +kubebuilder:validation:Enum=all;local_only;selected
+	// +optional
+	AllowedActions *string `json:"allowedActions,omitempty"`
+
+	// The actions and reusable workflows that repositories may use when
+	// allowedActions is selected.
+	// +optional
+	SelectedActions *SelectedActions `json:"selectedActions,omitempty"`
+}
+
+// ActionsOrganizationPermissionsObservation are the observable fields of an
+// ActionsOrganizationPermissions.
+type ActionsOrganizationPermissionsObservation struct {
+	// The IDs of the repositories in which GitHub Actions is enabled when
+	// enabledRepositories is selected.
+	SelectedRepositoryIDs []int64 `json:"selectedRepositoryIds,omitempty"`
+}
+
+// An ActionsOrganizationPermissionsSpec defines the desired state of an
+// ActionsOrganizationPermissions.
+type ActionsOrganizationPermissionsSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       ActionsOrganizationPermissionsParameters `json:"forProvider"`
+}
+
+// An ActionsOrganizationPermissionsStatus represents the observed state of an
+// ActionsOrganizationPermissions.
+type ActionsOrganizationPermissionsStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          ActionsOrganizationPermissionsObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// An ActionsOrganizationPermissions configures the repositories of an
+// organization in which GitHub Actions is enabled, and the actions they may
+// use. An organization has exactly one set of Actions permissions; they cannot
+// be created or deleted, only managed.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="ENABLED",type="string",JSONPath=".spec.forProvider.enabledRepositories"
+// +kubebuilder:printcolumn:name="ALLOWED",type="string",JSONPath=".spec.forProvider.allowedActions"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster
+type ActionsOrganizationPermissions struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ActionsOrganizationPermissionsSpec   `json:"spec"`
+	Status ActionsOrganizationPermissionsStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ActionsOrganizationPermissionsList contains a list of
+// ActionsOrganizationPermissions
+type ActionsOrganizationPermissionsList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ActionsOrganizationPermissions `json:"items"`
+}
+
+// ActionsOrganizationPermissions type metadata.
+var (
+	ActionsOrganizationPermissionsKind             = reflect.TypeOf(ActionsOrganizationPermissions{}).Name()
+	ActionsOrganizationPermissionsGroupKind        = schema.GroupKind{Group: Group, Kind: ActionsOrganizationPermissionsKind}.String()
+	ActionsOrganizationPermissionsKindAPIVersion   = ActionsOrganizationPermissionsKind + "." + SchemeGroupVersion.String()
+	ActionsOrganizationPermissionsGroupVersionKind = SchemeGroupVersion.WithKind(ActionsOrganizationPermissionsKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&ActionsOrganizationPermissions{}, &ActionsOrganizationPermissionsList{})
+}
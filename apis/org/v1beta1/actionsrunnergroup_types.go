@@ -0,0 +1,158 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"reflect"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Visibilities of a self-hosted runner group.
+const (
+	RunnerGroupVisibilityAll      = "all"
+	RunnerGroupVisibilitySelected = "selected"
+)
+
+// ActionsRunnerGroupParameters are the configurable fields of an
+// ActionsRunnerGroup.
+type ActionsRunnerGroupParameters struct {
+	// The name of the organization. Defaults to the default organization of
+	// the ProviderConfig.
+	// +crossplane:generate:reference:type=Organization
+	// +crossplane:generate:reference:refFieldName=OrgRef
+	// +crossplane:generate:reference:selectorFieldName=OrgSelector
+	// +optional
+	Org string `json:"org,omitempty"`
+
+	// OrgRef refers to an Organization resource.
+	// +optional
+	OrgRef *xpv1.Reference `json:"orgRef,omitempty"`
+
+	// OrgSelector selects one Organization resource.
+	// +optional
+	OrgSelector *xpv1.Selector `json:"orgSelector,omitempty"`
+
+	// The name of the runner group, which is unique within the organization.
+	// An existing runner group with this name is adopted.
+	Name string `json:"name"`
+
+	// The repositories that may use the runner group. Defaults to all.
+	// +kubebuilder:validation:Enum=all;selected
+	// +optional
+	Visibility *string `json:"visibility,omitempty"`
+
+	// The names of the repositories that may use the runner group when its
+	// visibility is selected.
+	// +crossplane:generate:reference:type=Repository
+	// +crossplane:generate:reference:refFieldName=SelectedRepositoryRefs
+	// +crossplane:generate:reference:selectorFieldName=SelectedRepositorySelector
+	// +optional
+	SelectedRepositories []string `json:"selectedRepositories,omitempty"`
+
+	// SelectedRepositoryRefs refer to Repository resources.
+	// +optional
+	SelectedRepositoryRefs []xpv1.Reference `json:"selectedRepositoryRefs,omitempty"`
+
+	// SelectedRepositorySelector selects Repository resources.
+	// +optional
+	SelectedRepositorySelector *xpv1.Selector `json:"selectedRepositorySelector,omitempty"`
+
+	// Whether public repositories may use the runner group.
+	// +optional
+	AllowsPublicRepositories *bool `json:"allowsPublicRepositories,omitempty"`
+
+	// Whether only the selectedWorkflows may use the runner group.
+	// +optional
+	RestrictedToWorkflows *bool `json:"restrictedToWorkflows,omitempty"`
+
+	// The workflows that may use the runner group when restrictedToWorkflows
+	// is true, for example
+	// my-org/my-service/.github/workflows/deploy.yaml@main.
+	// +optional
+	SelectedWorkflows []string `json:"selectedWorkflows,omitempty"`
+}
+
+// ActionsRunnerGroupObservation are the observable fields of an
+// ActionsRunnerGroup.
+type ActionsRunnerGroupObservation struct {
+	// The numeric ID of the runner group.
+	ID int64 `json:"id,omitempty"`
+
+	// When the registration token last published to the connection secret
+	// expires.
+	RegistrationTokenExpiresAt *metav1.Time `json:"registrationTokenExpiresAt,omitempty"`
+}
+
+// An ActionsRunnerGroupSpec defines the desired state of an
+// ActionsRunnerGroup.
+type ActionsRunnerGroupSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       ActionsRunnerGroupParameters `json:"forProvider"`
+}
+
+// An ActionsRunnerGroupStatus represents the observed state of an
+// ActionsRunnerGroup.
+type ActionsRunnerGroupStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          ActionsRunnerGroupObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// An ActionsRunnerGroup is a group of self-hosted GitHub Actions runners of an
+// organization, which controls the repositories and workflows that may use
+// them. Its external name is the ID of the runner group. Its connection secret
+// holds a token with which self-hosted runners may register with the
+// organization.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="GROUP",type="string",JSONPath=".spec.forProvider.name"
+// +kubebuilder:printcolumn:name="VISIBILITY",type="string",JSONPath=".spec.forProvider.visibility"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster
+type ActionsRunnerGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ActionsRunnerGroupSpec   `json:"spec"`
+	Status ActionsRunnerGroupStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ActionsRunnerGroupList contains a list of ActionsRunnerGroup
+type ActionsRunnerGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ActionsRunnerGroup `json:"items"`
+}
+
+// ActionsRunnerGroup type metadata.
+var (
+	ActionsRunnerGroupKind             = reflect.TypeOf(ActionsRunnerGroup{}).Name()
+	ActionsRunnerGroupGroupKind        = schema.GroupKind{Group: Group, Kind: ActionsRunnerGroupKind}.String()
+	ActionsRunnerGroupKindAPIVersion   = ActionsRunnerGroupKind + "." + SchemeGroupVersion.String()
+	ActionsRunnerGroupGroupVersionKind = SchemeGroupVersion.WithKind(ActionsRunnerGroupKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&ActionsRunnerGroup{}, &ActionsRunnerGroupList{})
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"reflect"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// BlockedUserParameters are the configurable fields of a BlockedUser.
+type BlockedUserParameters struct {
+	// The name of the organization. Defaults to the default organization of
+	// the ProviderConfig.
+	// +crossplane:generate:reference:type=Organization
+	// +crossplane:generate:reference:refFieldName=OrgRef
+	// +crossplane:generate:reference:selectorFieldName=OrgSelector
+	// +optional
+	Org string `json:"org,omitempty"`
+
+	// OrgRef refers to an Organization resource.
+	// +optional
+	OrgRef *xpv1.Reference `json:"orgRef,omitempty"`
+
+	// OrgSelector selects one Organization resource.
+	// +optional
+	OrgSelector *xpv1.Selector `json:"orgSelector,omitempty"`
+
+	// The username of the user to be blocked.
+	// +crossplane:generate:reference:type=User
+	// +crossplane:generate:reference:extractor=UserLogin()
+	// +crossplane:generate:reference:refFieldName=UserRef
+	// +crossplane:generate:reference:selectorFieldName=UserSelector
+	// +optional
+	User string `json:"user,omitempty"`
+
+	// UserRef refers to a User resource.
+	// +optional
+	UserRef *xpv1.Reference `json:"userRef,omitempty"`
+
+	// UserSelector selects one User resource.
+	// +optional
+	UserSelector *xpv1.Selector `json:"userSelector,omitempty"`
+}
+
+// BlockedUserObservation are the observable fields of a BlockedUser. A user
+// is either blocked or not, so there are none.
+type BlockedUserObservation struct{}
+
+// A BlockedUserSpec defines the desired state of a BlockedUser.
+type BlockedUserSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       BlockedUserParameters `json:"forProvider"`
+}
+
+// A BlockedUserStatus represents the observed state of a BlockedUser.
+type BlockedUserStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          BlockedUserObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A BlockedUser blocks a user from an organization.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="USER",type="string",JSONPath=".spec.forProvider.user"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster
+type BlockedUser struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BlockedUserSpec   `json:"spec"`
+	Status BlockedUserStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// BlockedUserList contains a list of BlockedUser
+type BlockedUserList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BlockedUser `json:"items"`
+}
+
+// BlockedUser type metadata.
+var (
+	BlockedUserKind             = reflect.TypeOf(BlockedUser{}).Name()
+	BlockedUserGroupKind        = schema.GroupKind{Group: Group, Kind: BlockedUserKind}.String()
+	BlockedUserKindAPIVersion   = BlockedUserKind + "." + SchemeGroupVersion.String()
+	BlockedUserGroupVersionKind = SchemeGroupVersion.WithKind(BlockedUserKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&BlockedUser{}, &BlockedUserList{})
+}
@@ -0,0 +1,203 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"reflect"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// ReasonInvalidOwners indicates a CODEOWNERS file names owners GitHub cannot
+// request reviews from.
+const ReasonInvalidOwners xpv1.ConditionReason = "InvalidOwners"
+
+// InvalidOwners returns a condition that indicates a CODEOWNERS file names
+// owners that do not exist, or lack write access to the repository.
+func InvalidOwners() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               xpv1.TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonInvalidOwners,
+	}
+}
+
+// A CodeOwnersRule assigns the owners of the files matching a pattern.
+type CodeOwnersRule struct {
+	// The pattern of the files the rule applies to, for example *.go or
+	// /docs/. Later rules take precedence over earlier ones.
+	Pattern string `json:"pattern"`
+
+	// Teams are the slugs of the teams that own matching files.
+	// +crossplane:generate:reference:type=Team
+	// +crossplane:generate:reference:refFieldName=TeamRefs
+	// +crossplane:generate:reference:selectorFieldName=TeamSelector
+	// +optional
+	Teams []string `json:"teams,omitempty"`
+
+	// TeamRefs refer to Team resources.
+	// +optional
+	TeamRefs []xpv1.Reference `json:"teamRefs,omitempty"`
+
+	// TeamSelector selects Team resources.
+	// +optional
+	TeamSelector *xpv1.Selector `json:"teamSelector,omitempty"`
+
+	// Users are the usernames of the users who own matching files.
+	// +crossplane:generate:reference:type=User
+	// +crossplane:generate:reference:extractor=UserLogin()
+	// +crossplane:generate:reference:refFieldName=UserRefs
+	// +crossplane:generate:reference:selectorFieldName=UserSelector
+	// +optional
+	Users []string `json:"users,omitempty"`
+
+	// UserRefs refer to User resources.
+	// +optional
+	UserRefs []xpv1.Reference `json:"userRefs,omitempty"`
+
+	// UserSelector selects User resources.
+	// +optional
+	UserSelector *xpv1.Selector `json:"userSelector,omitempty"`
+}
+
+// CodeOwnersParameters are the configurable fields of a CodeOwners.
+type CodeOwnersParameters struct {
+	// The name of the organization that owns the repository, and its teams.
+	// Defaults to the default organization of the ProviderConfig.
+	// +crossplane:generate:reference:type=Organization
+	// +crossplane:generate:reference:refFieldName=OrgRef
+	// +crossplane:generate:reference:selectorFieldName=OrgSelector
+	// +optional
+	Org string `json:"org,omitempty"`
+
+	// OrgRef refers to an Organization resource.
+	// +optional
+	OrgRef *xpv1.Reference `json:"orgRef,omitempty"`
+
+	// OrgSelector selects one Organization resource.
+	// +optional
+	OrgSelector *xpv1.Selector `json:"orgSelector,omitempty"`
+
+	// The name of the repository.
+	Repository string `json:"repository"`
+
+	// The branch on which the CODEOWNERS file is committed. Defaults to the
+	// default branch of the repository.
+	// +optional
+	Branch *string `json:"branch,omitempty"`
+
+	// The path of the CODEOWNERS file.
+	// +kubebuilder:validation:Enum=.github/CODEOWNERS;CODEOWNERS;docs/CODEOWNERS
+	// +kubebuilder:default=.github/CODEOWNERS
+	// +optional
+	Path *string `json:"path,omitempty"`
+
+	// Rules are rendered, in order, as the lines of the CODEOWNERS file.
+	Rules []CodeOwnersRule `json:"rules"`
+
+	// The message of the commits that create or update the CODEOWNERS file.
+	// Defaults to "Create <path>" or "Update <path>".
+	// +optional
+	CommitMessage *string `json:"commitMessage,omitempty"`
+
+	// The author of the commits that create, update, or delete the
+	// CODEOWNERS file. Defaults to the user or app the provider
+	// authenticates as.
+	// +optional
+	CommitAuthor *CommitAuthor `json:"commitAuthor,omitempty"`
+}
+
+// A CodeOwnersError is an error GitHub found in a CODEOWNERS file.
+type CodeOwnersError struct {
+	// The line of the file on which the error was found.
+	Line int `json:"line"`
+
+	// The kind of error, for example Unknown owner.
+	Kind string `json:"kind"`
+
+	// A message that describes the error.
+	Message string `json:"message,omitempty"`
+}
+
+// CodeOwnersObservation are the observable fields of a CodeOwners.
+type CodeOwnersObservation struct {
+	// The blob SHA of the CODEOWNERS file on the branch.
+	SHA string `json:"sha,omitempty"`
+
+	// The SHA of the commit with which the provider last committed the
+	// CODEOWNERS file.
+	CommitSHA string `json:"commitSha,omitempty"`
+
+	// Errors GitHub found in the CODEOWNERS file, such as owners that do
+	// not exist or lack write access to the repository.
+	Errors []CodeOwnersError `json:"errors,omitempty"`
+}
+
+// A CodeOwnersSpec defines the desired state of a CodeOwners.
+type CodeOwnersSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       CodeOwnersParameters `json:"forProvider"`
+}
+
+// A CodeOwnersStatus represents the observed state of a CodeOwners.
+type CodeOwnersStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          CodeOwnersObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A CodeOwners is a CODEOWNERS file of a repository, rendered from rules that
+// assign teams and users as the owners of its files.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="REPOSITORY",type="string",JSONPath=".spec.forProvider.repository"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster
+type CodeOwners struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CodeOwnersSpec   `json:"spec"`
+	Status CodeOwnersStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CodeOwnersList contains a list of CodeOwners
+type CodeOwnersList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CodeOwners `json:"items"`
+}
+
+// CodeOwners type metadata.
+var (
+	CodeOwnersKind             = reflect.TypeOf(CodeOwners{}).Name()
+	CodeOwnersGroupKind        = schema.GroupKind{Group: Group, Kind: CodeOwnersKind}.String()
+	CodeOwnersKindAPIVersion   = CodeOwnersKind + "." + SchemeGroupVersion.String()
+	CodeOwnersGroupVersionKind = SchemeGroupVersion.WithKind(CodeOwnersKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&CodeOwners{}, &CodeOwnersList{})
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"reflect"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Base roles from which a custom repository role inherits its permissions.
+const (
+	BaseRoleRead     = "read"
+	BaseRoleTriage   = "triage"
+	BaseRoleWrite    = "write"
+	BaseRoleMaintain = "maintain"
+)
+
+// CustomRepositoryRoleParameters are the configurable fields of a
+// CustomRepositoryRole.
+type CustomRepositoryRoleParameters struct {
+	// The name of the organization. Defaults to the default organization of
+	// the ProviderConfig.
+	// +crossplane:generate:reference:type=Organization
+	// +crossplane:generate:reference:refFieldName=OrgRef
+	// +crossplane:generate:reference:selectorFieldName=OrgSelector
+	// +optional
+	Org string `json:"org,omitempty"`
+
+	// OrgRef refers to an Organization resource.
+	// +optional
+	OrgRef *xpv1.Reference `json:"orgRef,omitempty"`
+
+	// OrgSelector selects one Organization resource.
+	// +optional
+	OrgSelector *xpv1.Selector `json:"orgSelector,omitempty"`
+
+	// The name of the role, which is unique within the organization. An
+	// existing role with this name is adopted.
+	Name string `json:"name"`
+
+	// A description of the role.
+	// +optional
+	Description *string `json:"description,omitempty"`
+
+	// The role from which the role inherits its permissions.
+	// +kubebuilder:validation:Enum=read;triage;write;maintain
+	BaseRole string `json:"baseRole"`
+
+	// The fine-grained permissions the role grants in addition to those of
+	// its base role, for example delete_alerts_code_scanning.
+	// +optional
+	Permissions []string `json:"permissions,omitempty"`
+}
+
+// CustomRepositoryRoleObservation are the observable fields of a
+// CustomRepositoryRole.
+type CustomRepositoryRoleObservation struct {
+	// The numeric ID of the role.
+	ID int64 `json:"id,omitempty"`
+}
+
+// A CustomRepositoryRoleSpec defines the desired state of a
+// CustomRepositoryRole.
+type CustomRepositoryRoleSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       CustomRepositoryRoleParameters `json:"forProvider"`
+}
+
+// A CustomRepositoryRoleStatus represents the observed state of a
+// CustomRepositoryRole.
+type CustomRepositoryRoleStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          CustomRepositoryRoleObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A CustomRepositoryRole is a repository role of an organization that grants
+// fine-grained permissions in addition to those of a base role. Its external
+// name is the ID of the role.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="ROLE",type="string",JSONPath=".spec.forProvider.name"
+// +kubebuilder:printcolumn:name="BASE-ROLE",type="string",JSONPath=".spec.forProvider.baseRole"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster
+type CustomRepositoryRole struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CustomRepositoryRoleSpec   `json:"spec"`
+	Status CustomRepositoryRoleStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CustomRepositoryRoleList contains a list of CustomRepositoryRole
+type CustomRepositoryRoleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CustomRepositoryRole `json:"items"`
+}
+
+// CustomRepositoryRoleName extracts the name of a CustomRepositoryRole, by
+// which repository permission fields refer to it.
+func CustomRepositoryRoleName() reference.ExtractValueFn {
+	return func(mg resource.Managed) string {
+		r, ok := mg.(*CustomRepositoryRole)
+		if !ok {
+			return ""
+		}
+		return r.Spec.ForProvider.Name
+	}
+}
+
+// CustomRepositoryRole type metadata.
+var (
+	CustomRepositoryRoleKind             = reflect.TypeOf(CustomRepositoryRole{}).Name()
+	CustomRepositoryRoleGroupKind        = schema.GroupKind{Group: Group, Kind: CustomRepositoryRoleKind}.String()
+	CustomRepositoryRoleKindAPIVersion   = CustomRepositoryRoleKind + "." + SchemeGroupVersion.String()
+	CustomRepositoryRoleGroupVersionKind = SchemeGroupVersion.WithKind(CustomRepositoryRoleKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&CustomRepositoryRole{}, &CustomRepositoryRoleList{})
+}
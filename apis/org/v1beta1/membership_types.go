@@ -0,0 +1,187 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"reflect"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Roles of a member of a team.
+const (
+	RoleMember     = "member"
+	RoleMaintainer = "maintainer"
+)
+
+// Reasons a Membership or OrganizationInvitation may not be ready.
+const (
+	ReasonInvitationPending xpv1.ConditionReason = "InvitationPending"
+	ReasonInvitationExpired xpv1.ConditionReason = "InvitationExpired"
+)
+
+// InvitationPending returns a condition that indicates a user was invited to
+// the organization, but has yet to accept.
+func InvitationPending() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               xpv1.TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonInvitationPending,
+	}
+}
+
+// InvitationExpired returns a condition that indicates a user did not accept
+// their invitation to the organization before it expired.
+func InvitationExpired() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               xpv1.TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonInvitationExpired,
+	}
+}
+
+// MembershipParameters are the configurable fields of a Membership.
+type MembershipParameters struct {
+	// The name of the organization to which the user should be added.
+	// Defaults to the default organization of the ProviderConfig.
+	// +crossplane:generate:reference:type=Organization
+	// +crossplane:generate:reference:refFieldName=OrgRef
+	// +crossplane:generate:reference:selectorFieldName=OrgSelector
+	// +optional
+	Org string `json:"org,omitempty"`
+
+	// OrgRef refers to an Organization resource.
+	// +optional
+	OrgRef *xpv1.Reference `json:"orgRef,omitempty"`
+
+	// OrgSelector selects one Organization resource.
+	// +optional
+	OrgSelector *xpv1.Selector `json:"orgSelector,omitempty"`
+
+	// The username of the user to be granted membership.
+	// +crossplane:generate:reference:type=User
+	// +crossplane:generate:reference:extractor=UserLogin()
+	// +crossplane:generate:reference:refFieldName=UserRef
+	// +crossplane:generate:reference:selectorFieldName=UserSelector
+	// +optional
+	User string `json:"user,omitempty"`
+
+	// UserRef refers to a User resource. Its policy should resolve Always
+	// for the Membership to follow the user if they change their username.
+	// +optional
+	UserRef *xpv1.Reference `json:"userRef,omitempty"`
+
+	// UserSelector selects one User resource.
+	// +optional
+	UserSelector *xpv1.Selector `json:"userSelector,omitempty"`
+
+	// Team is the slug of the team to which the user should be added.
+	// +crossplane:generate:reference:type=Team
+	// +crossplane:generate:reference:refFieldName=TeamRef
+	// +crossplane:generate:reference:selectorFieldName=TeamSelector
+	// +optional
+	Team *string `json:"team,omitempty"`
+
+	// TeamRef refers to a Team resource.
+	// +optional
+	TeamRef *xpv1.Reference `json:"teamRef,omitempty"`
+
+	// TeamSelector selects one Team resource.
+	// +optional
+	TeamSelector *xpv1.Selector `json:"teamSelector,omitempty"`
+
+	// The role of the user in the team.
+	// +kubebuilder:validation:Enum=member;maintainer
+	// +kubebuilder:default=member
+	// +optional
+	Role *string `json:"role,omitempty"`
+
+	// ReinviteOnExpiry controls whether a user who does not accept their
+	// invitation to the organization before it expires, after 7 days, is
+	// invited again.
+	// +optional
+	ReinviteOnExpiry *bool `json:"reinviteOnExpiry,omitempty"`
+}
+
+// MembershipObservation are the observable fields of a Membership.
+type MembershipObservation struct {
+	// The state of the membership: active, or pending until the user accepts
+	// an invitation to the organization.
+	State string `json:"state,omitempty"`
+
+	// InvitationExpiresAt is the time at which the user's invitation to the
+	// organization expires, while their membership is pending.
+	InvitationExpiresAt *metav1.Time `json:"invitationExpiresAt,omitempty"`
+}
+
+// A MembershipSpec defines the desired state of a Membership.
+type MembershipSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       MembershipParameters `json:"forProvider"`
+}
+
+// A MembershipStatus represents the observed state of a Membership.
+type MembershipStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          MembershipObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Membership is a user's membership of a team.
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="TEAM",type="string",JSONPath=".spec.forProvider.team"
+// +kubebuilder:printcolumn:name="USER",type="string",JSONPath=".spec.forProvider.user"
+// +kubebuilder:printcolumn:name="ROLE",type="string",JSONPath=".spec.forProvider.role"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster
+type Membership struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   MembershipSpec   `json:"spec"`
+	Status MembershipStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// MembershipList contains a list of Membership
+type MembershipList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Membership `json:"items"`
+}
+
+// Membership type metadata.
+var (
+	MembershipKind             = reflect.TypeOf(Membership{}).Name()
+	MembershipGroupKind        = schema.GroupKind{Group: Group, Kind: MembershipKind}.String()
+	MembershipKindAPIVersion   = MembershipKind + "." + SchemeGroupVersion.String()
+	MembershipGroupVersionKind = SchemeGroupVersion.WithKind(MembershipKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&Membership{}, &MembershipList{})
+}
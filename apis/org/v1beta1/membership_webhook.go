@@ -0,0 +1,98 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"regexp"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// A GitHub username may only contain alphanumeric characters or single
+// hyphens, cannot begin or end with a hyphen, and is at most 39 characters.
+var username = regexp.MustCompile(`^[a-zA-Z0-9]+(-[a-zA-Z0-9]+)*$`)
+
+const maxUsernameLength = 39
+
+// SetupWebhookWithManager adds the Membership validating and conversion webhooks
+// to the supplied manager's webhook server.
+func (in *Membership) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(in).Complete()
+}
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-org-github-hasheddan-io-v1beta1-membership,mutating=false,failurePolicy=fail,sideEffects=None,groups=org.github.hasheddan.io,resources=memberships,versions=v1beta1,name=memberships.org.github.hasheddan.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &Membership{}
+
+// ValidateCreate validates a Membership that is being created.
+func (in *Membership) ValidateCreate() error {
+	return in.invalid(in.validate())
+}
+
+// ValidateUpdate validates the fields of a Membership that are being updated.
+func (in *Membership) ValidateUpdate(old runtime.Object) error {
+	o, ok := old.(*Membership)
+	if !ok || in.GetDeletionTimestamp() != nil {
+		return nil
+	}
+	fp := field.NewPath("spec", "forProvider")
+	p := in.Spec.ForProvider
+	errs := changed(in.validate(), o.validate())
+	errs = append(errs, immutable(fp.Child("org"), o.Spec.ForProvider.Org, p.Org, p.OrgRef != nil || p.OrgSelector != nil)...)
+	// A Membership that references a User follows their username.
+	if p.UserRef == nil && p.UserSelector == nil {
+		errs = append(errs, immutable(fp.Child("user"), o.Spec.ForProvider.User, p.User, false)...)
+	}
+	errs = append(errs, immutable(fp.Child("team"), pointer.StringDeref(o.Spec.ForProvider.Team, ""), pointer.StringDeref(p.Team, ""), p.TeamRef != nil || p.TeamSelector != nil)...)
+	return in.invalid(errs)
+}
+
+// ValidateDelete validates a Membership that is being deleted.
+func (in *Membership) ValidateDelete() error {
+	return nil
+}
+
+func (in *Membership) validate() field.ErrorList {
+	fp := field.NewPath("spec", "forProvider")
+	p := in.Spec.ForProvider
+
+	var errs field.ErrorList
+	if p.Team == nil && p.TeamRef == nil && p.TeamSelector == nil {
+		errs = append(errs, field.Required(fp.Child("team"), "one of team, teamRef or teamSelector is required"))
+	}
+	switch {
+	case p.User == "" && p.UserRef == nil && p.UserSelector == nil:
+		errs = append(errs, field.Required(fp.Child("user"), "one of user, userRef or userSelector is required"))
+	case p.User == "":
+		// The user will be resolved from the referenced User.
+	case len(p.User) > maxUsernameLength || !username.MatchString(p.User):
+		errs = append(errs, field.Invalid(fp.Child("user"), p.User, "must be a GitHub username: at most 39 alphanumeric characters or single hyphens, and cannot begin or end with a hyphen"))
+	}
+	return errs
+}
+
+func (in *Membership) invalid(errs field.ErrorList) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(MembershipGroupVersionKind.GroupKind(), in.GetName(), errs)
+}
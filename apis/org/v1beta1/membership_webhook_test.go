@@ -0,0 +1,146 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+func TestMembershipValidate(t *testing.T) {
+	membership := func(user string, team *string) *Membership {
+		return &Membership{Spec: MembershipSpec{ForProvider: MembershipParameters{Org: "crossplane", User: user, Team: team}}}
+	}
+
+	cases := map[string]struct {
+		reason string
+		old    *Membership
+		new    *Membership
+		valid  bool
+	}{
+		"Valid": {
+			reason: "A membership of a team by a valid user should be valid.",
+			new:    membership("hu-bot", pointer.String("platform")),
+			valid:  true,
+		},
+		"TeamRef": {
+			reason: "A membership of a referenced team should be valid.",
+			new: &Membership{Spec: MembershipSpec{ForProvider: MembershipParameters{
+				Org: "crossplane", User: "hubot", TeamRef: &xpv1.Reference{Name: "platform"},
+			}}},
+			valid: true,
+		},
+		"UserRef": {
+			reason: "A membership of a referenced user, whose username is yet to be resolved, should be valid.",
+			new: &Membership{Spec: MembershipSpec{ForProvider: MembershipParameters{
+				Org: "crossplane", UserRef: &xpv1.Reference{Name: "hubot"}, Team: pointer.String("platform"),
+			}}},
+			valid: true,
+		},
+		"NoUser": {
+			reason: "A membership of no user should be invalid.",
+			new:    membership("", pointer.String("platform")),
+		},
+		"NoTeam": {
+			reason: "A membership of no team should be invalid.",
+			new:    membership("hubot", nil),
+		},
+		"LeadingHyphen": {
+			reason: "A username may not begin with a hyphen.",
+			new:    membership("-hubot", pointer.String("platform")),
+		},
+		"ConsecutiveHyphens": {
+			reason: "A username may not contain consecutive hyphens.",
+			new:    membership("hu--bot", pointer.String("platform")),
+		},
+		"InvalidCharacter": {
+			reason: "A username may only contain alphanumeric characters or hyphens.",
+			new:    membership("hu_bot", pointer.String("platform")),
+		},
+		"TooLong": {
+			reason: "A username may be at most 39 characters.",
+			new:    membership(strings.Repeat("a", 40), pointer.String("platform")),
+		},
+		"UserChanged": {
+			reason: "The user of an existing membership should be immutable.",
+			old:    membership("hubot", pointer.String("platform")),
+			new:    membership("monalisa", pointer.String("platform")),
+		},
+		"ReferencedUserChanged": {
+			reason: "The user of a membership that references a User should follow their username.",
+			old: &Membership{Spec: MembershipSpec{ForProvider: MembershipParameters{
+				Org: "crossplane", User: "hubot", UserRef: &xpv1.Reference{Name: "hubot"}, Team: pointer.String("platform"),
+			}}},
+			new: &Membership{Spec: MembershipSpec{ForProvider: MembershipParameters{
+				Org: "crossplane", User: "hubot-renamed", UserRef: &xpv1.Reference{Name: "hubot"}, Team: pointer.String("platform"),
+			}}},
+			valid: true,
+		},
+		"TeamChanged": {
+			reason: "The team of an existing membership should be immutable.",
+			old:    membership("hubot", pointer.String("platform")),
+			new:    membership("hubot", pointer.String("security")),
+		},
+		"TeamResolved": {
+			reason: "The team of an existing membership may be set once when it is resolved from a reference.",
+			old: &Membership{Spec: MembershipSpec{ForProvider: MembershipParameters{
+				Org: "crossplane", User: "hubot", TeamRef: &xpv1.Reference{Name: "platform"},
+			}}},
+			new: &Membership{Spec: MembershipSpec{ForProvider: MembershipParameters{
+				Org: "crossplane", User: "hubot", Team: pointer.String("platform"), TeamRef: &xpv1.Reference{Name: "platform"},
+			}}},
+			valid: true,
+		},
+		"Unchanged": {
+			reason: "An update should not be rejected because of a username it does not change.",
+			old:    membership("hu_bot", pointer.String("platform")),
+			new: &Membership{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"cool": "true"}},
+				Spec:       MembershipSpec{ForProvider: MembershipParameters{Org: "crossplane", User: "hu_bot", Team: pointer.String("platform")}},
+			},
+			valid: true,
+		},
+		"Deleting": {
+			reason: "A membership that is being deleted should not be validated.",
+			old:    membership("hubot", pointer.String("platform")),
+			new: &Membership{
+				ObjectMeta: metav1.ObjectMeta{DeletionTimestamp: &metav1.Time{}},
+				Spec:       MembershipSpec{ForProvider: MembershipParameters{Org: "crossplane", User: "monalisa"}},
+			},
+			valid: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var err error
+			if tc.old == nil {
+				err = tc.new.ValidateCreate()
+			} else {
+				err = tc.new.ValidateUpdate(tc.old)
+			}
+			if (err == nil) != tc.valid {
+				t.Errorf("\n%s\nValidate(...): want valid %t, got error %v", tc.reason, tc.valid, err)
+			}
+		})
+	}
+}
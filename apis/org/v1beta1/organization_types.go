@@ -0,0 +1,122 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"reflect"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// OrganizationParameters are the configurable fields of an Organization.
+type OrganizationParameters struct {
+	// The email address to which the organization's bills are sent.
+	BillingEmail *string `json:"billingEmail,omitempty"`
+
+	// The URL of the organization's website.
+	Blog *string `json:"blog,omitempty"`
+
+	// The location of the organization.
+	Location *string `json:"location,omitempty"`
+
+	// A description about the organization.
+	Description *string `json:"description,omitempty"`
+
+	// The permission members of the organization have to its repositories.
+	// +kubebuilder:validation:Enum=read;write;admin;none
+	DefaultRepositoryPermission *string `json:"defaultRepositoryPermission,omitempty"`
+
+	// Whether members of the organization can create repositories.
+	MembersCanCreateRepositories *bool `json:"membersCanCreateRepositories,omitempty"`
+
+	// Whether members of the organization can create public repositories.
+	MembersCanCreatePublicRepositories *bool `json:"membersCanCreatePublicRepositories,omitempty"`
+
+	// Whether members of the organization can create private repositories.
+	MembersCanCreatePrivateRepositories *bool `json:"membersCanCreatePrivateRepositories,omitempty"`
+
+	// Whether members of the organization can create internal repositories.
+	// Only organizations owned by an enterprise have internal repositories.
+	MembersCanCreateInternalRepositories *bool `json:"membersCanCreateInternalRepositories,omitempty"`
+
+	// Whether contributors must sign off on commits made via the web UI.
+	WebCommitSignoffRequired *bool `json:"webCommitSignoffRequired,omitempty"`
+}
+
+// OrganizationObservation are the observable fields of an Organization.
+type OrganizationObservation struct {
+	NodeID string `json:"nodeId,omitempty"`
+
+	// Whether members of the organization must enable two-factor
+	// authentication. It can only be changed in the GitHub web UI.
+	TwoFactorRequirementEnabled *bool `json:"twoFactorRequirementEnabled,omitempty"`
+}
+
+// An OrganizationSpec defines the desired state of an Organization.
+type OrganizationSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       OrganizationParameters `json:"forProvider"`
+}
+
+// An OrganizationStatus represents the observed state of an Organization.
+type OrganizationStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          OrganizationObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// An Organization manages the settings of an existing GitHub organization,
+// whose login is the Organization's external name. Organizations are never
+// created or deleted; deleting an Organization stops managing its settings.
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster
+type Organization struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OrganizationSpec   `json:"spec"`
+	Status OrganizationStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// OrganizationList contains a list of Organization
+type OrganizationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Organization `json:"items"`
+}
+
+// Organization type metadata.
+var (
+	OrganizationKind             = reflect.TypeOf(Organization{}).Name()
+	OrganizationGroupKind        = schema.GroupKind{Group: Group, Kind: OrganizationKind}.String()
+	OrganizationKindAPIVersion   = OrganizationKind + "." + SchemeGroupVersion.String()
+	OrganizationGroupVersionKind = SchemeGroupVersion.WithKind(OrganizationKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&Organization{}, &OrganizationList{})
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"reflect"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Roles of an invitee of an organization.
+const (
+	InvitationRoleAdmin          = "admin"
+	InvitationRoleDirectMember   = "direct_member"
+	InvitationRoleBillingManager = "billing_manager"
+)
+
+// States of an invitation to an organization.
+const (
+	InvitationStatePending  = "pending"
+	InvitationStateAccepted = "accepted"
+	InvitationStateFailed   = "failed"
+)
+
+// OrganizationInvitationParameters are the configurable fields of an
+// OrganizationInvitation. Exactly one of email and inviteeID must be set.
+type OrganizationInvitationParameters struct {
+	// The name of the organization to which the invitee is invited. Defaults
+	// to the default organization of the ProviderConfig.
+	// +crossplane:generate:reference:type=Organization
+	// +crossplane:generate:reference:refFieldName=OrgRef
+	// +crossplane:generate:reference:selectorFieldName=OrgSelector
+	// +optional
+	Org string `json:"org,omitempty"`
+
+	// OrgRef refers to an Organization resource.
+	// +optional
+	OrgRef *xpv1.Reference `json:"orgRef,omitempty"`
+
+	// OrgSelector selects one Organization resource.
+	// +optional
+	OrgSelector *xpv1.Selector `json:"orgSelector,omitempty"`
+
+	// Email is the email address of the invitee, who need not yet have a
+	// GitHub account.
+	// +optional
+	Email *string `json:"email,omitempty"`
+
+	// InviteeID is the ID of the GitHub user who is invited.
+	// +optional
+	InviteeID *int64 `json:"inviteeID,omitempty"`
+
+	// The role of the invitee in the organization.
+	// +kubebuilder:validation:Enum=admin;direct_member;billing_manager
+	// +kubebuilder:default=direct_member
+	// +optional
+	Role *string `json:"role,omitempty"`
+
+	// Teams are the slugs of the teams the invitee joins once they accept.
+	// +crossplane:generate:reference:type=Team
+	// +crossplane:generate:reference:refFieldName=TeamRefs
+	// +crossplane:generate:reference:selectorFieldName=TeamSelector
+	// +optional
+	Teams []string `json:"teams,omitempty"`
+
+	// TeamRefs refer to Team resources.
+	// +optional
+	TeamRefs []xpv1.Reference `json:"teamRefs,omitempty"`
+
+	// TeamSelector selects Team resources.
+	// +optional
+	TeamSelector *xpv1.Selector `json:"teamSelector,omitempty"`
+}
+
+// OrganizationInvitationObservation are the observable fields of an
+// OrganizationInvitation.
+type OrganizationInvitationObservation struct {
+	// The state of the invitation: pending until it is accepted, or failed
+	// if it expires first.
+	State string `json:"state,omitempty"`
+
+	// Login is the username of the invitee, if they have a GitHub account.
+	Login string `json:"login,omitempty"`
+
+	// CreatedAt is the time at which the invitation was sent.
+	CreatedAt *metav1.Time `json:"createdAt,omitempty"`
+
+	// FailedReason is the reason the invitation failed, for example because
+	// it expired.
+	FailedReason string `json:"failedReason,omitempty"`
+}
+
+// An OrganizationInvitationSpec defines the desired state of an
+// OrganizationInvitation.
+type OrganizationInvitationSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       OrganizationInvitationParameters `json:"forProvider"`
+}
+
+// An OrganizationInvitationStatus represents the observed state of an
+// OrganizationInvitation.
+type OrganizationInvitationStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          OrganizationInvitationObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// An OrganizationInvitation is an invitation to join an organization. Its
+// external name is the ID of the invitation.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EMAIL",type="string",JSONPath=".spec.forProvider.email"
+// +kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.atProvider.state"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster
+type OrganizationInvitation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OrganizationInvitationSpec   `json:"spec"`
+	Status OrganizationInvitationStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// OrganizationInvitationList contains a list of OrganizationInvitation
+type OrganizationInvitationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OrganizationInvitation `json:"items"`
+}
+
+// OrganizationInvitation type metadata.
+var (
+	OrganizationInvitationKind             = reflect.TypeOf(OrganizationInvitation{}).Name()
+	OrganizationInvitationGroupKind        = schema.GroupKind{Group: Group, Kind: OrganizationInvitationKind}.String()
+	OrganizationInvitationKindAPIVersion   = OrganizationInvitationKind + "." + SchemeGroupVersion.String()
+	OrganizationInvitationGroupVersionKind = SchemeGroupVersion.WithKind(OrganizationInvitationKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&OrganizationInvitation{}, &OrganizationInvitationList{})
+}
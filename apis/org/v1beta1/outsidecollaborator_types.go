@@ -0,0 +1,124 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"reflect"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// OutsideCollaboratorParameters are the configurable fields of an OutsideCollaborator.
+type OutsideCollaboratorParameters struct {
+	// The name of the organization. Defaults to the default organization of
+	// the ProviderConfig.
+	// +crossplane:generate:reference:type=Organization
+	// +crossplane:generate:reference:refFieldName=OrgRef
+	// +crossplane:generate:reference:selectorFieldName=OrgSelector
+	// +optional
+	Org string `json:"org,omitempty"`
+
+	// OrgRef refers to an Organization resource.
+	// +optional
+	OrgRef *xpv1.Reference `json:"orgRef,omitempty"`
+
+	// OrgSelector selects one Organization resource.
+	// +optional
+	OrgSelector *xpv1.Selector `json:"orgSelector,omitempty"`
+
+	// The username of the member to be converted to an outside collaborator.
+	// +crossplane:generate:reference:type=User
+	// +crossplane:generate:reference:extractor=UserLogin()
+	// +crossplane:generate:reference:refFieldName=UserRef
+	// +crossplane:generate:reference:selectorFieldName=UserSelector
+	// +optional
+	User string `json:"user,omitempty"`
+
+	// UserRef refers to a User resource.
+	// +optional
+	UserRef *xpv1.Reference `json:"userRef,omitempty"`
+
+	// UserSelector selects one User resource.
+	// +optional
+	UserSelector *xpv1.Selector `json:"userSelector,omitempty"`
+
+	// RemoveOnDelete controls whether deleting the OutsideCollaborator
+	// removes the collaborator from all of the organization's repositories,
+	// including those they were granted access to by other means. By default
+	// they keep their access.
+	// +optional
+	RemoveOnDelete *bool `json:"removeOnDelete,omitempty"`
+}
+
+// OutsideCollaboratorObservation are the observable fields of an OutsideCollaborator.
+type OutsideCollaboratorObservation struct {
+	// ID of the outside collaborator.
+	ID int64 `json:"id,omitempty"`
+}
+
+// An OutsideCollaboratorSpec defines the desired state of an OutsideCollaborator.
+type OutsideCollaboratorSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       OutsideCollaboratorParameters `json:"forProvider"`
+}
+
+// An OutsideCollaboratorStatus represents the observed state of an OutsideCollaborator.
+type OutsideCollaboratorStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          OutsideCollaboratorObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// An OutsideCollaborator converts a member of an organization to an outside
+// collaborator, who may only access the repositories they collaborate on.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="USER",type="string",JSONPath=".spec.forProvider.user"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster
+type OutsideCollaborator struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OutsideCollaboratorSpec   `json:"spec"`
+	Status OutsideCollaboratorStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// OutsideCollaboratorList contains a list of OutsideCollaborator
+type OutsideCollaboratorList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OutsideCollaborator `json:"items"`
+}
+
+// OutsideCollaborator type metadata.
+var (
+	OutsideCollaboratorKind             = reflect.TypeOf(OutsideCollaborator{}).Name()
+	OutsideCollaboratorGroupKind        = schema.GroupKind{Group: Group, Kind: OutsideCollaboratorKind}.String()
+	OutsideCollaboratorKindAPIVersion   = OutsideCollaboratorKind + "." + SchemeGroupVersion.String()
+	OutsideCollaboratorGroupVersionKind = SchemeGroupVersion.WithKind(OutsideCollaboratorKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&OutsideCollaborator{}, &OutsideCollaboratorList{})
+}
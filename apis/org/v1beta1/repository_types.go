@@ -0,0 +1,175 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"reflect"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Visibilities of a repository.
+const (
+	VisibilityPublic   = "public"
+	VisibilityPrivate  = "private"
+	VisibilityInternal = "internal"
+)
+
+// A RepositoryTemplateSource is a template repository from which a repository
+// is generated.
+type RepositoryTemplateSource struct {
+	// The full name of the template repository, for example
+	// my-org/service-template.
+	// +kubebuilder:validation:Pattern=`^[^/]+/[^/]+$`
+	Repository string `json:"repository"`
+
+	// Whether to copy all of the branches of the template repository, rather
+	// than only its default branch.
+	// +optional
+	IncludeAllBranches *bool `json:"includeAllBranches,omitempty"`
+}
+
+// A RepositoryForkSource is a repository of which a repository is a fork.
+type RepositoryForkSource struct {
+	// The full name of the repository to fork, for example my-org/service.
+	// +kubebuilder:validation:Pattern=`^[^/]+/[^/]+$`
+	Repository string `json:"repository"`
+}
+
+// RepositoryParameters are the configurable fields of a Repository.
+type RepositoryParameters struct {
+	// The name of the organization. Defaults to the default organization of
+	// the ProviderConfig.
+	// +crossplane:generate:reference:type=Organization
+	// +crossplane:generate:reference:refFieldName=OrgRef
+	// +crossplane:generate:reference:selectorFieldName=OrgSelector
+	// +optional
+	Org string `json:"org,omitempty"`
+
+	// OrgRef refers to an Organization resource.
+	// +optional
+	OrgRef *xpv1.Reference `json:"orgRef,omitempty"`
+
+	// OrgSelector selects one Organization resource.
+	// +optional
+	OrgSelector *xpv1.Selector `json:"orgSelector,omitempty"`
+
+	// A description of the repository.
+	// +optional
+	Description *string `json:"description,omitempty"`
+
+	// The visibility of the repository. Internal repositories are visible to
+	// the members of the organization's enterprise. A fork has the visibility
+	// of the repository it forks. A repository generated from a template is
+	// private unless its visibility is set.
+	// +kubebuilder:validation:Enum=public;private;internal
+	// +optional
+	Visibility *string `json:"visibility,omitempty"`
+
+	// The template repository from which to generate the repository. The
+	// repository is created empty if neither a template nor a fork is
+	// specified. Template may not be changed once the repository is created.
+	// +optional
+	Template *RepositoryTemplateSource `json:"template,omitempty"`
+
+	// The repository of which the repository is a fork. The fork is named by
+	// the external name of the Repository, which is set to the fork's name if
+	// GitHub suffixes it because the name is taken. Fork may not be changed
+	// once the repository is created.
+	// +optional
+	Fork *RepositoryForkSource `json:"fork,omitempty"`
+}
+
+// RepositoryObservation are the observable fields of a Repository.
+type RepositoryObservation struct {
+	// The numeric ID of the repository.
+	ID int64 `json:"id,omitempty"`
+
+	// The GraphQL node ID of the repository.
+	NodeID string `json:"nodeId,omitempty"`
+
+	// The full name of the repository, for example my-org/my-service.
+	FullName string `json:"fullName,omitempty"`
+
+	// The URL of the repository on GitHub.
+	HTMLURL string `json:"htmlUrl,omitempty"`
+
+	// The default branch of the repository.
+	DefaultBranch string `json:"defaultBranch,omitempty"`
+
+	// The full name of the template repository from which the repository was
+	// generated.
+	Template string `json:"template,omitempty"`
+
+	// The full name of the repository of which the repository is a fork.
+	Parent string `json:"parent,omitempty"`
+}
+
+// A RepositorySpec defines the desired state of a Repository.
+type RepositorySpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       RepositoryParameters `json:"forProvider"`
+}
+
+// A RepositoryStatus represents the observed state of a Repository.
+type RepositoryStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          RepositoryObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Repository is a repository of an organization, which may be generated from
+// a template repository or forked from another repository. Its external name
+// is the name of the repository. A generated or forked repository is not
+// Ready until GitHub has finished copying its contents.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="REPOSITORY",type="string",JSONPath=".status.atProvider.fullName"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster
+type Repository struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RepositorySpec   `json:"spec"`
+	Status RepositoryStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RepositoryList contains a list of Repository
+type RepositoryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Repository `json:"items"`
+}
+
+// Repository type metadata.
+var (
+	RepositoryKind             = reflect.TypeOf(Repository{}).Name()
+	RepositoryGroupKind        = schema.GroupKind{Group: Group, Kind: RepositoryKind}.String()
+	RepositoryKindAPIVersion   = RepositoryKind + "." + SchemeGroupVersion.String()
+	RepositoryGroupVersionKind = SchemeGroupVersion.WithKind(RepositoryKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&Repository{}, &RepositoryList{})
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"reflect"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// A ConfigMapKeySelector selects a key of a ConfigMap.
+type ConfigMapKeySelector struct {
+	// Name of the ConfigMap.
+	Name string `json:"name"`
+
+	// Namespace of the ConfigMap.
+	Namespace string `json:"namespace"`
+
+	// The key to select.
+	Key string `json:"key"`
+}
+
+// A CommitAuthor is the author of a commit.
+type CommitAuthor struct {
+	// The name of the author.
+	Name string `json:"name"`
+
+	// The email address of the author.
+	Email string `json:"email"`
+}
+
+// RepositoryFileParameters are the configurable fields of a RepositoryFile.
+// Exactly one of content and contentConfigMapRef must be set.
+type RepositoryFileParameters struct {
+	// The name of the organization that owns the repository. Defaults to the
+	// default organization of the ProviderConfig.
+	// +crossplane:generate:reference:type=Organization
+	// +crossplane:generate:reference:refFieldName=OrgRef
+	// +crossplane:generate:reference:selectorFieldName=OrgSelector
+	// +optional
+	Org string `json:"org,omitempty"`
+
+	// OrgRef refers to an Organization resource.
+	// +optional
+	OrgRef *xpv1.Reference `json:"orgRef,omitempty"`
+
+	// OrgSelector selects one Organization resource.
+	// +optional
+	OrgSelector *xpv1.Selector `json:"orgSelector,omitempty"`
+
+	// The name of the repository.
+	Repository string `json:"repository"`
+
+	// The branch on which the file is committed. Defaults to the default
+	// branch of the repository.
+	// +optional
+	Branch *string `json:"branch,omitempty"`
+
+	// The path of the file in the repository, for example
+	// .github/dependabot.yml.
+	Path string `json:"path"`
+
+	// The content of the file.
+	// +optional
+	Content *string `json:"content,omitempty"`
+
+	// ContentConfigMapRef selects the key of a ConfigMap whose value is the
+	// content of the file.
+	// +optional
+	ContentConfigMapRef *ConfigMapKeySelector `json:"contentConfigMapRef,omitempty"`
+
+	// The message of the commits that create or update the file. Defaults
+	// to "Create <path>" or "Update <path>".
+	// +optional
+	CommitMessage *string `json:"commitMessage,omitempty"`
+
+	// The author of the commits that create, update, or delete the file.
+	// Defaults to the user or app the provider authenticates as.
+	// +optional
+	CommitAuthor *CommitAuthor `json:"commitAuthor,omitempty"`
+
+	// Overwrite controls whether changes made to the file outside the
+	// provider are overwritten. When false the file is only updated if it
+	// was not changed since the provider last committed it.
+	// +kubebuilder:default=true
+	// +optional
+	Overwrite *bool `json:"overwrite,omitempty"`
+}
+
+// RepositoryFileObservation are the observable fields of a RepositoryFile.
+type RepositoryFileObservation struct {
+	// The blob SHA of the file on the branch.
+	SHA string `json:"sha,omitempty"`
+
+	// The blob SHA of the file as the provider last committed it, or last
+	// observed it with its desired content. The file was changed outside the
+	// provider if it differs from sha.
+	AppliedSHA string `json:"appliedSha,omitempty"`
+
+	// The SHA of the commit with which the provider last committed the file.
+	CommitSHA string `json:"commitSha,omitempty"`
+}
+
+// A RepositoryFileSpec defines the desired state of a RepositoryFile.
+type RepositoryFileSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       RepositoryFileParameters `json:"forProvider"`
+}
+
+// A RepositoryFileStatus represents the observed state of a RepositoryFile.
+type RepositoryFileStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          RepositoryFileObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A RepositoryFile is a file committed to a branch of a repository.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="REPOSITORY",type="string",JSONPath=".spec.forProvider.repository"
+// +kubebuilder:printcolumn:name="PATH",type="string",JSONPath=".spec.forProvider.path"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster
+type RepositoryFile struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RepositoryFileSpec   `json:"spec"`
+	Status RepositoryFileStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RepositoryFileList contains a list of RepositoryFile
+type RepositoryFileList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RepositoryFile `json:"items"`
+}
+
+// RepositoryFile type metadata.
+var (
+	RepositoryFileKind             = reflect.TypeOf(RepositoryFile{}).Name()
+	RepositoryFileGroupKind        = schema.GroupKind{Group: Group, Kind: RepositoryFileKind}.String()
+	RepositoryFileKindAPIVersion   = RepositoryFileKind + "." + SchemeGroupVersion.String()
+	RepositoryFileGroupVersionKind = SchemeGroupVersion.WithKind(RepositoryFileKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&RepositoryFile{}, &RepositoryFileList{})
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"reflect"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// SecurityManagerTeamParameters are the configurable fields of a
+// SecurityManagerTeam.
+type SecurityManagerTeamParameters struct {
+	// The name of the organization of the team. Defaults to the default
+	// organization of the ProviderConfig.
+	// +crossplane:generate:reference:type=Organization
+	// +crossplane:generate:reference:refFieldName=OrgRef
+	// +crossplane:generate:reference:selectorFieldName=OrgSelector
+	// +optional
+	Org string `json:"org,omitempty"`
+
+	// OrgRef refers to an Organization resource.
+	// +optional
+	OrgRef *xpv1.Reference `json:"orgRef,omitempty"`
+
+	// OrgSelector selects one Organization resource.
+	// +optional
+	OrgSelector *xpv1.Selector `json:"orgSelector,omitempty"`
+
+	// Team is the slug of the team that is assigned the security manager
+	// role.
+	// +crossplane:generate:reference:type=Team
+	// +crossplane:generate:reference:refFieldName=TeamRef
+	// +crossplane:generate:reference:selectorFieldName=TeamSelector
+	// +optional
+	Team *string `json:"team,omitempty"`
+
+	// TeamRef refers to a Team resource.
+	// +optional
+	TeamRef *xpv1.Reference `json:"teamRef,omitempty"`
+
+	// TeamSelector selects one Team resource.
+	// +optional
+	TeamSelector *xpv1.Selector `json:"teamSelector,omitempty"`
+}
+
+// SecurityManagerTeamObservation are the observable fields of a
+// SecurityManagerTeam.
+type SecurityManagerTeamObservation struct {
+	// The numeric ID of the team.
+	ID int64 `json:"id,omitempty"`
+}
+
+// A SecurityManagerTeamSpec defines the desired state of a
+// SecurityManagerTeam.
+type SecurityManagerTeamSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       SecurityManagerTeamParameters `json:"forProvider"`
+}
+
+// A SecurityManagerTeamStatus represents the observed state of a
+// SecurityManagerTeam.
+type SecurityManagerTeamStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          SecurityManagerTeamObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A SecurityManagerTeam assigns the security manager role of an organization
+// to a team, whose members may manage security alerts and settings across all
+// of the organization's repositories.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="TEAM",type="string",JSONPath=".spec.forProvider.team"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster
+type SecurityManagerTeam struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SecurityManagerTeamSpec   `json:"spec"`
+	Status SecurityManagerTeamStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SecurityManagerTeamList contains a list of SecurityManagerTeam
+type SecurityManagerTeamList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SecurityManagerTeam `json:"items"`
+}
+
+// SecurityManagerTeam type metadata.
+var (
+	SecurityManagerTeamKind             = reflect.TypeOf(SecurityManagerTeam{}).Name()
+	SecurityManagerTeamGroupKind        = schema.GroupKind{Group: Group, Kind: SecurityManagerTeamKind}.String()
+	SecurityManagerTeamKindAPIVersion   = SecurityManagerTeamKind + "." + SchemeGroupVersion.String()
+	SecurityManagerTeamGroupVersionKind = SchemeGroupVersion.WithKind(SecurityManagerTeamKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&SecurityManagerTeam{}, &SecurityManagerTeamList{})
+}
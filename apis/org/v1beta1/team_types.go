@@ -0,0 +1,152 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"reflect"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Notification settings of a team.
+const (
+	NotificationsEnabled  = "notifications_enabled"
+	NotificationsDisabled = "notifications_disabled"
+)
+
+// TeamParameters are the configurable fields of a Team.
+type TeamParameters struct {
+	// The name of the organization this team belongs to. Defaults to the
+	// default organization of the ProviderConfig.
+	// +crossplane:generate:reference:type=Organization
+	// +crossplane:generate:reference:refFieldName=OrgRef
+	// +crossplane:generate:reference:selectorFieldName=OrgSelector
+	// +optional
+	Org string `json:"org,omitempty"`
+
+	// OrgRef refers to an Organization resource.
+	// +optional
+	OrgRef *xpv1.Reference `json:"orgRef,omitempty"`
+
+	// OrgSelector selects one Organization resource.
+	// +optional
+	OrgSelector *xpv1.Selector `json:"orgSelector,omitempty"`
+
+	// The name of the team. Defaults to the team's slug, which is its
+	// external name. GitHub derives the slug from the name, so the external
+	// name is updated to the new slug when the name changes.
+	// +optional
+	Name *string `json:"name,omitempty"`
+
+	// A description about the team.
+	// +optional
+	Description *string `json:"description,omitempty"`
+
+	// The visibility of the team. Nested teams must be closed.
+	// +kubebuilder:validation:Enum=secret;closed
+	// +optional
+	Privacy *string `json:"privacy,omitempty"`
+
+	// Parent is the slug of the team's parent team, if it is nested.
+	// +crossplane:generate:reference:type=Team
+	// +crossplane:generate:reference:refFieldName=ParentRef
+	// +crossplane:generate:reference:selectorFieldName=ParentSelector
+	// +optional
+	Parent *string `json:"parent,omitempty"`
+
+	// ParentRef refers to the Team resource of the team's parent team.
+	// +optional
+	ParentRef *xpv1.Reference `json:"parentRef,omitempty"`
+
+	// ParentSelector selects the Team resource of the team's parent team.
+	// +optional
+	ParentSelector *xpv1.Selector `json:"parentSelector,omitempty"`
+
+	// NotificationSetting controls whether members are notified when the
+	// team is mentioned.
+	// +kubebuilder:validation:Enum=notifications_enabled;notifications_disabled
+	// +optional
+	NotificationSetting *string `json:"notificationSetting,omitempty"`
+
+	// LDAPDN is the distinguished name of the LDAP entry with which the
+	// team's membership is synchronized. It may only be set when the
+	// ProviderConfig targets a GitHub Enterprise Server with LDAP sync.
+	// +optional
+	LDAPDN *string `json:"ldapDN,omitempty"`
+}
+
+// TeamObservation are the observable fields of a Team.
+type TeamObservation struct {
+	// The node ID of the team, used to identify it in the GraphQL API.
+	NodeID string `json:"nodeId,omitempty"`
+
+	// The numeric ID of the team.
+	ID int64 `json:"id,omitempty"`
+}
+
+// A TeamSpec defines the desired state of a Team.
+type TeamSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       TeamParameters `json:"forProvider"`
+}
+
+// A TeamStatus represents the observed state of a Team.
+type TeamStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          TeamObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Team is a team of an organization, whose slug is its external name.
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster
+type Team struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TeamSpec   `json:"spec"`
+	Status TeamStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TeamList contains a list of Team
+type TeamList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Team `json:"items"`
+}
+
+// Team type metadata.
+var (
+	TeamKind             = reflect.TypeOf(Team{}).Name()
+	TeamGroupKind        = schema.GroupKind{Group: Group, Kind: TeamKind}.String()
+	TeamKindAPIVersion   = TeamKind + "." + SchemeGroupVersion.String()
+	TeamGroupVersionKind = SchemeGroupVersion.WithKind(TeamKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&Team{}, &TeamList{})
+}
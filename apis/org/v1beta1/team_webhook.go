@@ -0,0 +1,109 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// SetupWebhookWithManager adds the Team validating and conversion webhooks to the
+// supplied manager's webhook server.
+func (in *Team) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(in).Complete()
+}
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-org-github-hasheddan-io-v1beta1-team,mutating=false,failurePolicy=fail,sideEffects=None,groups=org.github.hasheddan.io,resources=teams,versions=v1beta1,name=teams.org.github.hasheddan.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &Team{}
+
+// ValidateCreate validates a Team that is being created.
+func (in *Team) ValidateCreate() error {
+	return in.invalid(in.validate())
+}
+
+// ValidateUpdate validates the fields of a Team that are being updated.
+func (in *Team) ValidateUpdate(old runtime.Object) error {
+	o, ok := old.(*Team)
+	if !ok || in.GetDeletionTimestamp() != nil {
+		return nil
+	}
+	fp := field.NewPath("spec", "forProvider")
+	p := in.Spec.ForProvider
+	errs := changed(in.validate(), o.validate())
+	errs = append(errs, immutable(fp.Child("org"), o.Spec.ForProvider.Org, p.Org, p.OrgRef != nil || p.OrgSelector != nil)...)
+	return in.invalid(errs)
+}
+
+// ValidateDelete validates a Team that is being deleted.
+func (in *Team) ValidateDelete() error {
+	return nil
+}
+
+func (in *Team) validate() field.ErrorList {
+	fp := field.NewPath("spec", "forProvider")
+	p := in.Spec.ForProvider
+
+	var errs field.ErrorList
+	nested := p.Parent != nil || p.ParentRef != nil || p.ParentSelector != nil
+	if nested && p.Privacy != nil && *p.Privacy == "secret" {
+		errs = append(errs, field.Invalid(fp.Child("privacy"), *p.Privacy, "nested teams must be closed"))
+	}
+	return errs
+}
+
+func (in *Team) invalid(errs field.ErrorList) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(TeamGroupVersionKind.GroupKind(), in.GetName(), errs)
+}
+
+// immutable returns an error if a field has changed. A field that was not set
+// may only be set when it is resolved from a reference; otherwise it defaults,
+// for example to the default organization of the ProviderConfig, and setting
+// it could change the external resource the managed resource refers to.
+func immutable(path *field.Path, old, new string, referenced bool) field.ErrorList {
+	if old == new || (old == "" && referenced) {
+		return nil
+	}
+	return field.ErrorList{field.Invalid(path, new, "field is immutable once set")}
+}
+
+// changed returns the errors that are not in old, so that an update is not
+// rejected because of fields it does not change.
+func changed(errs, old field.ErrorList) field.ErrorList {
+	var out field.ErrorList
+	for _, err := range errs {
+		if !contains(old, err) {
+			out = append(out, err)
+		}
+	}
+	return out
+}
+
+func contains(errs field.ErrorList, err *field.Error) bool {
+	for _, e := range errs {
+		if e.Error() == err.Error() {
+			return true
+		}
+	}
+	return false
+}
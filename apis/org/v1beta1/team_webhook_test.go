@@ -0,0 +1,94 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+func TestTeamValidate(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		old    *Team
+		new    *Team
+		valid  bool
+	}{
+		"Valid": {
+			reason: "A closed nested team should be valid.",
+			new:    &Team{Spec: TeamSpec{ForProvider: TeamParameters{Org: "crossplane", Privacy: pointer.String("closed"), Parent: pointer.String("engineering")}}},
+			valid:  true,
+		},
+		"SecretNested": {
+			reason: "A secret nested team should be invalid.",
+			new:    &Team{Spec: TeamSpec{ForProvider: TeamParameters{Org: "crossplane", Privacy: pointer.String("secret"), Parent: pointer.String("engineering")}}},
+		},
+		"SecretParentRef": {
+			reason: "A secret team whose parent is a reference should be invalid.",
+			new:    &Team{Spec: TeamSpec{ForProvider: TeamParameters{Org: "crossplane", Privacy: pointer.String("secret"), ParentRef: &xpv1.Reference{Name: "engineering"}}}},
+		},
+		"OrgChanged": {
+			reason: "The organization of an existing team should be immutable.",
+			old:    &Team{Spec: TeamSpec{ForProvider: TeamParameters{Org: "crossplane"}}},
+			new:    &Team{Spec: TeamSpec{ForProvider: TeamParameters{Org: "upbound"}}},
+		},
+		"OrgResolved": {
+			reason: "The organization of an existing team may be set once, for example when it is resolved from a reference.",
+			old:    &Team{Spec: TeamSpec{ForProvider: TeamParameters{OrgRef: &xpv1.Reference{Name: "crossplane"}}}},
+			new:    &Team{Spec: TeamSpec{ForProvider: TeamParameters{Org: "crossplane", OrgRef: &xpv1.Reference{Name: "crossplane"}}}},
+			valid:  true,
+		},
+		"OrgDefaulted": {
+			reason: "The organization of an existing team that defaulted to that of its ProviderConfig should be immutable.",
+			old:    &Team{Spec: TeamSpec{ForProvider: TeamParameters{}}},
+			new:    &Team{Spec: TeamSpec{ForProvider: TeamParameters{Org: "upbound"}}},
+		},
+		"Unchanged": {
+			reason: "An update should not be rejected because of fields it does not change.",
+			old:    &Team{Spec: TeamSpec{ForProvider: TeamParameters{Org: "crossplane", Privacy: pointer.String("secret"), Parent: pointer.String("engineering")}}},
+			new:    &Team{Spec: TeamSpec{ForProvider: TeamParameters{Org: "crossplane", Privacy: pointer.String("secret"), Parent: pointer.String("engineering"), Description: pointer.String("cool")}}},
+			valid:  true,
+		},
+		"Deleting": {
+			reason: "A team that is being deleted should not be validated.",
+			old:    &Team{Spec: TeamSpec{ForProvider: TeamParameters{Org: "crossplane"}}},
+			new: &Team{
+				ObjectMeta: metav1.ObjectMeta{DeletionTimestamp: &metav1.Time{}},
+				Spec:       TeamSpec{ForProvider: TeamParameters{Org: "upbound"}},
+			},
+			valid: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var err error
+			if tc.old == nil {
+				err = tc.new.ValidateCreate()
+			} else {
+				err = tc.new.ValidateUpdate(tc.old)
+			}
+			if (err == nil) != tc.valid {
+				t.Errorf("\n%s\nValidate(...): want valid %t, got error %v", tc.reason, tc.valid, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"reflect"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// An IdPGroup is a group of the identity provider with which an organization
+// synchronizes its teams.
+type IdPGroup struct {
+	// The ID of the group.
+	ID string `json:"id"`
+
+	// The name of the group. Defaults to the name of the identity provider's
+	// group with the supplied ID.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// A description of the group. Defaults to the description of the
+	// identity provider's group with the supplied ID.
+	// +optional
+	Description string `json:"description,omitempty"`
+}
+
+// TeamIdPGroupMappingParameters are the configurable fields of a
+// TeamIdPGroupMapping.
+type TeamIdPGroupMappingParameters struct {
+	// The name of the organization of the team. Defaults to the default
+	// organization of the ProviderConfig.
+	// +crossplane:generate:reference:type=Organization
+	// +crossplane:generate:reference:refFieldName=OrgRef
+	// +crossplane:generate:reference:selectorFieldName=OrgSelector
+	// +optional
+	Org string `json:"org,omitempty"`
+
+	// OrgRef refers to an Organization resource.
+	// +optional
+	OrgRef *xpv1.Reference `json:"orgRef,omitempty"`
+
+	// OrgSelector selects one Organization resource.
+	// +optional
+	OrgSelector *xpv1.Selector `json:"orgSelector,omitempty"`
+
+	// Team is the slug of the team whose membership is synchronized.
+	// +crossplane:generate:reference:type=Team
+	// +crossplane:generate:reference:refFieldName=TeamRef
+	// +crossplane:generate:reference:selectorFieldName=TeamSelector
+	// +optional
+	Team *string `json:"team,omitempty"`
+
+	// TeamRef refers to a Team resource.
+	// +optional
+	TeamRef *xpv1.Reference `json:"teamRef,omitempty"`
+
+	// TeamSelector selects one Team resource.
+	// +optional
+	TeamSelector *xpv1.Selector `json:"teamSelector,omitempty"`
+
+	// Groups are the identity provider groups connected to the team. The
+	// team's members are synchronized with the members of these groups.
+	// +kubebuilder:validation:MinItems=1
+	Groups []IdPGroup `json:"groups"`
+}
+
+// TeamIdPGroupMappingObservation are the observable fields of a
+// TeamIdPGroupMapping.
+type TeamIdPGroupMappingObservation struct {
+	// Groups are the identity provider groups connected to the team.
+	Groups []IdPGroup `json:"groups,omitempty"`
+
+	// AvailableGroups are the identity provider groups that may be connected
+	// to the organization's teams.
+	AvailableGroups []IdPGroup `json:"availableGroups,omitempty"`
+}
+
+// A TeamIdPGroupMappingSpec defines the desired state of a
+// TeamIdPGroupMapping.
+type TeamIdPGroupMappingSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       TeamIdPGroupMappingParameters `json:"forProvider"`
+}
+
+// A TeamIdPGroupMappingStatus represents the observed state of a
+// TeamIdPGroupMapping.
+type TeamIdPGroupMappingStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          TeamIdPGroupMappingObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A TeamIdPGroupMapping connects a team to the identity provider groups with
+// which its membership is synchronized using team synchronization.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="TEAM",type="string",JSONPath=".spec.forProvider.team"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster
+type TeamIdPGroupMapping struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TeamIdPGroupMappingSpec   `json:"spec"`
+	Status TeamIdPGroupMappingStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TeamIdPGroupMappingList contains a list of TeamIdPGroupMapping
+type TeamIdPGroupMappingList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TeamIdPGroupMapping `json:"items"`
+}
+
+// TeamIdPGroupMapping type metadata.
+var (
+	TeamIdPGroupMappingKind             = reflect.TypeOf(TeamIdPGroupMapping{}).Name()
+	TeamIdPGroupMappingGroupKind        = schema.GroupKind{Group: Group, Kind: TeamIdPGroupMappingKind}.String()
+	TeamIdPGroupMappingKindAPIVersion   = TeamIdPGroupMappingKind + "." + SchemeGroupVersion.String()
+	TeamIdPGroupMappingGroupVersionKind = SchemeGroupVersion.WithKind(TeamIdPGroupMappingKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&TeamIdPGroupMapping{}, &TeamIdPGroupMappingList{})
+}
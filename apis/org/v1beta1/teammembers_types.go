@@ -0,0 +1,145 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"reflect"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// TeamMembersParameters are the configurable fields of a TeamMembers.
+type TeamMembersParameters struct {
+	// The name of the organization of the team. Defaults to the default
+	// organization of the ProviderConfig.
+	// +crossplane:generate:reference:type=Organization
+	// +crossplane:generate:reference:refFieldName=OrgRef
+	// +crossplane:generate:reference:selectorFieldName=OrgSelector
+	// +optional
+	Org string `json:"org,omitempty"`
+
+	// OrgRef refers to an Organization resource.
+	// +optional
+	OrgRef *xpv1.Reference `json:"orgRef,omitempty"`
+
+	// OrgSelector selects one Organization resource.
+	// +optional
+	OrgSelector *xpv1.Selector `json:"orgSelector,omitempty"`
+
+	// Team is the slug of the team whose members are declared.
+	// +crossplane:generate:reference:type=Team
+	// +crossplane:generate:reference:refFieldName=TeamRef
+	// +crossplane:generate:reference:selectorFieldName=TeamSelector
+	// +optional
+	Team *string `json:"team,omitempty"`
+
+	// TeamRef refers to a Team resource.
+	// +optional
+	TeamRef *xpv1.Reference `json:"teamRef,omitempty"`
+
+	// TeamSelector selects one Team resource.
+	// +optional
+	TeamSelector *xpv1.Selector `json:"teamSelector,omitempty"`
+
+	// Members are the usernames of the users who should be members of the
+	// team, with the member role.
+	// +optional
+	Members []string `json:"members,omitempty"`
+
+	// Maintainers are the usernames of the users who should be members of
+	// the team, with the maintainer role.
+	// +optional
+	Maintainers []string `json:"maintainers,omitempty"`
+
+	// Exclusive controls whether users who are not declared are removed from
+	// the team. When false declared users are only added, or have their role
+	// changed.
+	// +kubebuilder:default=true
+	// +optional
+	Exclusive *bool `json:"exclusive,omitempty"`
+}
+
+// TeamMembersObservation are the observable fields of a TeamMembers. GitHub
+// usernames are case-insensitive, so they are reported in lower case.
+type TeamMembersObservation struct {
+	// Members are the usernames of the team's active members with the member
+	// role.
+	Members []string `json:"members,omitempty"`
+
+	// Maintainers are the usernames of the team's active members with the
+	// maintainer role.
+	Maintainers []string `json:"maintainers,omitempty"`
+
+	// Pending are the usernames of the users who were invited to the team,
+	// and will become members once they accept an invitation to the
+	// organization.
+	Pending []string `json:"pending,omitempty"`
+}
+
+// A TeamMembersSpec defines the desired state of a TeamMembers.
+type TeamMembersSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       TeamMembersParameters `json:"forProvider"`
+}
+
+// A TeamMembersStatus represents the observed state of a TeamMembers.
+type TeamMembersStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          TeamMembersObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A TeamMembers declares the complete roster of a team: its members and its
+// maintainers.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="TEAM",type="string",JSONPath=".spec.forProvider.team"
+// +kubebuilder:printcolumn:name="EXCLUSIVE",type="boolean",JSONPath=".spec.forProvider.exclusive"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster
+type TeamMembers struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TeamMembersSpec   `json:"spec"`
+	Status TeamMembersStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TeamMembersList contains a list of TeamMembers
+type TeamMembersList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TeamMembers `json:"items"`
+}
+
+// TeamMembers type metadata.
+var (
+	TeamMembersKind             = reflect.TypeOf(TeamMembers{}).Name()
+	TeamMembersGroupKind        = schema.GroupKind{Group: Group, Kind: TeamMembersKind}.String()
+	TeamMembersKindAPIVersion   = TeamMembersKind + "." + SchemeGroupVersion.String()
+	TeamMembersGroupVersionKind = SchemeGroupVersion.WithKind(TeamMembersKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&TeamMembers{}, &TeamMembersList{})
+}
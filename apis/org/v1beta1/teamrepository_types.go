@@ -0,0 +1,165 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"reflect"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Permissions a team may be granted to a repository, other than custom
+// repository roles.
+const (
+	PermissionPull     = "pull"
+	PermissionTriage   = "triage"
+	PermissionPush     = "push"
+	PermissionMaintain = "maintain"
+	PermissionAdmin    = "admin"
+)
+
+// TeamRepositoryParameters are the configurable fields of a TeamRepository.
+type TeamRepositoryParameters struct {
+	// The name of the organization of the team and repository. Defaults to
+	// the default organization of the ProviderConfig.
+	// +crossplane:generate:reference:type=Organization
+	// +crossplane:generate:reference:refFieldName=OrgRef
+	// +crossplane:generate:reference:selectorFieldName=OrgSelector
+	// +optional
+	Org string `json:"org,omitempty"`
+
+	// OrgRef refers to an Organization resource.
+	// +optional
+	OrgRef *xpv1.Reference `json:"orgRef,omitempty"`
+
+	// OrgSelector selects one Organization resource.
+	// +optional
+	OrgSelector *xpv1.Selector `json:"orgSelector,omitempty"`
+
+	// Team is the slug of the team that is granted the permission.
+	// +crossplane:generate:reference:type=Team
+	// +crossplane:generate:reference:refFieldName=TeamRef
+	// +crossplane:generate:reference:selectorFieldName=TeamSelector
+	// +optional
+	Team *string `json:"team,omitempty"`
+
+	// TeamRef refers to a Team resource.
+	// +optional
+	TeamRef *xpv1.Reference `json:"teamRef,omitempty"`
+
+	// TeamSelector selects one Team resource.
+	// +optional
+	TeamSelector *xpv1.Selector `json:"teamSelector,omitempty"`
+
+	// Repository is the name of the repository to which the team is granted
+	// the permission.
+	// +crossplane:generate:reference:type=Repository
+	// +crossplane:generate:reference:refFieldName=RepositoryRef
+	// +crossplane:generate:reference:selectorFieldName=RepositorySelector
+	// +optional
+	Repository *string `json:"repository,omitempty"`
+
+	// RepositoryRef refers to a Repository resource.
+	// +optional
+	RepositoryRef *xpv1.Reference `json:"repositoryRef,omitempty"`
+
+	// RepositorySelector selects one Repository resource.
+	// +optional
+	RepositorySelector *xpv1.Selector `json:"repositorySelector,omitempty"`
+
+	// Permission is the permission the team is granted: pull, triage, push,
+	// maintain, admin, or the name of a custom repository role of the
+	// organization. Defaults to push, as on GitHub.
+	// +crossplane:generate:reference:type=CustomRepositoryRole
+	// +crossplane:generate:reference:extractor=CustomRepositoryRoleName()
+	// +crossplane:generate:reference:refFieldName=PermissionRef
+	// +crossplane:generate:reference:selectorFieldName=PermissionSelector
+	// +optional
+	Permission *string `json:"permission,omitempty"`
+
+	// PermissionRef refers to a CustomRepositoryRole resource, whose name is
+	// the permission the team is granted.
+	// +optional
+	PermissionRef *xpv1.Reference `json:"permissionRef,omitempty"`
+
+	// PermissionSelector selects one CustomRepositoryRole resource.
+	// +optional
+	PermissionSelector *xpv1.Selector `json:"permissionSelector,omitempty"`
+}
+
+// TeamRepositoryObservation are the observable fields of a TeamRepository.
+type TeamRepositoryObservation struct {
+	// RoleName is the name of the team's role in the repository: read,
+	// triage, write, maintain, admin, or the name of a custom repository
+	// role.
+	RoleName string `json:"roleName,omitempty"`
+}
+
+// A TeamRepositorySpec defines the desired state of a TeamRepository.
+type TeamRepositorySpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       TeamRepositoryParameters `json:"forProvider"`
+}
+
+// A TeamRepositoryStatus represents the observed state of a TeamRepository.
+type TeamRepositoryStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          TeamRepositoryObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A TeamRepository grants a team a permission to a repository of its
+// organization.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="TEAM",type="string",JSONPath=".spec.forProvider.team"
+// +kubebuilder:printcolumn:name="REPOSITORY",type="string",JSONPath=".spec.forProvider.repository"
+// +kubebuilder:printcolumn:name="ROLE",type="string",JSONPath=".status.atProvider.roleName"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster
+type TeamRepository struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TeamRepositorySpec   `json:"spec"`
+	Status TeamRepositoryStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TeamRepositoryList contains a list of TeamRepository
+type TeamRepositoryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TeamRepository `json:"items"`
+}
+
+// TeamRepository type metadata.
+var (
+	TeamRepositoryKind             = reflect.TypeOf(TeamRepository{}).Name()
+	TeamRepositoryGroupKind        = schema.GroupKind{Group: Group, Kind: TeamRepositoryKind}.String()
+	TeamRepositoryKindAPIVersion   = TeamRepositoryKind + "." + SchemeGroupVersion.String()
+	TeamRepositoryGroupVersionKind = SchemeGroupVersion.WithKind(TeamRepositoryKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&TeamRepository{}, &TeamRepositoryList{})
+}
@@ -21,6 +21,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 
 	orgv1alpha1 "github.com/hasheddan/kc-provider-github/apis/org/v1alpha1"
+	orgv1beta1 "github.com/hasheddan/kc-provider-github/apis/org/v1beta1"
 	templatev1alpha1 "github.com/hasheddan/kc-provider-github/apis/v1alpha1"
 )
 
@@ -29,6 +30,7 @@ func init() {
 	AddToSchemes = append(AddToSchemes,
 		templatev1alpha1.SchemeBuilder.AddToScheme,
 		orgv1alpha1.SchemeBuilder.AddToScheme,
+		orgv1beta1.SchemeBuilder.AddToScheme,
 	)
 }
 
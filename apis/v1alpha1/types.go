@@ -38,18 +38,59 @@ type ProviderConfigSpec struct {
 
 	// Credentials required to authenticate to this provider.
 	Credentials ProviderCredentials `json:"credentials"`
+
+	// BaseURL of the GitHub REST API. Defaults to https://api.github.com/.
+	// The REST API of GitHub Enterprise Server is served at
+	// https://HOSTNAME/api/v3/.
+	// +optional
+	BaseURL *string `json:"baseURL,omitempty"`
+
+	// DefaultOrganization is the organization of managed resources that use
+	// this ProviderConfig and do not specify an organization.
+	// +optional
+	DefaultOrganization *string `json:"defaultOrganization,omitempty"`
 }
 
 // A ProviderConfigStatus reflects the observed state of a ProviderConfig.
 type ProviderConfigStatus struct {
 	xpv1.ProviderConfigStatus `json:",inline"`
+
+	// TokenType is the type of the token read from the credentials Secret,
+	// for example classic, fine-grained or installation.
+	TokenType string `json:"tokenType,omitempty"`
+
+	// Scopes granted to the token. Only classic and OAuth tokens have scopes.
+	Scopes []string `json:"scopes,omitempty"`
+
+	// ExpiresAt is when the token expires, if it does.
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+
+	// Login of the user the token authenticates as. Installation tokens do
+	// not authenticate as a user.
+	Login string `json:"login,omitempty"`
+
+	// LastCheckTime is when the credentials were last checked.
+	LastCheckTime *metav1.Time `json:"lastCheckTime,omitempty"`
+
+	// CheckedGeneration is the generation of the ProviderConfig when its
+	// credentials were last checked. They are checked again as soon as it
+	// changes.
+	CheckedGeneration int64 `json:"checkedGeneration,omitempty"`
+
+	// CheckedSecretVersion is the resource version of the credentials Secret
+	// when the credentials were last checked. They are checked again as soon
+	// as the Secret changes.
+	CheckedSecretVersion string `json:"checkedSecretVersion,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 
 // A ProviderConfig configures a Template provider.
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="LOGIN",type="string",JSONPath=".status.login"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="TOKEN-TYPE",type="string",JSONPath=".status.tokenType",priority=1
 // +kubebuilder:printcolumn:name="SECRET-NAME",type="string",JSONPath=".spec.credentialsSecretRef.name",priority=1
 // +kubebuilder:resource:scope=Cluster
 type ProviderConfig struct {
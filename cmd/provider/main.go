@@ -17,17 +17,27 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 
 	"gopkg.in/alecthomas/kingpin.v2"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
+	xpcontroller "github.com/crossplane/crossplane-runtime/pkg/controller"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
 
 	"github.com/hasheddan/kc-provider-github/apis"
+	orgv1beta1 "github.com/hasheddan/kc-provider-github/apis/org/v1beta1"
 	"github.com/hasheddan/kc-provider-github/pkg/controller"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/options"
+	"github.com/hasheddan/kc-provider-github/pkg/receiver"
 )
 
 func main() {
@@ -35,6 +45,18 @@ func main() {
 		app        = kingpin.New(filepath.Base(os.Args[0]), "Template support for Crossplane.").DefaultEnvars()
 		debug      = app.Flag("debug", "Run with debug logging.").Short('d').Bool()
 		syncPeriod = app.Flag("sync", "Controller manager sync period such as 300ms, 1.5h, or 2h45m").Short('s').Default("1h").Duration()
+
+		pollInterval     = app.Flag("poll", "Poll interval controls how often an individual resource should be checked for drift.").Default("1m").Duration()
+		leaderElection   = app.Flag("leader-election", "Use leader election for the controller manager.").Short('l').Default("false").OverrideDefaultFromEnvar("LEADER_ELECTION").Bool()
+		maxReconcileRate = app.Flag("max-reconcile-rate", "The global maximum rate per second at which resources may be checked for drift from the desired state.").Default("10").Int()
+
+		metricsBindAddress = app.Flag("metrics-bind-address", "The address the metrics endpoint binds to.").Default(":8080").String()
+		probeBindAddress   = app.Flag("health-probe-bind-address", "The address the health probe endpoints bind to.").Default(":8081").String()
+
+		webhookAddress = app.Flag("github-webhook-address", "The address at which to receive GitHub webhooks, such as :9000. GitHub webhooks are not received unless it is set.").String()
+		webhookSecret  = app.Flag("github-webhook-secret", "The secret used to verify the signatures of GitHub webhooks.").Envar("GITHUB_WEBHOOK_SECRET").String()
+
+		webhookTLSCertDir = app.Flag("webhook-tls-cert-dir", "The directory of the TLS certificate (tls.crt) and key (tls.key) used to serve admission and conversion webhooks. It is required unless the installed CRDs do not use webhook conversion.").Envar("WEBHOOK_TLS_CERT_DIR").String()
 	)
 	kingpin.MustParse(app.Parse(os.Args[1:]))
 
@@ -47,15 +69,70 @@ func main() {
 		ctrl.SetLogger(zl)
 	}
 
-	log.Debug("Starting", "sync-period", syncPeriod.String())
+	log.Debug("Starting", "sync-period", syncPeriod.String(), "poll-interval", pollInterval.String(), "max-reconcile-rate", *maxReconcileRate)
 
 	cfg, err := ctrl.GetConfig()
 	kingpin.FatalIfError(err, "Cannot get API server rest config")
 
-	mgr, err := ctrl.NewManager(cfg, ctrl.Options{SyncPeriod: syncPeriod})
+	// The manager serves the metrics registered with controller-runtime's
+	// registry, including those recorded for GitHub API requests.
+	mgr, err := ctrl.NewManager(ratelimiter.LimitRESTConfig(cfg, *maxReconcileRate), ctrl.Options{
+		SyncPeriod:             syncPeriod,
+		MetricsBindAddress:     *metricsBindAddress,
+		HealthProbeBindAddress: *probeBindAddress,
+		LeaderElection:         *leaderElection,
+		LeaderElectionID:       "crossplane-leader-election-kc-provider-github",
+		Port:                   9443,
+		CertDir:                *webhookTLSCertDir,
+	})
 	kingpin.FatalIfError(err, "Cannot create controller manager")
+	kingpin.FatalIfError(mgr.AddHealthzCheck("healthz", healthz.Ping), "Cannot add health check")
+	kingpin.FatalIfError(mgr.AddReadyzCheck("readyz", healthz.Ping), "Cannot add ready check")
+
+	o := options.Options{
+		Options: xpcontroller.Options{
+			Logger:                  log,
+			MaxConcurrentReconciles: *maxReconcileRate,
+			PollInterval:            *pollInterval,
+			GlobalRateLimiter:       ratelimiter.NewGlobal(*maxReconcileRate),
+		},
+	}
+
+	if *webhookAddress != "" {
+		if *webhookSecret == "" {
+			kingpin.Fatalf("--github-webhook-secret is required when --github-webhook-address is set")
+		}
+		o.Receiver = receiver.New(*webhookAddress, []byte(*webhookSecret), receiver.WithLogger(log.WithValues("component", "github-webhook-receiver")))
+		kingpin.FatalIfError(mgr.Add(o.Receiver), "Cannot add GitHub webhook receiver to manager")
+	}
 
 	kingpin.FatalIfError(apis.AddToScheme(mgr.GetScheme()), "Cannot add Template APIs to scheme")
-	kingpin.FatalIfError(controller.Setup(mgr, log), "Cannot setup Template controllers")
+	kingpin.FatalIfError(apiextensionsv1.AddToScheme(mgr.GetScheme()), "Cannot add CustomResourceDefinitions to scheme")
+	kingpin.FatalIfError(controller.Setup(mgr, o), "Cannot setup Template controllers")
+	if *webhookTLSCertDir == "" && webhookConversion(context.Background(), mgr.GetAPIReader()) {
+		kingpin.Fatalf("--webhook-tls-cert-dir is required because the Team, Membership and Organization CRDs are converted between API versions by the provider's conversion webhook")
+	}
+	if *webhookTLSCertDir != "" {
+		kingpin.FatalIfError((&orgv1beta1.Team{}).SetupWebhookWithManager(mgr), "Cannot setup Team webhook")
+		kingpin.FatalIfError((&orgv1beta1.Membership{}).SetupWebhookWithManager(mgr), "Cannot setup Membership webhook")
+		kingpin.FatalIfError((&orgv1beta1.Organization{}).SetupWebhookWithManager(mgr), "Cannot setup Organization webhook")
+	}
 	kingpin.FatalIfError(mgr.Start(ctrl.SetupSignalHandler()), "Cannot start controller manager")
 }
+
+// webhookConversion returns true if any CRD of a kind that is served at more
+// than one API version is converted by the provider's conversion webhook, as
+// the CRDs of its package are. A CRD that cannot be read, for example because
+// the provider may not read CRDs, is assumed to be.
+func webhookConversion(ctx context.Context, c client.Reader) bool {
+	for _, plural := range []string{"teams", "memberships", "organizations"} {
+		crd := &apiextensionsv1.CustomResourceDefinition{}
+		if err := c.Get(ctx, types.NamespacedName{Name: plural + "." + orgv1beta1.Group}, crd); err != nil {
+			return true
+		}
+		if crd.Spec.Conversion != nil && crd.Spec.Conversion.Strategy == apiextensionsv1.WebhookConverter {
+			return true
+		}
+	}
+	return false
+}
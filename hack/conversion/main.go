@@ -0,0 +1,75 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Conversion configures the supplied CustomResourceDefinitions to convert
+// between their versions using the provider's conversion webhook.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+const header = "---\n"
+
+// The webhook service is a placeholder, like the service of the generated
+// webhook configurations; the package manager points it at the provider.
+var conversion = map[string]interface{}{
+	"strategy": "Webhook",
+	"webhook": map[string]interface{}{
+		"conversionReviewVersions": []string{"v1"},
+		"clientConfig": map[string]interface{}{
+			"service": map[string]interface{}{
+				"name":      "webhook-service",
+				"namespace": "system",
+				"path":      "/convert",
+			},
+		},
+	},
+}
+
+func main() {
+	for _, f := range os.Args[1:] {
+		if err := convert(f); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+}
+
+func convert(path string) error {
+	b, err := os.ReadFile(path) //nolint:gosec // Paths are supplied by go:generate.
+	if err != nil {
+		return errors.Wrap(err, "cannot read CRD")
+	}
+	crd := map[string]interface{}{}
+	if err := yaml.Unmarshal(b, &crd); err != nil {
+		return errors.Wrapf(err, "cannot parse CRD %s", path)
+	}
+	spec, ok := crd["spec"].(map[string]interface{})
+	if !ok {
+		return errors.Errorf("CRD %s has no spec", path)
+	}
+	spec["conversion"] = conversion
+	out, err := yaml.Marshal(crd)
+	if err != nil {
+		return errors.Wrapf(err, "cannot serialize CRD %s", path)
+	}
+	return errors.Wrap(os.WriteFile(path, append([]byte(header), out...), 0o644), "cannot write CRD") //nolint:gosec // CRDs are not secret.
+}
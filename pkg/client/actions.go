@@ -0,0 +1,94 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-github/v45/github"
+)
+
+// A RunnerGroup extends github.RunnerGroup with settings that it does not yet
+// support.
+type RunnerGroup struct {
+	github.RunnerGroup
+	RestrictedToWorkflows *bool    `json:"restricted_to_workflows,omitempty"`
+	SelectedWorkflows     []string `json:"selected_workflows,omitempty"`
+}
+
+// A CreateRunnerGroupRequest extends github.CreateRunnerGroupRequest with
+// settings that it does not yet support.
+type CreateRunnerGroupRequest struct {
+	github.CreateRunnerGroupRequest
+	RestrictedToWorkflows *bool    `json:"restricted_to_workflows,omitempty"`
+	SelectedWorkflows     []string `json:"selected_workflows,omitempty"`
+}
+
+// An UpdateRunnerGroupRequest extends github.UpdateRunnerGroupRequest with
+// settings that it does not yet support.
+type UpdateRunnerGroupRequest struct {
+	github.UpdateRunnerGroupRequest
+	RestrictedToWorkflows *bool    `json:"restricted_to_workflows,omitempty"`
+	SelectedWorkflows     []string `json:"selected_workflows,omitempty"`
+}
+
+// GetRunnerGroup gets the supplied runner group, using the same endpoint as
+// ActionsService.GetOrganizationRunnerGroup.
+func GetRunnerGroup(ctx context.Context, c *github.Client, org string, id int64) (*RunnerGroup, error) {
+	return doRunnerGroup(ctx, c, http.MethodGet, fmt.Sprintf("orgs/%v/actions/runner-groups/%v", org, id), nil)
+}
+
+// CreateRunnerGroup creates the supplied runner group, using the same endpoint
+// as ActionsService.CreateOrganizationRunnerGroup.
+func CreateRunnerGroup(ctx context.Context, c *github.Client, org string, g CreateRunnerGroupRequest) (*RunnerGroup, error) {
+	return doRunnerGroup(ctx, c, http.MethodPost, fmt.Sprintf("orgs/%v/actions/runner-groups", org), g)
+}
+
+// UpdateRunnerGroup updates the supplied runner group, using the same endpoint
+// as ActionsService.UpdateOrganizationRunnerGroup.
+func UpdateRunnerGroup(ctx context.Context, c *github.Client, org string, id int64, g UpdateRunnerGroupRequest) (*RunnerGroup, error) {
+	return doRunnerGroup(ctx, c, http.MethodPatch, fmt.Sprintf("orgs/%v/actions/runner-groups/%v", org, id), g)
+}
+
+func doRunnerGroup(ctx context.Context, c *github.Client, method, u string, body interface{}) (*RunnerGroup, error) {
+	req, err := c.NewRequest(method, u, body)
+	if err != nil {
+		return nil, err
+	}
+	g := &RunnerGroup{}
+	if _, err := c.Do(ctx, req, g); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// RepositoryIDs returns the IDs of the supplied repositories of the supplied
+// organization, which GitHub Actions endpoints use to select repositories. It
+// never returns nil.
+func RepositoryIDs(ctx context.Context, c *github.Client, org string, names []string) ([]int64, error) {
+	ids := make([]int64, 0, len(names))
+	for _, n := range names {
+		r, _, err := c.Repositories.Get(ctx, org, n)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, r.GetID())
+	}
+	return ids, nil
+}
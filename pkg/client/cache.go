@@ -0,0 +1,173 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v45/github"
+)
+
+// DefaultObservationTTL is how long an ObservationCache shares the results of
+// a query by default.
+const DefaultObservationTTL = 30 * time.Second
+
+type cacheEntry struct {
+	done    chan struct{}
+	expires time.Time
+	value   interface{}
+	err     error
+}
+
+// An ObservationCache observes GitHub resources in batches using the GraphQL
+// API, and shares the results between concurrent observations for a short
+// time. This allows many managed resources, for example the Memberships of a
+// team, to be observed using a handful of API calls.
+type ObservationCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*cacheEntry
+}
+
+// NewObservationCache returns an ObservationCache that shares results for the
+// supplied duration.
+func NewObservationCache(ttl time.Duration) *ObservationCache {
+	return &ObservationCache{ttl: ttl, now: time.Now, entries: map[string]*cacheEntry{}}
+}
+
+func cacheKey(parts ...string) string {
+	return fmt.Sprintf("%q", parts)
+}
+
+// get returns the value cached at the supplied key, calling fetch to fill the
+// cache if it is empty or expired. Concurrent callers share a single call to
+// fetch. Errors are returned to all callers waiting on the failed fetch, but
+// are not cached.
+func (c *ObservationCache) get(ctx context.Context, key string, fetch func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	c.mu.Lock()
+	e, ok := c.entries[key]
+	if ok {
+		select {
+		case <-e.done:
+			if c.now().After(e.expires) {
+				ok = false
+			}
+		default:
+			// Another caller is fetching this entry.
+		}
+	}
+	if !ok {
+		c.sweep()
+		e = &cacheEntry{done: make(chan struct{})}
+		c.entries[key] = e
+		c.mu.Unlock()
+
+		e.value, e.err = fetch(ctx)
+		e.expires = c.now().Add(c.ttl)
+
+		c.mu.Lock()
+		if e.err != nil && c.entries[key] == e {
+			delete(c.entries, key)
+		}
+		c.mu.Unlock()
+		close(e.done)
+		return e.value, e.err
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-e.done:
+		return e.value, e.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// sweep removes expired entries. It must be called with the mutex held.
+func (c *ObservationCache) sweep() {
+	now := c.now()
+	for k, e := range c.entries {
+		select {
+		case <-e.done:
+			if now.After(e.expires) {
+				delete(c.entries, k)
+			}
+		default:
+		}
+	}
+}
+
+func (c *ObservationCache) invalidate(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, key)
+}
+
+// TeamMembers returns the active memberships of the supplied team, keyed by
+// user login. Results are shared between callers of the same scope, which
+// should identify the credentials used by the supplied GraphQLClient.
+func (c *ObservationCache) TeamMembers(ctx context.Context, q *GraphQLClient, scope, org, team string) (map[string]*github.Membership, error) {
+	v, err := c.get(ctx, cacheKey("members", scope, org, team), func(ctx context.Context) (interface{}, error) {
+		return q.TeamMembers(ctx, org, team)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(map[string]*github.Membership), nil
+}
+
+// InvalidateTeamMembers discards the cached members of the supplied team.
+func (c *ObservationCache) InvalidateTeamMembers(scope, org, team string) {
+	c.invalidate(cacheKey("members", scope, org, team))
+}
+
+// Teams returns the teams of the supplied organization, keyed by slug. Results
+// are shared between callers of the same scope, which should identify the
+// credentials used by the supplied GraphQLClient.
+func (c *ObservationCache) Teams(ctx context.Context, q *GraphQLClient, scope, org string) (map[string]*Team, error) {
+	v, err := c.get(ctx, cacheKey("teams", scope, org), func(ctx context.Context) (interface{}, error) {
+		return q.Teams(ctx, org)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(map[string]*Team), nil
+}
+
+// InvalidateTeams discards the cached teams of the supplied organization.
+func (c *ObservationCache) InvalidateTeams(scope, org string) {
+	c.invalidate(cacheKey("teams", scope, org))
+}
+
+// ExternalIdentities returns the logins of the users linked to the supplied
+// organization's identity provider, keyed by their SAML NameID and their SCIM
+// username. Results are shared between callers of the same scope, which
+// should identify the credentials used by the supplied GraphQLClient.
+func (c *ObservationCache) ExternalIdentities(ctx context.Context, q *GraphQLClient, scope, org string) (map[string]string, error) {
+	v, err := c.get(ctx, cacheKey("identities", scope, org), func(ctx context.Context) (interface{}, error) {
+		return q.ExternalIdentities(ctx, org)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(map[string]string), nil
+}
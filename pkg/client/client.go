@@ -2,6 +2,9 @@ package client
 
 import (
 	"context"
+	"net/http"
+	"net/url"
+	"strings"
 
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 	"github.com/google/go-github/v45/github"
@@ -21,24 +24,62 @@ const (
 	errGetPC        = "cannot get ProviderConfig"
 	errNoSecretRef  = "ProviderConfig does not reference a credentials Secret"
 	errGetSecret    = "cannot get credentials Secret"
+	errNoOrg        = "no organization: set the organization of the managed resource or the default organization of its ProviderConfig"
 
 	errNewClient = "cannot create new Service"
+	errParseURL  = "cannot parse GitHub API base URL"
 )
 
-// NewClient creates a new client.
-func NewClient(token string) (*github.Client, error) {
+// NewClient creates a new client that authenticates using the supplied token.
+// Metrics about the requests it makes are labelled with the supplied
+// ProviderConfig name.
+func NewClient(token, providerConfig string) (*github.Client, error) {
 	if token == "" {
 		return nil, errors.New(errEmptyToken)
 	}
-	ctx := context.Background()
 	ts := oauth2.StaticTokenSource(
 		&oauth2.Token{AccessToken: token},
 	)
-	tc := oauth2.NewClient(ctx, ts)
+	tc := &http.Client{Transport: &oauth2.Transport{
+		Source: ts,
+		Base:   &InstrumentedTransport{ProviderConfig: providerConfig},
+	}}
 
 	return github.NewClient(tc), nil
 }
 
+// IsNotFound returns true if the supplied error was returned because the GitHub
+// API responded that the requested resource was not found.
+func IsNotFound(err error) bool {
+	var rerr *github.ErrorResponse
+	return errors.As(err, &rerr) && rerr.Response != nil && rerr.Response.StatusCode == http.StatusNotFound
+}
+
+// IsEnterpriseServer returns true if the supplied client makes requests to a
+// GitHub Enterprise Server, rather than to github.com.
+func IsEnterpriseServer(c *github.Client) bool {
+	return c.BaseURL.Host != "api.github.com"
+}
+
+// setBaseURL configures the supplied client to make requests to the GitHub API
+// served at the supplied base URL.
+func setBaseURL(c *github.Client, baseURL string) error {
+	if !strings.HasSuffix(baseURL, "/") {
+		baseURL += "/"
+	}
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return errors.Wrap(err, errParseURL)
+	}
+	c.BaseURL = u
+	c.UploadURL = u
+	return nil
+}
+
+// UseProviderConfig tracks that the supplied managed resource uses its
+// ProviderConfig, and returns a client that uses the ProviderConfig's
+// credentials. Clients are pooled, and shared by all managed resources that use
+// the same ProviderConfig.
 func UseProviderConfig(ctx context.Context, c client.Client, mg resource.Managed) (*github.Client, error) {
 	usage := resource.NewProviderConfigUsageTracker(c, &apisv1alpha1.ProviderConfigUsage{})
 
@@ -51,20 +92,52 @@ func UseProviderConfig(ctx context.Context, c client.Client, mg resource.Managed
 		return nil, errors.Wrap(err, errGetPC)
 	}
 
+	token, err := Token(ctx, c, pc)
+	if err != nil {
+		return nil, err
+	}
+	return PooledClient(pc, token)
+}
+
+// Organization returns the supplied organization of the supplied managed
+// resource. If no organization is supplied it returns the default organization
+// of the managed resource's ProviderConfig.
+func Organization(ctx context.Context, c client.Reader, mg resource.Managed, org string) (string, error) {
+	if org != "" {
+		return org, nil
+	}
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := c.Get(ctx, types.NamespacedName{Name: mg.GetProviderConfigReference().Name}, pc); err != nil {
+		return "", errors.Wrap(err, errGetPC)
+	}
+	if pc.Spec.DefaultOrganization == nil || *pc.Spec.DefaultOrganization == "" {
+		return "", errors.New(errNoOrg)
+	}
+	return *pc.Spec.DefaultOrganization, nil
+}
+
+// Token returns the token read from the credentials Secret of the supplied
+// ProviderConfig.
+func Token(ctx context.Context, c client.Client, pc *apisv1alpha1.ProviderConfig) (string, error) {
 	// A secret is the most common way to authenticate to a provider, but some
 	// providers additionally support alternative authentication methods such as
 	// IAM, so a reference is not required.
 	ref := pc.Spec.Credentials.SecretRef
 	if ref == nil {
-		return nil, errors.New(errNoSecretRef)
+		return "", errors.New(errNoSecretRef)
 	}
 
 	s := &v1.Secret{}
 	if err := c.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
-		return nil, errors.Wrap(err, errGetSecret)
+		return "", errors.Wrap(err, errGetSecret)
 	}
+	return string(s.Data[ref.Key]), nil
+}
 
-	svc, err := NewClient(string(s.Data[ref.Key]))
+// PooledClient returns a client that authenticates using the supplied token on
+// behalf of the supplied ProviderConfig, reusing a pooled client if possible.
+func PooledClient(pc *apisv1alpha1.ProviderConfig, token string) (*github.Client, error) {
+	svc, err := defaultPool.Get(pc, token)
 	if err != nil {
 		return nil, errors.Wrap(err, errNewClient)
 	}
@@ -0,0 +1,138 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v45/github"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/hasheddan/kc-provider-github/apis/org/v1beta1"
+	apisv1alpha1 "github.com/hasheddan/kc-provider-github/apis/v1alpha1"
+)
+
+func TestOrganization(t *testing.T) {
+	errBoom := errors.New("boom")
+	mg := &v1beta1.Team{Spec: v1beta1.TeamSpec{
+		ResourceSpec: xpv1.ResourceSpec{ProviderConfigReference: &xpv1.Reference{Name: "default"}},
+	}}
+
+	type args struct {
+		kube client.Reader
+		org  string
+	}
+	type want struct {
+		org string
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"Supplied": {
+			reason: "A supplied organization should be returned.",
+			args: args{
+				org: "crossplane",
+			},
+			want: want{
+				org: "crossplane",
+			},
+		},
+		"Default": {
+			reason: "The ProviderConfig's default organization should be returned if no organization is supplied.",
+			args: args{
+				kube: &test.MockClient{MockGet: test.NewMockGetFn(nil, func(o client.Object) error {
+					o.(*apisv1alpha1.ProviderConfig).Spec.DefaultOrganization = github.String("crossplane")
+					return nil
+				})},
+			},
+			want: want{
+				org: "crossplane",
+			},
+		},
+		"NoDefault": {
+			reason: "An error should be returned if no organization is supplied and the ProviderConfig has no default.",
+			args: args{
+				kube: &test.MockClient{MockGet: test.NewMockGetFn(nil)},
+			},
+			want: want{
+				err: errors.New(errNoOrg),
+			},
+		},
+		"GetProviderConfigError": {
+			reason: "An error should be returned if the ProviderConfig cannot be read.",
+			args: args{
+				kube: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errGetPC),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := Organization(context.Background(), tc.args.kube, mg, tc.args.org)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nOrganization(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.org, got); diff != "" {
+				t.Errorf("\n%s\nOrganization(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestIsEnterpriseServer(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		baseURL string
+		want    bool
+	}{
+		"GitHub": {
+			reason:  "A client of github.com should not target an Enterprise Server.",
+			baseURL: "https://api.github.com/",
+			want:    false,
+		},
+		"EnterpriseServer": {
+			reason:  "A client of any other host should target an Enterprise Server.",
+			baseURL: "https://github.example.org/api/v3/",
+			want:    true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := github.NewClient(nil)
+			if err := setBaseURL(c, tc.baseURL); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want, IsEnterpriseServer(c)); diff != "" {
+				t.Errorf("\n%s\nIsEnterpriseServer(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
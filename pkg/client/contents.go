@@ -0,0 +1,68 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"crypto/sha1" //nolint:gosec // Git identifies blobs by their SHA-1.
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/google/go-github/v45/github"
+)
+
+// BlobSHA returns the SHA Git assigns a blob with the supplied content, which
+// is the SHA the Contents API reports for a file with that content.
+func BlobSHA(content []byte) string {
+	blob := append([]byte(fmt.Sprintf("blob %d\x00", len(content))), content...)
+	sum := sha1.Sum(blob) //nolint:gosec // Git identifies blobs by their SHA-1.
+	return hex.EncodeToString(sum[:])
+}
+
+// A CodeOwnersError is an error GitHub found in a CODEOWNERS file.
+type CodeOwnersError struct {
+	Line       int    `json:"line"`
+	Column     int    `json:"column"`
+	Kind       string `json:"kind"`
+	Source     string `json:"source"`
+	Suggestion string `json:"suggestion,omitempty"`
+	Message    string `json:"message"`
+	Path       string `json:"path"`
+}
+
+// ListCodeOwnersErrors lists the errors GitHub found in the CODEOWNERS file of
+// the supplied repository, at the supplied ref or the repository's default
+// branch. RepositoriesService does not yet support this endpoint.
+func ListCodeOwnersErrors(ctx context.Context, c *github.Client, owner, repo, ref string) ([]CodeOwnersError, error) {
+	u := fmt.Sprintf("repos/%v/%v/codeowners/errors", owner, repo)
+	if ref != "" {
+		u += "?ref=" + url.QueryEscape(ref)
+	}
+	req, err := c.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	rsp := &struct {
+		Errors []CodeOwnersError `json:"errors"`
+	}{}
+	if _, err := c.Do(ctx, req, rsp); err != nil {
+		return nil, err
+	}
+	return rsp.Errors, nil
+}
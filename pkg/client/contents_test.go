@@ -0,0 +1,51 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestBlobSHA(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		content string
+		want    string
+	}{
+		"Empty": {
+			reason:  "The SHA of an empty file should be that of Git's empty blob.",
+			content: "",
+			want:    "e69de29bb2d1d6434b8b29ae775ad8c2e48c5391",
+		},
+		"Content": {
+			reason:  "The SHA of a file should be the SHA git hash-object reports for it.",
+			content: "hello\n",
+			want:    "ce013625030ba8dba906f756967f9e9ca394464a",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := BlobSHA([]byte(tc.content))
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nBlobSHA(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-github/v45/github"
+)
+
+// A CustomRepoRole is a custom repository role of an organization.
+// OrganizationsService only lists the IDs and names of custom repository
+// roles.
+type CustomRepoRole struct {
+	ID          int64    `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	BaseRole    string   `json:"base_role"`
+	Permissions []string `json:"permissions"`
+}
+
+// CustomRepoRoleOptions are the settings of a custom repository role that may
+// be created or edited.
+type CustomRepoRoleOptions struct {
+	Name        string   `json:"name"`
+	Description *string  `json:"description,omitempty"`
+	BaseRole    string   `json:"base_role"`
+	Permissions []string `json:"permissions"`
+}
+
+// ListCustomRepoRoles lists the custom repository roles of the supplied
+// organization, paging through all of them. OrganizationsService lists only
+// their first page.
+func ListCustomRepoRoles(ctx context.Context, c *github.Client, org string) ([]*CustomRepoRole, error) {
+	var roles []*CustomRepoRole
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		req, err := c.NewRequest(http.MethodGet, fmt.Sprintf("orgs/%v/custom_roles?per_page=%d&page=%d", org, opts.PerPage, opts.Page), nil)
+		if err != nil {
+			return nil, err
+		}
+		l := &struct {
+			CustomRoles []*CustomRepoRole `json:"custom_roles"`
+		}{}
+		rsp, err := c.Do(ctx, req, l)
+		if err != nil {
+			return nil, err
+		}
+		roles = append(roles, l.CustomRoles...)
+		if rsp.NextPage == 0 {
+			return roles, nil
+		}
+		opts.Page = rsp.NextPage
+	}
+}
+
+// GetCustomRepoRole gets the supplied custom repository role.
+func GetCustomRepoRole(ctx context.Context, c *github.Client, org string, id int64) (*CustomRepoRole, error) {
+	return doCustomRepoRole(ctx, c, http.MethodGet, fmt.Sprintf("orgs/%v/custom_roles/%v", org, id), nil)
+}
+
+// CreateCustomRepoRole creates the supplied custom repository role.
+func CreateCustomRepoRole(ctx context.Context, c *github.Client, org string, o CustomRepoRoleOptions) (*CustomRepoRole, error) {
+	return doCustomRepoRole(ctx, c, http.MethodPost, fmt.Sprintf("orgs/%v/custom_roles", org), o)
+}
+
+// EditCustomRepoRole edits the supplied custom repository role.
+func EditCustomRepoRole(ctx context.Context, c *github.Client, org string, id int64, o CustomRepoRoleOptions) (*CustomRepoRole, error) {
+	return doCustomRepoRole(ctx, c, http.MethodPatch, fmt.Sprintf("orgs/%v/custom_roles/%v", org, id), o)
+}
+
+// DeleteCustomRepoRole deletes the supplied custom repository role. Teams and
+// users who were granted the role lose access to its repositories.
+func DeleteCustomRepoRole(ctx context.Context, c *github.Client, org string, id int64) error {
+	req, err := c.NewRequest(http.MethodDelete, fmt.Sprintf("orgs/%v/custom_roles/%v", org, id), nil)
+	if err != nil {
+		return err
+	}
+	_, err = c.Do(ctx, req, nil)
+	return err
+}
+
+func doCustomRepoRole(ctx context.Context, c *github.Client, method, u string, body interface{}) (*CustomRepoRole, error) {
+	req, err := c.NewRequest(method, u, body)
+	if err != nil {
+		return nil, err
+	}
+	r := &CustomRepoRole{}
+	if _, err := c.Do(ctx, req, r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
@@ -0,0 +1,34 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+// DiffErrors returns a diff of the supplied errors. Any error matches
+// cmpopts.AnyError, which tests use for errors returned by a Server since
+// their messages include its address.
+func DiffErrors(want, got error) string {
+	if want == cmpopts.AnyError && got != nil {
+		return ""
+	}
+	return cmp.Diff(want, got, test.EquateErrors())
+}
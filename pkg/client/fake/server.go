@@ -0,0 +1,2379 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fake provides an in-memory fake of the GitHub REST API for use in
+// tests.
+package fake
+
+import (
+	"crypto/sha1" //nolint:gosec // Git identifies blobs by their SHA-1.
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v45/github"
+)
+
+// Defaults that match those of the GitHub API.
+const (
+	DefaultRateLimit = 5000
+	DefaultPageSize  = 30
+	MaxPageSize      = 100
+	DefaultBranch    = "main"
+)
+
+type team struct {
+	team         github.Team
+	notification string
+	members      map[string]*github.Membership
+	idpGroups    []*github.IDPGroup
+
+	// Whether the team is assigned the security manager role.
+	securityManager bool
+
+	// The team's permissions, keyed by repository.
+	repos map[string]string
+}
+
+// A fullTeam extends github.Team with settings it does not support.
+type fullTeam struct {
+	github.Team
+	NotificationSetting string `json:"notification_setting,omitempty"`
+}
+
+// A newTeam extends github.NewTeam with settings it does not support.
+type newTeam struct {
+	github.NewTeam
+	NotificationSetting *string `json:"notification_setting,omitempty"`
+}
+
+func (t *team) full() fullTeam {
+	return fullTeam{Team: t.team, NotificationSetting: t.notification}
+}
+
+// An organization extends github.Organization with settings it does not
+// support.
+type organization struct {
+	github.Organization
+	WebCommitSignoffRequired *bool `json:"web_commit_signoff_required,omitempty"`
+}
+
+// A CustomRole is a custom repository role of an organization.
+type CustomRole struct {
+	ID          int64    `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	BaseRole    string   `json:"base_role"`
+	Permissions []string `json:"permissions"`
+}
+
+// ActionsPermissions are the GitHub Actions permissions of an organization.
+type ActionsPermissions struct {
+	EnabledRepositories   string
+	AllowedActions        string
+	SelectedActions       github.ActionsAllowed
+	SelectedRepositoryIDs []int64
+}
+
+// A RunnerGroup is a self-hosted runner group of an organization.
+type RunnerGroup struct {
+	ID                       int64    `json:"id"`
+	Name                     string   `json:"name"`
+	Visibility               string   `json:"visibility"`
+	AllowsPublicRepositories bool     `json:"allows_public_repositories"`
+	RestrictedToWorkflows    bool     `json:"restricted_to_workflows"`
+	SelectedWorkflows        []string `json:"selected_workflows"`
+	SelectedRepositoryIDs    []int64  `json:"-"`
+}
+
+// A runnerGroupRequest creates or updates a runner group.
+type runnerGroupRequest struct {
+	Name                     *string  `json:"name"`
+	Visibility               *string  `json:"visibility"`
+	SelectedRepositoryIDs    []int64  `json:"selected_repository_ids"`
+	AllowsPublicRepositories *bool    `json:"allows_public_repositories"`
+	RestrictedToWorkflows    *bool    `json:"restricted_to_workflows"`
+	SelectedWorkflows        []string `json:"selected_workflows"`
+}
+
+// A RepoFile is a file committed to a branch of a repository, and the commit
+// that last changed it.
+type RepoFile struct {
+	Content string
+	SHA     string
+	Message string
+	Author  *github.CommitAuthor
+}
+
+// A fileKey identifies a file on a branch of a repository.
+type fileKey struct {
+	repo, branch, path string
+}
+
+// blobSHA returns the SHA Git assigns a blob with the supplied content.
+func blobSHA(content string) string {
+	sum := sha1.Sum([]byte(fmt.Sprintf("blob %d\x00%s", len(content), content))) //nolint:gosec // Git identifies blobs by their SHA-1.
+	return hex.EncodeToString(sum[:])
+}
+
+type org struct {
+	org       github.Organization
+	signoff   *bool
+	members   map[string]*github.Membership
+	invites   map[string]*github.Invitation // Keyed by login, or email if the invitee has no login.
+	failed    []*github.Invitation
+	joins     map[int64][]string // Slugs of the teams invitees join, keyed by invitation ID.
+	nameIDs   map[string]string  // Logins keyed by NameID.
+	outside   map[string]bool    // Logins of outside collaborators.
+	blocked   map[string]bool    // Logins of blocked users.
+	roles     []*CustomRole
+	actions   ActionsPermissions
+	runners   []*RunnerGroup
+	tokens    int                        // Registration tokens created.
+	branches  map[string]map[string]bool // Branches keyed by repository.
+	copying   map[string]map[string]bool // Branches yet to be copied to generated and forked repositories.
+	files     map[fileKey]*RepoFile
+	teams     map[string]*team
+	repos     map[string]*github.Repository
+	idpGroups []*github.IDPGroup
+}
+
+// A Server is an in-memory fake of the GitHub REST API. It serves the
+// organization, team, team membership and repository endpoints, responding
+// with the status codes, pagination links and rate limit headers GitHub would.
+type Server struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	nextID    int64
+	orgs      map[string]*org
+	users     map[string]*github.User
+	limit     int
+	remaining int
+	reset     time.Time
+	requests  int
+
+	// The token used to make requests.
+	login      string
+	scopes     []string
+	expiration time.Time
+}
+
+// NewServer starts and returns a new Server. Callers should call Close when
+// finished to shut it down.
+func NewServer() *Server {
+	s := &Server{
+		nextID:    1,
+		orgs:      map[string]*org{},
+		users:     map[string]*github.User{},
+		limit:     DefaultRateLimit,
+		remaining: DefaultRateLimit,
+		reset:     time.Now().Add(time.Hour),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	return s
+}
+
+// Client returns a GitHub client that makes requests to the Server.
+func (s *Server) Client() *github.Client {
+	c := github.NewClient(s.Server.Client())
+	c.BaseURL, _ = url.Parse(s.URL + "/")
+	return c
+}
+
+// SetRateLimit sets the number of requests the Server will serve before it
+// responds that the rate limit was exceeded.
+func (s *Server) SetRateLimit(remaining int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.remaining = remaining
+}
+
+// SetToken sets the user and OAuth scopes of the token used to make requests,
+// and when it expires. The token authenticates as an installation if the login
+// is empty, and does not expire if the expiration is zero.
+func (s *Server) SetToken(login string, scopes []string, expiration time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if login != "" {
+		s.addUser(login)
+	}
+	s.login = login
+	s.scopes = scopes
+	s.expiration = expiration
+}
+
+// Requests returns the number of requests the Server has served.
+func (s *Server) Requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests
+}
+
+func (s *Server) id() int64 {
+	id := s.nextID
+	s.nextID++
+	return id
+}
+
+// AddUser adds a user to the Server.
+func (s *Server) AddUser(login string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.addUser(login)
+}
+
+func (s *Server) addUser(login string) *github.User {
+	if u, ok := s.users[login]; ok {
+		return u
+	}
+	id := s.id()
+	u := &github.User{
+		Login:  github.String(login),
+		ID:     github.Int64(id),
+		NodeID: github.String(fmt.Sprintf("U_%d", id)),
+		Type:   github.String("User"),
+	}
+	s.users[login] = u
+	return u
+}
+
+// EditUser edits the supplied user as if they edited their profile.
+func (s *Server) EditUser(login string, fn func(u *github.User)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fn(s.users[login])
+}
+
+// RenameUser changes the login of the supplied user, as if they changed their
+// username.
+func (s *Server) RenameUser(login, newLogin string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u := s.users[login]
+	delete(s.users, login)
+	u.Login = github.String(newLogin)
+	s.users[newLogin] = u
+}
+
+// AddOrg adds an organization to the Server.
+func (s *Server) AddOrg(login string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := s.id()
+	s.orgs[login] = &org{
+		org: github.Organization{
+			Login:  github.String(login),
+			ID:     github.Int64(id),
+			NodeID: github.String(fmt.Sprintf("O_%d", id)),
+			Type:   github.String("Organization"),
+		},
+		members:  map[string]*github.Membership{},
+		invites:  map[string]*github.Invitation{},
+		joins:    map[int64][]string{},
+		outside:  map[string]bool{},
+		blocked:  map[string]bool{},
+		actions:  ActionsPermissions{EnabledRepositories: "all", AllowedActions: "all"},
+		branches: map[string]map[string]bool{},
+		copying:  map[string]map[string]bool{},
+		files:    map[fileKey]*RepoFile{},
+		teams:    map[string]*team{},
+		repos:    map[string]*github.Repository{},
+	}
+}
+
+// Org returns the supplied organization, if it exists.
+func (s *Server) Org(login string) (github.Organization, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o, ok := s.orgs[login]
+	if !ok {
+		return github.Organization{}, false
+	}
+	return o.org, true
+}
+
+// EditOrg edits the supplied organization as if it were edited outside the
+// provider, for example by an owner in the GitHub web UI.
+func (s *Server) EditOrg(login string, fn func(o *github.Organization)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fn(&s.orgs[login].org)
+}
+
+// AddOrgMember adds the supplied user, who is added to the Server if
+// necessary, to the supplied organization with the supplied role.
+func (s *Server) AddOrgMember(orgLogin, login, role string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.addUser(login)
+	s.orgs[orgLogin].members[login] = &github.Membership{Role: github.String(role), State: github.String("active")}
+}
+
+// OrgMembership returns the supplied user's membership of the supplied
+// organization, if it exists.
+func (s *Server) OrgMembership(orgLogin, login string) (github.Membership, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o, ok := s.orgs[orgLogin]
+	if !ok {
+		return github.Membership{}, false
+	}
+	m, ok := o.members[login]
+	if !ok {
+		return github.Membership{}, false
+	}
+	return *m, true
+}
+
+// AddOutsideCollaborator adds the supplied user, who is added to the Server if
+// necessary, to the supplied organization as an outside collaborator.
+func (s *Server) AddOutsideCollaborator(orgLogin, login string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.addUser(login)
+	s.orgs[orgLogin].outside[login] = true
+}
+
+// IsOutsideCollaborator returns true if the supplied user is an outside
+// collaborator of the supplied organization.
+func (s *Server) IsOutsideCollaborator(orgLogin, login string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o, ok := s.orgs[orgLogin]
+	return ok && o.outside[login]
+}
+
+// BlockUser blocks the supplied user, who is added to the Server if necessary,
+// from the supplied organization.
+func (s *Server) BlockUser(orgLogin, login string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.addUser(login)
+	block(s.orgs[orgLogin], login)
+}
+
+// IsBlocked returns true if the supplied user is blocked from the supplied
+// organization.
+func (s *Server) IsBlocked(orgLogin, login string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o, ok := s.orgs[orgLogin]
+	return ok && o.blocked[login]
+}
+
+// block the supplied user from the supplied organization. Blocked users lose
+// their membership of the organization and its teams, their access as an
+// outside collaborator, and any pending invitation.
+func block(o *org, login string) {
+	leave(o, login)
+	delete(o.outside, login)
+	cancel(o, login)
+	o.blocked[login] = true
+}
+
+// leave removes the supplied user from the supplied organization and its
+// teams.
+func leave(o *org, login string) {
+	delete(o.members, login)
+	for _, t := range o.teams {
+		delete(t.members, login)
+	}
+}
+
+// AddCustomRole adds the supplied custom repository role to the supplied
+// organization, returning its ID.
+func (s *Server) AddCustomRole(orgLogin string, r CustomRole) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r.ID = s.id()
+	o := s.orgs[orgLogin]
+	o.roles = append(o.roles, &r)
+	return r.ID
+}
+
+// CustomRole returns the supplied custom repository role, if it exists.
+func (s *Server) CustomRole(orgLogin string, id int64) (CustomRole, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o, ok := s.orgs[orgLogin]
+	if !ok {
+		return CustomRole{}, false
+	}
+	for _, r := range o.roles {
+		if r.ID == id {
+			return *r, true
+		}
+	}
+	return CustomRole{}, false
+}
+
+// SetSecurityManagerTeam assigns or removes the security manager role of the
+// supplied organization to or from the supplied team.
+func (s *Server) SetSecurityManagerTeam(orgLogin, slug string, assigned bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.orgs[orgLogin].teams[slug].securityManager = assigned
+}
+
+// IsSecurityManagerTeam returns true if the supplied team is assigned the
+// security manager role of the supplied organization.
+func (s *Server) IsSecurityManagerTeam(orgLogin, slug string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o, ok := s.orgs[orgLogin]
+	if !ok {
+		return false
+	}
+	t, ok := o.teams[slug]
+	return ok && t.securityManager
+}
+
+// AddExternalIdentity links the supplied user, who is added to the Server if
+// necessary, to the supplied organization's SAML identity provider with the
+// supplied NameID.
+func (s *Server) AddExternalIdentity(orgLogin, nameID, login string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.addUser(login)
+	o := s.orgs[orgLogin]
+	if o.nameIDs == nil {
+		o.nameIDs = map[string]string{}
+	}
+	o.nameIDs[nameID] = login
+}
+
+// AddOrgInvitation invites the supplied user, who is added to the Server if
+// necessary, to the supplied organization at the supplied time.
+func (s *Server) AddOrgInvitation(orgLogin, login string, created time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.addUser(login)
+	s.invite(s.orgs[orgLogin], login).CreatedAt = &created
+}
+
+// invite the supplied user to the supplied organization, unless they are
+// already invited. It returns their invitation.
+func (s *Server) invite(o *org, login string) *github.Invitation {
+	if i, ok := o.invites[login]; ok {
+		return i
+	}
+	now := time.Now()
+	i := &github.Invitation{
+		ID:        github.Int64(s.id()),
+		Login:     github.String(login),
+		Role:      github.String("direct_member"),
+		CreatedAt: &now,
+	}
+	o.invites[login] = i
+	return i
+}
+
+// OrgInvitation returns the pending invitation to the supplied organization
+// of the supplied login or email, if any.
+func (s *Server) OrgInvitation(orgLogin, key string) (github.Invitation, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o, ok := s.orgs[orgLogin]
+	if !ok {
+		return github.Invitation{}, false
+	}
+	i, ok := o.invites[key]
+	if !ok {
+		return github.Invitation{}, false
+	}
+	return *i, true
+}
+
+// AcceptOrgInvitation accepts the pending invitation to the supplied
+// organization of the supplied login or email, as the supplied user. The user
+// joins the organization, and the teams they were invited to.
+func (s *Server) AcceptOrgInvitation(orgLogin, key, login string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o := s.orgs[orgLogin]
+	i := o.invites[key]
+	delete(o.invites, key)
+	s.addUser(login)
+	role := "member"
+	if i.GetRole() == "admin" {
+		role = "admin"
+	}
+	o.members[login] = &github.Membership{Role: github.String(role), State: github.String("active")}
+	for _, t := range o.teams {
+		if m, ok := t.members[key]; ok {
+			m.State = github.String("active")
+		}
+	}
+	for _, slug := range o.joins[i.GetID()] {
+		if t, ok := o.teams[slug]; ok {
+			t.members[login] = &github.Membership{Role: github.String("member"), State: github.String("active")}
+		}
+	}
+}
+
+// ExpireOrgInvitation expires the pending invitation to the supplied
+// organization of the supplied login or email, as if it were not accepted
+// within 7 days.
+func (s *Server) ExpireOrgInvitation(orgLogin, key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o := s.orgs[orgLogin]
+	i := o.invites[key]
+	cancel(o, key)
+	i.FailedAt = &github.Timestamp{Time: time.Now()}
+	i.FailedReason = github.String("expired")
+	o.failed = append(o.failed, i)
+}
+
+// cancel the pending invitation of the supplied login or email to the
+// supplied organization, and their pending team memberships.
+func cancel(o *org, key string) {
+	delete(o.invites, key)
+	for _, t := range o.teams {
+		if m, ok := t.members[key]; ok && m.GetState() == "pending" {
+			delete(t.members, key)
+		}
+	}
+}
+
+// AddTeam adds the supplied team to the supplied organization, returning the
+// team as the API would.
+func (s *Server) AddTeam(orgLogin string, t github.NewTeam) *github.Team {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return &s.addTeam(s.orgs[orgLogin], t).team
+}
+
+func (s *Server) addTeam(o *org, nt github.NewTeam) *team {
+	id := s.id()
+	t := &team{
+		team: github.Team{
+			ID:           github.Int64(id),
+			NodeID:       github.String(fmt.Sprintf("T_%d", id)),
+			Name:         github.String(nt.Name),
+			Slug:         github.String(Slug(nt.Name)),
+			Description:  nt.Description,
+			Privacy:      github.String("secret"),
+			Permission:   github.String("pull"),
+			Organization: &o.org,
+		},
+		notification: "notifications_enabled",
+		members:      map[string]*github.Membership{},
+		repos:        map[string]string{},
+	}
+	if nt.Privacy != nil {
+		t.team.Privacy = nt.Privacy
+	}
+	if nt.LDAPDN != nil {
+		t.team.LDAPDN = nt.LDAPDN
+	}
+	if nt.ParentTeamID != nil {
+		// Nested teams are closed.
+		t.team.Parent = parent(o, *nt.ParentTeamID)
+		t.team.Privacy = github.String("closed")
+	}
+	o.teams[t.team.GetSlug()] = t
+	return t
+}
+
+// parent returns the team with the supplied ID, as it is represented when it is
+// the parent of another team.
+func parent(o *org, id int64) *github.Team {
+	for _, t := range o.teams {
+		if t.team.GetID() == id {
+			return &github.Team{ID: t.team.ID, NodeID: t.team.NodeID, Name: t.team.Name, Slug: t.team.Slug}
+		}
+	}
+	return nil
+}
+
+// AddTeamMember adds the supplied user, who is added to the Server if
+// necessary, to the supplied team with the supplied role and state. Users
+// whose membership is pending are invited to the organization.
+func (s *Server) AddTeamMember(orgLogin, slug, login, role, state string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.addUser(login)
+	s.orgs[orgLogin].teams[slug].members[login] = &github.Membership{Role: github.String(role), State: github.String(state)}
+	if state == "pending" {
+		s.invite(s.orgs[orgLogin], login)
+	}
+}
+
+// EditTeam edits the supplied team as if it were edited outside the provider,
+// for example by a user in the GitHub web UI.
+func (s *Server) EditTeam(orgLogin, slug string, nt github.NewTeam) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t := s.orgs[orgLogin].teams[slug]
+	if nt.Description != nil {
+		t.team.Description = nt.Description
+	}
+	if nt.Privacy != nil {
+		t.team.Privacy = nt.Privacy
+	}
+}
+
+// SetTeamNotificationSetting sets the notification setting of the supplied
+// team as if it were edited outside the provider.
+func (s *Server) SetTeamNotificationSetting(orgLogin, slug, setting string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.orgs[orgLogin].teams[slug].notification = setting
+}
+
+// TeamNotificationSetting returns the notification setting of the supplied
+// team, if it exists.
+func (s *Server) TeamNotificationSetting(orgLogin, slug string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o, ok := s.orgs[orgLogin]
+	if !ok {
+		return "", false
+	}
+	t, ok := o.teams[slug]
+	if !ok {
+		return "", false
+	}
+	return t.notification, true
+}
+
+// AddIdPGroup adds a group to the identity provider of the supplied
+// organization, making it available to be connected to its teams.
+func (s *Server) AddIdPGroup(orgLogin, id, name, description string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o := s.orgs[orgLogin]
+	o.idpGroups = append(o.idpGroups, &github.IDPGroup{GroupID: github.String(id), GroupName: github.String(name), GroupDescription: github.String(description)})
+}
+
+// ConnectIdPGroups connects the identity provider groups with the supplied IDs
+// to the supplied team, replacing any groups that were connected, as if they
+// were connected outside the provider.
+func (s *Server) ConnectIdPGroups(orgLogin, slug string, ids ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o := s.orgs[orgLogin]
+	groups := make([]*github.IDPGroup, 0, len(ids))
+	for _, id := range ids {
+		if g := idpGroup(o, id); g != nil {
+			groups = append(groups, g)
+		}
+	}
+	o.teams[slug].idpGroups = groups
+}
+
+// TeamIdPGroups returns the identity provider groups connected to the supplied
+// team, if it exists.
+func (s *Server) TeamIdPGroups(orgLogin, slug string) ([]github.IDPGroup, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o, ok := s.orgs[orgLogin]
+	if !ok {
+		return nil, false
+	}
+	t, ok := o.teams[slug]
+	if !ok {
+		return nil, false
+	}
+	groups := make([]github.IDPGroup, len(t.idpGroups))
+	for i, g := range t.idpGroups {
+		groups[i] = *g
+	}
+	return groups, true
+}
+
+// idpGroup returns the identity provider group with the supplied ID.
+func idpGroup(o *org, id string) *github.IDPGroup {
+	for _, g := range o.idpGroups {
+		if g.GetGroupID() == id {
+			return g
+		}
+	}
+	return nil
+}
+
+// RemoveTeamMember removes the supplied user from the supplied team.
+func (s *Server) RemoveTeamMember(orgLogin, slug, login string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.orgs[orgLogin].teams[slug].members, login)
+}
+
+// AddRepo adds a repository to the supplied organization.
+func (s *Server) AddRepo(orgLogin, name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o := s.orgs[orgLogin]
+	s.addRepo(o, name)
+	// Repositories are added with a default branch.
+	o.branches[name][DefaultBranch] = true
+}
+
+// addRepo adds an empty, public repository to the supplied organization.
+func (s *Server) addRepo(o *org, name string) *github.Repository {
+	id := s.id()
+	repo := &github.Repository{
+		ID:            github.Int64(id),
+		NodeID:        github.String(fmt.Sprintf("R_%d", id)),
+		Name:          github.String(name),
+		FullName:      github.String(o.org.GetLogin() + "/" + name),
+		HTMLURL:       github.String("https://github.com/" + o.org.GetLogin() + "/" + name),
+		Owner:         &github.User{Login: o.org.Login, Type: github.String("Organization")},
+		Private:       github.Bool(false),
+		Visibility:    github.String("public"),
+		DefaultBranch: github.String(DefaultBranch),
+	}
+	o.repos[name] = repo
+	o.branches[name] = map[string]bool{}
+	return repo
+}
+
+// Repo returns the supplied repository, if it exists.
+func (s *Server) Repo(orgLogin, name string) (github.Repository, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	repo, ok := s.orgs[orgLogin].repos[name]
+	if !ok {
+		return github.Repository{}, false
+	}
+	return *repo, true
+}
+
+// EditRepo edits the supplied repository as if it were edited outside the
+// provider, for example to make it a template repository.
+func (s *Server) EditRepo(orgLogin, name string, fn func(r *github.Repository)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fn(s.orgs[orgLogin].repos[name])
+}
+
+// CopyRepo completes the asynchronous copy of the branches of the template or
+// parent of the supplied generated or forked repository. Until it is called
+// the repository has no branches.
+func (s *Server) CopyRepo(orgLogin, name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o := s.orgs[orgLogin]
+	for b := range o.copying[name] {
+		o.branches[name][b] = true
+	}
+	delete(o.copying, name)
+}
+
+// AddTeamRepo grants the supplied team the supplied permission to the supplied
+// repository.
+func (s *Server) AddTeamRepo(orgLogin, slug, repo, permission string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.orgs[orgLogin].teams[slug].repos[repo] = permission
+}
+
+// TeamRepo returns the permission of the supplied team to the supplied
+// repository, if it has any.
+func (s *Server) TeamRepo(orgLogin, slug, repo string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, ok := s.orgs[orgLogin].teams[slug]
+	if !ok {
+		return "", false
+	}
+	permission, ok := t.repos[repo]
+	return permission, ok
+}
+
+// AddBranch adds a branch to the supplied repository.
+func (s *Server) AddBranch(orgLogin, repo, branch string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.orgs[orgLogin].branches[repo][branch] = true
+}
+
+// CommitFile commits the supplied content to the file at the supplied path on
+// the supplied branch, as if it were committed outside the provider. It
+// returns the file's blob SHA.
+func (s *Server) CommitFile(orgLogin, repo, branch, path, content string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f := &RepoFile{Content: content, SHA: blobSHA(content), Message: "Update " + path}
+	s.orgs[orgLogin].files[fileKey{repo: repo, branch: branch, path: path}] = f
+	return f.SHA
+}
+
+// File returns the file at the supplied path on the supplied branch, if it
+// exists.
+func (s *Server) File(orgLogin, repo, branch, path string) (RepoFile, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o, ok := s.orgs[orgLogin]
+	if !ok {
+		return RepoFile{}, false
+	}
+	f, ok := o.files[fileKey{repo: repo, branch: branch, path: path}]
+	if !ok {
+		return RepoFile{}, false
+	}
+	return *f, true
+}
+
+// Team returns the supplied team, if it exists.
+func (s *Server) Team(orgLogin, slug string) (github.Team, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o, ok := s.orgs[orgLogin]
+	if !ok {
+		return github.Team{}, false
+	}
+	t, ok := o.teams[slug]
+	if !ok {
+		return github.Team{}, false
+	}
+	return t.team, true
+}
+
+// TeamMembership returns the supplied user's membership of the supplied team,
+// if it exists.
+func (s *Server) TeamMembership(orgLogin, slug, login string) (github.Membership, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o, ok := s.orgs[orgLogin]
+	if !ok {
+		return github.Membership{}, false
+	}
+	t, ok := o.teams[slug]
+	if !ok {
+		return github.Membership{}, false
+	}
+	m, ok := t.members[login]
+	if !ok {
+		return github.Membership{}, false
+	}
+	return *m, true
+}
+
+var nonSlug = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// Slug returns the slug GitHub derives from the supplied team name.
+func Slug(name string) string {
+	return strings.Trim(nonSlug.ReplaceAllString(strings.ToLower(name), "-"), "-")
+}
+
+type errorResponse struct {
+	Message          string         `json:"message"`
+	Errors           []github.Error `json:"errors,omitempty"`
+	DocumentationURL string         `json:"documentation_url,omitempty"`
+}
+
+func write(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	if body != nil {
+		_ = json.NewEncoder(w).Encode(body)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, msg string, errs ...github.Error) {
+	write(w, status, errorResponse{Message: msg, Errors: errs, DocumentationURL: "https://docs.github.com/rest"})
+}
+
+func notFound(w http.ResponseWriter) {
+	writeError(w, http.StatusNotFound, "Not Found")
+}
+
+// paginate writes the page of items requested by the supplied request, with
+// Link headers to the other pages.
+func paginate(w http.ResponseWriter, r *http.Request, items []interface{}) {
+	write(w, http.StatusOK, page(w, r, items))
+}
+
+// page returns the page of items requested by the supplied request, writing
+// Link headers to the other pages.
+func page(w http.ResponseWriter, r *http.Request, items []interface{}) []interface{} {
+	size := DefaultPageSize
+	if pp, err := strconv.Atoi(r.URL.Query().Get("per_page")); err == nil && pp > 0 {
+		size = pp
+	}
+	if size > MaxPageSize {
+		size = MaxPageSize
+	}
+	page := 1
+	if p, err := strconv.Atoi(r.URL.Query().Get("page")); err == nil && p > 0 {
+		page = p
+	}
+	last := (len(items) + size - 1) / size
+	if last == 0 {
+		last = 1
+	}
+
+	link := func(p int, rel string) string {
+		u := *r.URL
+		u.Scheme, u.Host = "http", r.Host
+		q := u.Query()
+		q.Set("page", strconv.Itoa(p))
+		q.Set("per_page", strconv.Itoa(size))
+		u.RawQuery = q.Encode()
+		return fmt.Sprintf("<%s>; rel=%q", u.String(), rel)
+	}
+	var links []string
+	if page < last {
+		links = append(links, link(page+1, "next"), link(last, "last"))
+	}
+	if page > 1 {
+		links = append(links, link(1, "first"), link(page-1, "prev"))
+	}
+	if len(links) > 0 {
+		w.Header().Set("Link", strings.Join(links, ", "))
+	}
+
+	start := (page - 1) * size
+	if start > len(items) {
+		start = len(items)
+	}
+	end := start + size
+	if end > len(items) {
+		end = len(items)
+	}
+	return items[start:end]
+}
+
+// rateLimit records a request against the rate limit, writing the rate limit
+// headers. It returns false if the rate limit was exceeded.
+func (s *Server) rateLimit(w http.ResponseWriter) bool {
+	s.requests++
+	h := w.Header()
+	h.Set("X-RateLimit-Limit", strconv.Itoa(s.limit))
+	h.Set("X-RateLimit-Reset", strconv.FormatInt(s.reset.Unix(), 10))
+	h.Set("X-RateLimit-Resource", "core")
+	if s.remaining <= 0 {
+		h.Set("X-RateLimit-Remaining", "0")
+		h.Set("X-RateLimit-Used", strconv.Itoa(s.limit))
+		writeError(w, http.StatusForbidden, "API rate limit exceeded")
+		return false
+	}
+	s.remaining--
+	h.Set("X-RateLimit-Remaining", strconv.Itoa(s.remaining))
+	h.Set("X-RateLimit-Used", strconv.Itoa(s.limit-s.remaining))
+	return true
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.rateLimit(w) {
+		return
+	}
+
+	if !s.expiration.IsZero() {
+		w.Header().Set("GitHub-Authentication-Token-Expiration", s.expiration.UTC().Format("2006-01-02 15:04:05 MST"))
+	}
+	if s.scopes != nil {
+		w.Header().Set("X-OAuth-Scopes", strings.Join(s.scopes, ", "))
+	}
+
+	p := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch {
+	case len(p) == 1 && p[0] == "user" && r.Method == http.MethodGet:
+		if s.login == "" {
+			writeError(w, http.StatusForbidden, "Resource not accessible by integration")
+			return
+		}
+		write(w, http.StatusOK, s.users[s.login])
+	case len(p) == 2 && p[0] == "installation" && p[1] == "repositories" && r.Method == http.MethodGet:
+		if s.login != "" {
+			writeError(w, http.StatusForbidden, "You must authenticate with an installation access token in order to list repositories for an installation.")
+			return
+		}
+		repos := []*github.Repository{}
+		for _, o := range s.orgs {
+			for _, r := range o.repos {
+				repos = append(repos, r)
+			}
+		}
+		write(w, http.StatusOK, &github.ListRepositories{TotalCount: github.Int(len(repos)), Repositories: repos})
+	case len(p) >= 2 && p[0] == "orgs":
+		o, ok := s.orgs[p[1]]
+		if !ok {
+			notFound(w)
+			return
+		}
+		s.serveOrg(w, r, o, p[2:])
+	case len(p) == 3 && p[0] == "repos":
+		o, ok := s.orgs[p[1]]
+		if !ok {
+			notFound(w)
+			return
+		}
+		s.serveRepo(w, r, o, p[2])
+	case len(p) == 4 && p[0] == "repos" && r.Method == http.MethodGet && p[3] == "branches":
+		o, ok := s.orgs[p[1]]
+		if !ok || o.repos[p[2]] == nil {
+			notFound(w)
+			return
+		}
+		names := make([]string, 0, len(o.branches[p[2]]))
+		for b := range o.branches[p[2]] {
+			names = append(names, b)
+		}
+		sort.Strings(names)
+		items := make([]interface{}, len(names))
+		for i, b := range names {
+			items[i] = &github.Branch{Name: github.String(b)}
+		}
+		paginate(w, r, items)
+	case len(p) == 4 && p[0] == "repos" && r.Method == http.MethodPost && (p[3] == "generate" || p[3] == "forks"):
+		o, ok := s.orgs[p[1]]
+		if !ok || o.repos[p[2]] == nil {
+			notFound(w)
+			return
+		}
+		if p[3] == "generate" {
+			s.serveGenerateRepo(w, r, o, p[2])
+			return
+		}
+		s.serveForkRepo(w, r, o, p[2])
+	case len(p) == 5 && p[0] == "repos" && p[3] == "codeowners" && p[4] == "errors" && r.Method == http.MethodGet:
+		o, ok := s.orgs[p[1]]
+		if !ok || o.repos[p[2]] == nil {
+			notFound(w)
+			return
+		}
+		s.serveCodeOwnersErrors(w, r, o, p[2])
+	case len(p) >= 5 && p[0] == "repos" && p[3] == "contents":
+		o, ok := s.orgs[p[1]]
+		if !ok || o.repos[p[2]] == nil {
+			notFound(w)
+			return
+		}
+		s.serveContents(w, r, o, p[2], strings.Join(p[4:], "/"))
+	case len(p) == 2 && p[0] == "user" && r.Method == http.MethodGet:
+		for _, u := range s.users {
+			if strconv.FormatInt(u.GetID(), 10) == p[1] {
+				write(w, http.StatusOK, u)
+				return
+			}
+		}
+		notFound(w)
+	case len(p) == 1 && p[0] == "graphql" && r.Method == http.MethodPost:
+		s.serveGraphQL(w, r)
+	case len(p) == 2 && p[0] == "users" && r.Method == http.MethodGet:
+		u, ok := s.users[p[1]]
+		if !ok {
+			notFound(w)
+			return
+		}
+		write(w, http.StatusOK, u)
+	default:
+		notFound(w)
+	}
+}
+
+// serveGraphQL serves the external identities of an organization's SAML
+// identity provider, in one page, and the immediate members of a team. It
+// responds to any other query with an error.
+func (s *Server) serveGraphQL(w http.ResponseWriter, r *http.Request) {
+	req := struct {
+		Query     string                 `json:"query"`
+		Variables map[string]interface{} `json:"variables"`
+	}{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	members := strings.Contains(req.Query, "membership: IMMEDIATE")
+	if err != nil || (!members && !strings.Contains(req.Query, "externalIdentities(")) {
+		write(w, http.StatusOK, map[string]interface{}{"errors": []map[string]string{{"message": "unsupported query"}}})
+		return
+	}
+	login, _ := req.Variables["org"].(string)
+	o, ok := s.orgs[login]
+	if !ok {
+		write(w, http.StatusOK, map[string]interface{}{"data": map[string]interface{}{"organization": nil}})
+		return
+	}
+	if members {
+		s.serveGraphQLTeamMembers(w, o, req.Variables)
+		return
+	}
+	var idp interface{}
+	if o.nameIDs != nil {
+		ids := make([]string, 0, len(o.nameIDs))
+		for id := range o.nameIDs {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		nodes := make([]map[string]interface{}, len(ids))
+		for i, id := range ids {
+			nodes[i] = map[string]interface{}{
+				"samlIdentity": map[string]string{"nameId": id},
+				"scimIdentity": nil,
+				"user":         map[string]string{"login": o.nameIDs[id]},
+			}
+		}
+		idp = map[string]interface{}{"externalIdentities": map[string]interface{}{
+			"pageInfo": map[string]interface{}{"hasNextPage": false, "endCursor": ""},
+			"nodes":    nodes,
+		}}
+	}
+	write(w, http.StatusOK, map[string]interface{}{"data": map[string]interface{}{
+		"organization": map[string]interface{}{"samlIdentityProvider": idp},
+	}})
+}
+
+// serveGraphQLTeamMembers serves a page of the active immediate members of the
+// team whose slug is the team variable, with their roles as GraphQL enum
+// values.
+func (s *Server) serveGraphQLTeamMembers(w http.ResponseWriter, o *org, vars map[string]interface{}) {
+	slug, _ := vars["team"].(string)
+	t, ok := o.teams[slug]
+	if !ok {
+		write(w, http.StatusOK, map[string]interface{}{"data": map[string]interface{}{"organization": map[string]interface{}{"team": nil}}})
+		return
+	}
+	logins := make([]string, 0, len(t.members))
+	for l, m := range t.members {
+		if m.GetState() == "active" {
+			logins = append(logins, l)
+		}
+	}
+	sort.Strings(logins)
+
+	// The cursor of a page is the index of its last member.
+	start := 0
+	if c, ok := vars["cursor"].(string); ok {
+		i, _ := strconv.Atoi(c)
+		start = i + 1
+	}
+	end := len(logins)
+	if first, ok := vars["first"].(float64); ok && start+int(first) < end {
+		end = start + int(first)
+	}
+	edges := make([]map[string]interface{}, 0, end-start)
+	for _, l := range logins[start:end] {
+		edges = append(edges, map[string]interface{}{
+			"role": strings.ToUpper(t.members[l].GetRole()),
+			"node": map[string]string{"login": s.users[l].GetLogin()},
+		})
+	}
+	write(w, http.StatusOK, map[string]interface{}{"data": map[string]interface{}{
+		"organization": map[string]interface{}{"team": map[string]interface{}{"members": map[string]interface{}{
+			"pageInfo": map[string]interface{}{"hasNextPage": end < len(logins), "endCursor": strconv.Itoa(end - 1)},
+			"edges":    edges,
+		}}},
+	}})
+}
+
+// activeMembers returns the active members of the supplied team and of its
+// descendants, as GitHub lists them, keyed by login. A user's membership of
+// the team itself takes precedence.
+func activeMembers(o *org, t *team) map[string]*github.Membership {
+	members := map[string]*github.Membership{}
+	for _, c := range o.teams {
+		if c.team.GetParent().GetSlug() != t.team.GetSlug() {
+			continue
+		}
+		for l, m := range activeMembers(o, c) {
+			members[l] = m
+		}
+	}
+	for l, m := range t.members {
+		if m.GetState() == "active" {
+			members[l] = m
+		}
+	}
+	return members
+}
+
+func (s *Server) serveOrg(w http.ResponseWriter, r *http.Request, o *org, p []string) {
+	switch {
+	case len(p) == 0:
+		switch r.Method {
+		case http.MethodGet:
+			write(w, http.StatusOK, organization{Organization: o.org, WebCommitSignoffRequired: o.signoff})
+		case http.MethodPatch:
+			edit := organization{Organization: o.org, WebCommitSignoffRequired: o.signoff}
+			if err := json.NewDecoder(r.Body).Decode(&edit); err != nil {
+				writeError(w, http.StatusBadRequest, "Problems parsing JSON")
+				return
+			}
+			o.org, o.signoff = edit.Organization, edit.WebCommitSignoffRequired
+			write(w, http.StatusOK, edit)
+		default:
+			notFound(w)
+		}
+	case len(p) == 1 && p[0] == "teams":
+		s.serveTeams(w, r, o)
+	case len(p) == 2 && p[0] == "team-sync" && p[1] == "groups" && r.Method == http.MethodGet:
+		items := make([]interface{}, len(o.idpGroups))
+		for i, g := range o.idpGroups {
+			items[i] = g
+		}
+		write(w, http.StatusOK, map[string]interface{}{"groups": page(w, r, items)})
+	case len(p) >= 2 && p[0] == "teams":
+		t, ok := o.teams[p[1]]
+		if !ok {
+			notFound(w)
+			return
+		}
+		s.serveTeam(w, r, o, t, p[2:])
+	case len(p) == 1 && p[0] == "repos" && r.Method == http.MethodPost:
+		s.serveCreateRepo(w, r, o)
+	case len(p) == 1 && p[0] == "repos" && r.Method == http.MethodGet:
+		names := make([]string, 0, len(o.repos))
+		for n := range o.repos {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		items := make([]interface{}, len(names))
+		for i, n := range names {
+			items[i] = o.repos[n]
+		}
+		paginate(w, r, items)
+	case len(p) == 1 && p[0] == "invitations" && r.Method == http.MethodGet:
+		logins := make([]string, 0, len(o.invites))
+		for l := range o.invites {
+			logins = append(logins, l)
+		}
+		sort.Strings(logins)
+		items := make([]interface{}, len(logins))
+		for i, l := range logins {
+			items[i] = o.invites[l]
+		}
+		paginate(w, r, items)
+	case len(p) == 1 && p[0] == "invitations" && r.Method == http.MethodPost:
+		s.serveCreateInvitation(w, r, o)
+	case len(p) == 2 && p[0] == "memberships" && r.Method == http.MethodGet:
+		m, ok := o.members[p[1]]
+		if !ok {
+			notFound(w)
+			return
+		}
+		write(w, http.StatusOK, m)
+	case len(p) == 1 && p[0] == "failed_invitations" && r.Method == http.MethodGet:
+		items := make([]interface{}, len(o.failed))
+		for i, inv := range o.failed {
+			items[i] = inv
+		}
+		paginate(w, r, items)
+	case len(p) == 2 && p[0] == "invitations" && r.Method == http.MethodDelete:
+		// Cancelling an invitation cancels the user's pending team
+		// memberships.
+		for key, i := range o.invites {
+			if strconv.FormatInt(i.GetID(), 10) != p[1] {
+				continue
+			}
+			cancel(o, key)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		notFound(w)
+	case len(p) == 1 && p[0] == "outside_collaborators" && r.Method == http.MethodGet:
+		paginate(w, r, s.usersIn(o.outside))
+	case len(p) == 2 && p[0] == "outside_collaborators":
+		s.serveOutsideCollaborator(w, r, o, p[1])
+	case len(p) == 1 && p[0] == "blocks" && r.Method == http.MethodGet:
+		paginate(w, r, s.usersIn(o.blocked))
+	case len(p) == 2 && p[0] == "blocks":
+		s.serveBlock(w, r, o, p[1])
+	case len(p) == 1 && p[0] == "security-managers" && r.Method == http.MethodGet:
+		// Security manager teams are not paginated.
+		slugs := make([]string, 0, len(o.teams))
+		for slug, t := range o.teams {
+			if t.securityManager {
+				slugs = append(slugs, slug)
+			}
+		}
+		sort.Strings(slugs)
+		teams := make([]github.Team, len(slugs))
+		for i, slug := range slugs {
+			teams[i] = o.teams[slug].team
+		}
+		write(w, http.StatusOK, teams)
+	case len(p) == 3 && p[0] == "security-managers" && p[1] == "teams":
+		t, ok := o.teams[p[2]]
+		if !ok {
+			notFound(w)
+			return
+		}
+		switch r.Method {
+		case http.MethodPut:
+			t.securityManager = true
+		case http.MethodDelete:
+			t.securityManager = false
+		default:
+			notFound(w)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case len(p) == 1 && p[0] == "custom_roles":
+		s.serveCustomRoles(w, r, o)
+	case len(p) >= 2 && p[0] == "actions":
+		s.serveActions(w, r, o, p[1:])
+	case len(p) == 2 && p[0] == "custom_roles":
+		s.serveCustomRole(w, r, o, p[1])
+	case len(p) == 2 && p[0] == "members" && r.Method == http.MethodGet:
+		// Check organization membership.
+		if _, ok := o.members[p[1]]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		notFound(w)
+	}
+}
+
+// ActionsPermissions returns the GitHub Actions permissions of the supplied
+// organization.
+func (s *Server) ActionsPermissions(orgLogin string) ActionsPermissions {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	a := s.orgs[orgLogin].actions
+	a.SelectedActions.PatternsAllowed = append([]string(nil), a.SelectedActions.PatternsAllowed...)
+	a.SelectedRepositoryIDs = append([]int64(nil), a.SelectedRepositoryIDs...)
+	return a
+}
+
+// SetActionsPermissions sets the GitHub Actions permissions of the supplied
+// organization, as if they were set outside the provider.
+func (s *Server) SetActionsPermissions(orgLogin string, a ActionsPermissions) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.orgs[orgLogin].actions = a
+}
+
+// AddRunnerGroup adds the supplied runner group to the supplied organization.
+// It returns the runner group's ID.
+func (s *Server) AddRunnerGroup(orgLogin string, g RunnerGroup) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	g.ID = s.id()
+	o := s.orgs[orgLogin]
+	o.runners = append(o.runners, &g)
+	return g.ID
+}
+
+// RunnerGroup returns the supplied runner group, if it exists.
+func (s *Server) RunnerGroup(orgLogin string, id int64) (RunnerGroup, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, g := range s.orgs[orgLogin].runners {
+		if g.ID == id {
+			return *g, true
+		}
+	}
+	return RunnerGroup{}, false
+}
+
+// RegistrationTokens returns the number of self-hosted runner registration
+// tokens that were created for the supplied organization.
+func (s *Server) RegistrationTokens(orgLogin string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.orgs[orgLogin].tokens
+}
+
+// reposByID returns the repositories of the supplied organization with the
+// supplied IDs, or false if any does not exist.
+func reposByID(o *org, ids []int64) ([]interface{}, bool) {
+	items := make([]interface{}, 0, len(ids))
+	for _, id := range ids {
+		found := false
+		for _, repo := range o.repos {
+			if repo.GetID() == id {
+				items = append(items, repo)
+				found = true
+			}
+		}
+		if !found {
+			return nil, false
+		}
+	}
+	return items, true
+}
+
+// serveActions serves the GitHub Actions permissions, self-hosted runner group
+// and runner registration token endpoints of an organization.
+func (s *Server) serveActions(w http.ResponseWriter, r *http.Request, o *org, p []string) {
+	switch {
+	case len(p) == 1 && p[0] == "permissions":
+		s.serveActionsPermissions(w, r, o)
+	case len(p) == 2 && p[0] == "permissions" && p[1] == "selected-actions":
+		if o.actions.AllowedActions != "selected" {
+			writeError(w, http.StatusConflict, "Selected actions are only available when allowed_actions is selected")
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			write(w, http.StatusOK, o.actions.SelectedActions)
+		case http.MethodPut:
+			a := github.ActionsAllowed{}
+			if err := json.NewDecoder(r.Body).Decode(&a); err != nil {
+				writeError(w, http.StatusBadRequest, "Problems parsing JSON")
+				return
+			}
+			o.actions.SelectedActions = a
+			write(w, http.StatusNoContent, nil)
+		default:
+			notFound(w)
+		}
+	case len(p) == 2 && p[0] == "permissions" && p[1] == "repositories":
+		if o.actions.EnabledRepositories != "selected" {
+			writeError(w, http.StatusConflict, "Repositories are only available when enabled_repositories is selected")
+			return
+		}
+		s.serveSelectedRepos(w, r, o, &o.actions.SelectedRepositoryIDs)
+	case len(p) == 1 && p[0] == "runner-groups":
+		s.serveRunnerGroups(w, r, o)
+	case len(p) >= 2 && p[0] == "runner-groups":
+		var g *RunnerGroup
+		for _, rg := range o.runners {
+			if strconv.FormatInt(rg.ID, 10) == p[1] {
+				g = rg
+			}
+		}
+		if g == nil {
+			notFound(w)
+			return
+		}
+		if len(p) == 3 && p[2] == "repositories" {
+			s.serveSelectedRepos(w, r, o, &g.SelectedRepositoryIDs)
+			return
+		}
+		if len(p) != 2 {
+			notFound(w)
+			return
+		}
+		s.serveRunnerGroup(w, r, o, g)
+	case len(p) == 2 && p[0] == "runners" && p[1] == "registration-token" && r.Method == http.MethodPost:
+		o.tokens++
+		write(w, http.StatusCreated, &github.RegistrationToken{
+			Token:     github.String(fmt.Sprintf("RT_%s_%d", o.org.GetLogin(), o.tokens)),
+			ExpiresAt: &github.Timestamp{Time: time.Now().Add(time.Hour).Truncate(time.Second)},
+		})
+	default:
+		notFound(w)
+	}
+}
+
+func (s *Server) serveActionsPermissions(w http.ResponseWriter, r *http.Request, o *org) {
+	switch r.Method {
+	case http.MethodGet:
+		write(w, http.StatusOK, &github.ActionsPermissions{
+			EnabledRepositories: github.String(o.actions.EnabledRepositories),
+			AllowedActions:      github.String(o.actions.AllowedActions),
+		})
+	case http.MethodPut:
+		a := &github.ActionsPermissions{}
+		if err := json.NewDecoder(r.Body).Decode(a); err != nil {
+			writeError(w, http.StatusBadRequest, "Problems parsing JSON")
+			return
+		}
+		switch a.GetEnabledRepositories() {
+		case "all", "none", "selected":
+		default:
+			writeError(w, http.StatusUnprocessableEntity, "Validation Failed", github.Error{Resource: "ActionsPermissions", Field: "enabled_repositories", Code: "invalid"})
+			return
+		}
+		switch a.GetAllowedActions() {
+		case "", "all", "local_only", "selected":
+		default:
+			writeError(w, http.StatusUnprocessableEntity, "Validation Failed", github.Error{Resource: "ActionsPermissions", Field: "allowed_actions", Code: "invalid"})
+			return
+		}
+		o.actions.EnabledRepositories = a.GetEnabledRepositories()
+		if a.AllowedActions != nil {
+			o.actions.AllowedActions = a.GetAllowedActions()
+		}
+		write(w, http.StatusNoContent, nil)
+	default:
+		notFound(w)
+	}
+}
+
+// serveSelectedRepos lists or replaces the repositories with the supplied IDs.
+func (s *Server) serveSelectedRepos(w http.ResponseWriter, r *http.Request, o *org, ids *[]int64) {
+	switch r.Method {
+	case http.MethodGet:
+		items, _ := reposByID(o, *ids)
+		write(w, http.StatusOK, map[string]interface{}{"total_count": len(items), "repositories": page(w, r, items)})
+	case http.MethodPut:
+		req := &runnerGroupRequest{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			writeError(w, http.StatusBadRequest, "Problems parsing JSON")
+			return
+		}
+		if _, ok := reposByID(o, req.SelectedRepositoryIDs); !ok {
+			writeError(w, http.StatusUnprocessableEntity, "Validation Failed", github.Error{Resource: "Repository", Field: "selected_repository_ids", Code: "invalid"})
+			return
+		}
+		*ids = req.SelectedRepositoryIDs
+		write(w, http.StatusNoContent, nil)
+	default:
+		notFound(w)
+	}
+}
+
+func (s *Server) serveRunnerGroups(w http.ResponseWriter, r *http.Request, o *org) {
+	switch r.Method {
+	case http.MethodGet:
+		items := make([]interface{}, len(o.runners))
+		for i, g := range o.runners {
+			items[i] = g
+		}
+		write(w, http.StatusOK, map[string]interface{}{"total_count": len(items), "runner_groups": page(w, r, items)})
+	case http.MethodPost:
+		req := &runnerGroupRequest{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil || req.Name == nil {
+			writeError(w, http.StatusUnprocessableEntity, "Validation Failed", github.Error{Resource: "RunnerGroup", Field: "name", Code: "missing_field"})
+			return
+		}
+		for _, g := range o.runners {
+			if g.Name == *req.Name {
+				writeError(w, http.StatusUnprocessableEntity, "Validation Failed", github.Error{Resource: "RunnerGroup", Field: "name", Code: "already_exists"})
+				return
+			}
+		}
+		g := &RunnerGroup{Name: *req.Name, Visibility: "all"}
+		if !updateRunnerGroup(w, o, g, req) {
+			return
+		}
+		g.ID = s.id()
+		o.runners = append(o.runners, g)
+		write(w, http.StatusCreated, g)
+	default:
+		notFound(w)
+	}
+}
+
+func (s *Server) serveRunnerGroup(w http.ResponseWriter, r *http.Request, o *org, g *RunnerGroup) {
+	switch r.Method {
+	case http.MethodGet:
+		write(w, http.StatusOK, g)
+	case http.MethodPatch:
+		req := &runnerGroupRequest{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			writeError(w, http.StatusBadRequest, "Problems parsing JSON")
+			return
+		}
+		// Selected repositories are replaced via their own endpoint.
+		req.SelectedRepositoryIDs = nil
+		u := *g
+		if !updateRunnerGroup(w, o, &u, req) {
+			return
+		}
+		*g = u
+		write(w, http.StatusOK, g)
+	case http.MethodDelete:
+		for i, rg := range o.runners {
+			if rg == g {
+				o.runners = append(o.runners[:i], o.runners[i+1:]...)
+				break
+			}
+		}
+		write(w, http.StatusNoContent, nil)
+	default:
+		notFound(w)
+	}
+}
+
+// updateRunnerGroup applies the supplied request to the supplied runner group.
+// It writes an error and returns false if the request is invalid.
+func updateRunnerGroup(w http.ResponseWriter, o *org, g *RunnerGroup, req *runnerGroupRequest) bool {
+	if req.Name != nil {
+		g.Name = *req.Name
+	}
+	if req.Visibility != nil {
+		if *req.Visibility != "all" && *req.Visibility != "selected" {
+			writeError(w, http.StatusUnprocessableEntity, "Validation Failed", github.Error{Resource: "RunnerGroup", Field: "visibility", Code: "invalid"})
+			return false
+		}
+		g.Visibility = *req.Visibility
+	}
+	if req.SelectedRepositoryIDs != nil {
+		if _, ok := reposByID(o, req.SelectedRepositoryIDs); !ok {
+			writeError(w, http.StatusUnprocessableEntity, "Validation Failed", github.Error{Resource: "Repository", Field: "selected_repository_ids", Code: "invalid"})
+			return false
+		}
+		g.SelectedRepositoryIDs = req.SelectedRepositoryIDs
+	}
+	if req.AllowsPublicRepositories != nil {
+		g.AllowsPublicRepositories = *req.AllowsPublicRepositories
+	}
+	if req.RestrictedToWorkflows != nil {
+		g.RestrictedToWorkflows = *req.RestrictedToWorkflows
+		g.SelectedWorkflows = req.SelectedWorkflows
+	}
+	return true
+}
+
+// usersIn returns the users with the supplied logins, ordered by login.
+func (s *Server) usersIn(logins map[string]bool) []interface{} {
+	sorted := make([]string, 0, len(logins))
+	for l := range logins {
+		sorted = append(sorted, l)
+	}
+	sort.Strings(sorted)
+	items := make([]interface{}, len(sorted))
+	for i, l := range sorted {
+		items[i] = s.users[l]
+	}
+	return items
+}
+
+func (s *Server) serveOutsideCollaborator(w http.ResponseWriter, r *http.Request, o *org, login string) {
+	switch r.Method {
+	case http.MethodPut:
+		// Only members may be converted to outside collaborators. They lose
+		// their membership of the organization's teams.
+		if _, ok := o.members[login]; !ok {
+			notFound(w)
+			return
+		}
+		leave(o, login)
+		o.outside[login] = true
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodDelete:
+		if _, ok := o.members[login]; ok {
+			writeError(w, http.StatusUnprocessableEntity, "You cannot specify an organization member to remove as an outside collaborator.")
+			return
+		}
+		delete(o.outside, login)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		notFound(w)
+	}
+}
+
+func (s *Server) serveBlock(w http.ResponseWriter, r *http.Request, o *org, login string) {
+	switch r.Method {
+	case http.MethodGet:
+		if !o.blocked[login] {
+			notFound(w)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodPut:
+		if _, ok := s.users[login]; !ok {
+			notFound(w)
+			return
+		}
+		if o.blocked[login] {
+			writeError(w, http.StatusUnprocessableEntity, "Validation Failed", github.Error{Resource: "Block", Field: "blocked_user", Code: "already_exists"})
+			return
+		}
+		block(o, login)
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodDelete:
+		delete(o.blocked, login)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		notFound(w)
+	}
+}
+
+func (s *Server) serveCustomRoles(w http.ResponseWriter, r *http.Request, o *org) {
+	switch r.Method {
+	case http.MethodGet:
+		items := make([]interface{}, len(o.roles))
+		for i, cr := range o.roles {
+			items[i] = cr
+		}
+		write(w, http.StatusOK, map[string]interface{}{"total_count": len(o.roles), "custom_roles": page(w, r, items)})
+	case http.MethodPost:
+		cr := &CustomRole{}
+		if err := json.NewDecoder(r.Body).Decode(cr); err != nil {
+			writeError(w, http.StatusBadRequest, "Problems parsing JSON")
+			return
+		}
+		if !validCustomRole(w, o, cr) {
+			return
+		}
+		cr.ID = s.id()
+		o.roles = append(o.roles, cr)
+		write(w, http.StatusCreated, cr)
+	default:
+		notFound(w)
+	}
+}
+
+func (s *Server) serveCustomRole(w http.ResponseWriter, r *http.Request, o *org, id string) {
+	i := -1
+	for j, cr := range o.roles {
+		if strconv.FormatInt(cr.ID, 10) == id {
+			i = j
+		}
+	}
+	if i < 0 {
+		notFound(w)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		write(w, http.StatusOK, o.roles[i])
+	case http.MethodPatch:
+		edit := *o.roles[i]
+		if err := json.NewDecoder(r.Body).Decode(&edit); err != nil {
+			writeError(w, http.StatusBadRequest, "Problems parsing JSON")
+			return
+		}
+		if !validCustomRole(w, o, &edit) {
+			return
+		}
+		o.roles[i] = &edit
+		write(w, http.StatusOK, o.roles[i])
+	case http.MethodDelete:
+		o.roles = append(o.roles[:i], o.roles[i+1:]...)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		notFound(w)
+	}
+}
+
+// validCustomRole writes an error and returns false if the supplied custom
+// repository role is invalid, or its name is taken by another role.
+func validCustomRole(w http.ResponseWriter, o *org, cr *CustomRole) bool {
+	switch cr.BaseRole {
+	case "read", "triage", "write", "maintain":
+	default:
+		writeError(w, http.StatusUnprocessableEntity, "Validation Failed", github.Error{Resource: "CustomRole", Field: "base_role", Code: "invalid"})
+		return false
+	}
+	for _, other := range o.roles {
+		if other.Name == cr.Name && other.ID != cr.ID {
+			writeError(w, http.StatusUnprocessableEntity, "Validation Failed", github.Error{Resource: "CustomRole", Field: "name", Code: "already_exists"})
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Server) serveCreateInvitation(w http.ResponseWriter, r *http.Request, o *org) {
+	opts := &github.CreateOrgInvitationOptions{}
+	if err := json.NewDecoder(r.Body).Decode(opts); err != nil || (opts.Email == nil) == (opts.InviteeID == nil) {
+		writeError(w, http.StatusUnprocessableEntity, "Validation Failed", github.Error{Resource: "OrganizationInvitation", Field: "invitee_id", Code: "missing_field"})
+		return
+	}
+	i := &github.Invitation{Email: opts.Email, Role: github.String("direct_member")}
+	key := opts.GetEmail()
+	if opts.InviteeID != nil {
+		for l, u := range s.users {
+			if u.GetID() == *opts.InviteeID {
+				i.Login, key = github.String(l), l
+			}
+		}
+		if key == "" {
+			writeError(w, http.StatusUnprocessableEntity, "Validation Failed", github.Error{Resource: "OrganizationInvitation", Field: "invitee_id", Code: "invalid"})
+			return
+		}
+	}
+	if _, member := o.members[key]; member {
+		writeError(w, http.StatusUnprocessableEntity, "Validation Failed", github.Error{Resource: "OrganizationInvitation", Code: "custom", Message: "Invitee is already a part of this organization"})
+		return
+	}
+	if opts.Role != nil {
+		i.Role = opts.Role
+	}
+	var slugs []string
+	for _, id := range opts.TeamID {
+		t := parent(o, id)
+		if t == nil {
+			writeError(w, http.StatusUnprocessableEntity, "Validation Failed", github.Error{Resource: "OrganizationInvitation", Field: "team_ids", Code: "invalid"})
+			return
+		}
+		slugs = append(slugs, t.GetSlug())
+	}
+	now := time.Now()
+	i.ID, i.CreatedAt, i.TeamCount = github.Int64(s.id()), &now, github.Int(len(slugs))
+	o.invites[key] = i
+	o.joins[i.GetID()] = slugs
+	write(w, http.StatusCreated, i)
+}
+
+func (s *Server) serveTeams(w http.ResponseWriter, r *http.Request, o *org) {
+	switch r.Method {
+	case http.MethodGet:
+		slugs := make([]string, 0, len(o.teams))
+		for slug := range o.teams {
+			slugs = append(slugs, slug)
+		}
+		sort.Strings(slugs)
+		items := make([]interface{}, len(slugs))
+		for i, slug := range slugs {
+			items[i] = o.teams[slug].full()
+		}
+		paginate(w, r, items)
+	case http.MethodPost:
+		nt := newTeam{}
+		if err := json.NewDecoder(r.Body).Decode(&nt); err != nil || nt.Name == "" {
+			writeError(w, http.StatusUnprocessableEntity, "Validation Failed", github.Error{Resource: "Team", Field: "name", Code: "missing_field"})
+			return
+		}
+		if nt.ParentTeamID != nil && nt.GetPrivacy() == "secret" {
+			writeError(w, http.StatusUnprocessableEntity, "Validation Failed", github.Error{Resource: "Team", Field: "privacy", Code: "invalid"})
+			return
+		}
+		if _, exists := o.teams[Slug(nt.Name)]; exists {
+			writeError(w, http.StatusUnprocessableEntity, "Validation Failed", github.Error{Resource: "Team", Field: "name", Code: "already_exists"})
+			return
+		}
+		t := s.addTeam(o, nt.NewTeam)
+		if nt.NotificationSetting != nil {
+			t.notification = *nt.NotificationSetting
+		}
+		write(w, http.StatusCreated, t.full())
+	default:
+		notFound(w)
+	}
+}
+
+func (s *Server) serveTeam(w http.ResponseWriter, r *http.Request, o *org, t *team, p []string) {
+	switch {
+	case len(p) == 0:
+		switch r.Method {
+		case http.MethodGet:
+			write(w, http.StatusOK, t.full())
+		case http.MethodPatch:
+			nt := newTeam{}
+			if err := json.NewDecoder(r.Body).Decode(&nt); err != nil {
+				writeError(w, http.StatusBadRequest, "Problems parsing JSON")
+				return
+			}
+			if nt.Name != "" && Slug(nt.Name) != t.team.GetSlug() {
+				delete(o.teams, t.team.GetSlug())
+				t.team.Name = github.String(nt.Name)
+				t.team.Slug = github.String(Slug(nt.Name))
+				o.teams[t.team.GetSlug()] = t
+			}
+			if nt.Description != nil {
+				t.team.Description = nt.Description
+			}
+			if nt.Privacy != nil {
+				t.team.Privacy = nt.Privacy
+			}
+			if nt.LDAPDN != nil {
+				t.team.LDAPDN = nt.LDAPDN
+			}
+			if nt.ParentTeamID != nil {
+				t.team.Parent = parent(o, *nt.ParentTeamID)
+			}
+			if nt.NotificationSetting != nil {
+				t.notification = *nt.NotificationSetting
+			}
+			write(w, http.StatusOK, t.full())
+		case http.MethodDelete:
+			delete(o.teams, t.team.GetSlug())
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			notFound(w)
+		}
+	case len(p) == 1 && p[0] == "members" && r.Method == http.MethodGet:
+		// GitHub lists the members of child teams as members of their parent.
+		role := r.URL.Query().Get("role")
+		members := activeMembers(o, t)
+		logins := make([]string, 0, len(members))
+		for l, m := range members {
+			if role != "" && role != "all" && m.GetRole() != role {
+				continue
+			}
+			logins = append(logins, l)
+		}
+		sort.Strings(logins)
+		items := make([]interface{}, len(logins))
+		for i, l := range logins {
+			items[i] = s.users[l]
+		}
+		paginate(w, r, items)
+	case len(p) == 1 && p[0] == "invitations" && r.Method == http.MethodGet:
+		logins := make([]string, 0, len(t.members))
+		for l, m := range t.members {
+			if m.GetState() == "pending" {
+				logins = append(logins, l)
+			}
+		}
+		sort.Strings(logins)
+		items := make([]interface{}, len(logins))
+		for i, l := range logins {
+			items[i] = &github.Invitation{Login: github.String(l), Role: github.String("direct_member")}
+		}
+		paginate(w, r, items)
+	case len(p) == 2 && p[0] == "memberships":
+		s.serveTeamMembership(w, r, o, t, p[1])
+	case len(p) == 2 && p[0] == "team-sync" && p[1] == "group-mappings":
+		s.serveTeamSync(w, r, o, t)
+	case len(p) == 3 && p[0] == "repos" && p[1] == o.org.GetLogin():
+		s.serveTeamRepo(w, r, o, t, p[2])
+	default:
+		notFound(w)
+	}
+}
+
+// teamRoleNames are the names of the roles of a team that is granted the
+// built-in permissions to a repository.
+var teamRoleNames = map[string]string{
+	"pull": "read", "triage": "triage", "push": "write", "maintain": "maintain", "admin": "admin",
+}
+
+func (s *Server) serveTeamRepo(w http.ResponseWriter, r *http.Request, o *org, t *team, name string) {
+	repo, ok := o.repos[name]
+	if !ok {
+		notFound(w)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		permission, ok := t.repos[name]
+		if !ok {
+			notFound(w)
+			return
+		}
+		rn, ok := teamRoleNames[permission]
+		if !ok {
+			rn = permission
+		}
+		out := *repo
+		out.RoleName = github.String(rn)
+		write(w, http.StatusOK, out)
+	case http.MethodPut:
+		opts := &github.TeamAddTeamRepoOptions{}
+		if err := json.NewDecoder(r.Body).Decode(opts); err != nil {
+			writeError(w, http.StatusBadRequest, "Problems parsing JSON")
+			return
+		}
+		if opts.Permission == "" {
+			opts.Permission = "push"
+		}
+		valid := teamRoleNames[opts.Permission] != ""
+		for _, cr := range o.roles {
+			valid = valid || cr.Name == opts.Permission
+		}
+		if !valid {
+			writeError(w, http.StatusUnprocessableEntity, "Validation Failed", github.Error{Resource: "Team", Field: "permission", Code: "invalid"})
+			return
+		}
+		t.repos[name] = opts.Permission
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodDelete:
+		delete(t.repos, name)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		notFound(w)
+	}
+}
+
+func (s *Server) serveTeamSync(w http.ResponseWriter, r *http.Request, o *org, t *team) {
+	switch r.Method {
+	case http.MethodGet:
+		write(w, http.StatusOK, github.IDPGroupList{Groups: t.idpGroups})
+	case http.MethodPatch:
+		l := github.IDPGroupList{}
+		if err := json.NewDecoder(r.Body).Decode(&l); err != nil || l.Groups == nil {
+			writeError(w, http.StatusUnprocessableEntity, "Validation Failed", github.Error{Resource: "Team", Field: "groups", Code: "missing_field"})
+			return
+		}
+		groups := make([]*github.IDPGroup, 0, len(l.Groups))
+		for _, g := range l.Groups {
+			if g.GroupID == nil || g.GroupName == nil || g.GroupDescription == nil {
+				writeError(w, http.StatusUnprocessableEntity, "Validation Failed", github.Error{Resource: "Team", Field: "groups", Code: "missing_field"})
+				return
+			}
+			if idpGroup(o, g.GetGroupID()) == nil {
+				writeError(w, http.StatusUnprocessableEntity, "Validation Failed", github.Error{Resource: "Team", Field: "groups", Code: "invalid"})
+				return
+			}
+			groups = append(groups, g)
+		}
+		t.idpGroups = groups
+		write(w, http.StatusOK, github.IDPGroupList{Groups: t.idpGroups})
+	default:
+		notFound(w)
+	}
+}
+
+func (s *Server) serveTeamMembership(w http.ResponseWriter, r *http.Request, o *org, t *team, login string) {
+	switch r.Method {
+	case http.MethodGet:
+		m, ok := t.members[login]
+		if !ok {
+			notFound(w)
+			return
+		}
+		write(w, http.StatusOK, m)
+	case http.MethodPut:
+		if _, ok := s.users[login]; !ok {
+			notFound(w)
+			return
+		}
+		opts := &github.TeamAddTeamMembershipOptions{}
+		_ = json.NewDecoder(r.Body).Decode(opts)
+		role := opts.Role
+		if role == "" {
+			role = "member"
+		}
+		// Users who aren't yet members of the organization are invited to it,
+		// and their team membership is pending until they accept.
+		state := "active"
+		if _, member := o.members[login]; !member {
+			state = "pending"
+			s.invite(o, login)
+		}
+		if m, ok := t.members[login]; ok {
+			state = m.GetState()
+		}
+		m := &github.Membership{Role: github.String(role), State: github.String(state)}
+		t.members[login] = m
+		write(w, http.StatusOK, m)
+	case http.MethodDelete:
+		if _, ok := t.members[login]; !ok {
+			notFound(w)
+			return
+		}
+		delete(t.members, login)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		notFound(w)
+	}
+}
+
+// A fileOptions is the body of a request to create, update, or delete a file.
+type fileOptions struct {
+	Message *string              `json:"message,omitempty"`
+	Content *string              `json:"content,omitempty"`
+	SHA     *string              `json:"sha,omitempty"`
+	Branch  *string              `json:"branch,omitempty"`
+	Author  *github.CommitAuthor `json:"author,omitempty"`
+}
+
+func (s *Server) serveContents(w http.ResponseWriter, r *http.Request, o *org, repo, path string) {
+	opts := &fileOptions{}
+	if r.Method == http.MethodPut || r.Method == http.MethodDelete {
+		if err := json.NewDecoder(r.Body).Decode(opts); err != nil {
+			writeError(w, http.StatusBadRequest, "Problems parsing JSON")
+			return
+		}
+	}
+	branch := r.URL.Query().Get("ref")
+	if opts.Branch != nil {
+		branch = *opts.Branch
+	}
+	if branch == "" {
+		branch = o.repos[repo].GetDefaultBranch()
+	}
+	if !o.branches[repo][branch] {
+		notFound(w)
+		return
+	}
+	key := fileKey{repo: repo, branch: branch, path: path}
+	f, exists := o.files[key]
+
+	content := func(f *RepoFile) *github.RepositoryContent {
+		return &github.RepositoryContent{
+			Type:     github.String("file"),
+			Encoding: github.String("base64"),
+			Content:  github.String(base64.StdEncoding.EncodeToString([]byte(f.Content))),
+			Size:     github.Int(len(f.Content)),
+			Name:     github.String(path[strings.LastIndex(path, "/")+1:]),
+			Path:     github.String(path),
+			SHA:      github.String(f.SHA),
+		}
+	}
+	commit := func(f *RepoFile) github.Commit {
+		sum := sha1.Sum([]byte(fmt.Sprintf("commit %d", s.id()))) //nolint:gosec // Git identifies commits by their SHA-1.
+		return github.Commit{SHA: github.String(hex.EncodeToString(sum[:])), Message: github.String(f.Message), Author: f.Author}
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		if !exists {
+			notFound(w)
+			return
+		}
+		write(w, http.StatusOK, content(f))
+	case http.MethodPut:
+		if opts.Message == nil || opts.Content == nil {
+			writeError(w, http.StatusUnprocessableEntity, "Invalid request.")
+			return
+		}
+		if exists && opts.SHA == nil {
+			writeError(w, http.StatusUnprocessableEntity, `Invalid request. "sha" wasn't supplied.`)
+			return
+		}
+		if exists && *opts.SHA != f.SHA {
+			writeError(w, http.StatusConflict, fmt.Sprintf("%s does not match %s", path, *opts.SHA))
+			return
+		}
+		b, err := base64.StdEncoding.DecodeString(*opts.Content)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "content is not valid Base64")
+			return
+		}
+		nf := &RepoFile{Content: string(b), SHA: blobSHA(string(b)), Message: *opts.Message, Author: opts.Author}
+		o.files[key] = nf
+		status := http.StatusOK
+		if !exists {
+			status = http.StatusCreated
+		}
+		write(w, status, github.RepositoryContentResponse{Content: content(nf), Commit: commit(nf)})
+	case http.MethodDelete:
+		if !exists {
+			notFound(w)
+			return
+		}
+		if opts.Message == nil || opts.SHA == nil {
+			writeError(w, http.StatusUnprocessableEntity, "Invalid request.")
+			return
+		}
+		if *opts.SHA != f.SHA {
+			writeError(w, http.StatusConflict, fmt.Sprintf("%s does not match %s", path, *opts.SHA))
+			return
+		}
+		delete(o.files, key)
+		write(w, http.StatusOK, github.RepositoryContentResponse{Commit: commit(&RepoFile{Message: *opts.Message, Author: opts.Author})})
+	default:
+		notFound(w)
+	}
+}
+
+// serveCodeOwnersErrors reports owners in the CODEOWNERS file of the supplied
+// repository that do not exist, or lack write access to it.
+func (s *Server) serveCodeOwnersErrors(w http.ResponseWriter, r *http.Request, o *org, repo string) {
+	branch := r.URL.Query().Get("ref")
+	if branch == "" {
+		branch = o.repos[repo].GetDefaultBranch()
+	}
+	var f *RepoFile
+	var path string
+	for _, path = range []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"} {
+		if f = o.files[fileKey{repo: repo, branch: branch, path: path}]; f != nil {
+			break
+		}
+	}
+	if f == nil {
+		notFound(w)
+		return
+	}
+
+	valid := func(owner string) bool {
+		if slug := strings.TrimPrefix(owner, "@"+o.org.GetLogin()+"/"); slug != owner {
+			t, ok := o.teams[slug]
+			if !ok {
+				return false
+			}
+			switch t.repos[repo] {
+			case "push", "maintain", "admin":
+				return true
+			}
+			return false
+		}
+		login := strings.TrimPrefix(owner, "@")
+		_, member := o.members[login]
+		return member || o.outside[login]
+	}
+
+	errs := []map[string]interface{}{}
+	for i, line := range strings.Split(f.Content, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		for _, owner := range fields[1:] {
+			if valid(owner) {
+				continue
+			}
+			suggestion := fmt.Sprintf("make sure %s exists and has write access to the repository", owner)
+			errs = append(errs, map[string]interface{}{
+				"line":       i + 1,
+				"column":     strings.Index(line, owner) + 1,
+				"kind":       "Unknown owner",
+				"source":     line,
+				"suggestion": suggestion,
+				"message":    fmt.Sprintf("Unknown owner on line %d: %s\n\n  %s", i+1, suggestion, line),
+				"path":       path,
+			})
+		}
+	}
+	write(w, http.StatusOK, map[string]interface{}{"errors": errs})
+}
+
+func (s *Server) serveRepo(w http.ResponseWriter, r *http.Request, o *org, name string) {
+	repo, ok := o.repos[name]
+	if !ok {
+		notFound(w)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		write(w, http.StatusOK, repo)
+	case http.MethodPatch:
+		e := &github.Repository{}
+		if err := json.NewDecoder(r.Body).Decode(e); err != nil {
+			writeError(w, http.StatusBadRequest, "Problems parsing JSON")
+			return
+		}
+		if e.Visibility != nil && repo.GetFork() {
+			writeError(w, http.StatusUnprocessableEntity, "Validation Failed", github.Error{Resource: "Repository", Field: "visibility", Code: "invalid"})
+			return
+		}
+		if e.Description != nil {
+			repo.Description = e.Description
+		}
+		if e.Visibility != nil {
+			if !validVisibility(e.GetVisibility()) {
+				writeError(w, http.StatusUnprocessableEntity, "Validation Failed", github.Error{Resource: "Repository", Field: "visibility", Code: "invalid"})
+				return
+			}
+			setVisibility(repo, e.GetVisibility())
+		}
+		write(w, http.StatusOK, repo)
+	case http.MethodDelete:
+		delete(o.repos, name)
+		delete(o.branches, name)
+		delete(o.copying, name)
+		for k := range o.files {
+			if k.repo == name {
+				delete(o.files, k)
+			}
+		}
+		write(w, http.StatusNoContent, nil)
+	default:
+		notFound(w)
+	}
+}
+
+func validVisibility(v string) bool {
+	return v == "public" || v == "private" || v == "internal"
+}
+
+func setVisibility(repo *github.Repository, v string) {
+	repo.Visibility = github.String(v)
+	repo.Private = github.Bool(v != "public")
+}
+
+// serveCreateRepo creates an empty repository. GitHub creates repositories
+// without branches unless asked to initialize them.
+func (s *Server) serveCreateRepo(w http.ResponseWriter, r *http.Request, o *org) {
+	c := &github.Repository{}
+	if err := json.NewDecoder(r.Body).Decode(c); err != nil || c.GetName() == "" {
+		writeError(w, http.StatusUnprocessableEntity, "Validation Failed", github.Error{Resource: "Repository", Field: "name", Code: "missing_field"})
+		return
+	}
+	if _, exists := o.repos[c.GetName()]; exists {
+		writeError(w, http.StatusUnprocessableEntity, "Repository creation failed.", github.Error{Resource: "Repository", Field: "name", Code: "custom", Message: "name already exists on this account"})
+		return
+	}
+	v := c.GetVisibility()
+	if v == "" && c.GetPrivate() {
+		v = "private"
+	}
+	if v != "" && !validVisibility(v) {
+		writeError(w, http.StatusUnprocessableEntity, "Validation Failed", github.Error{Resource: "Repository", Field: "visibility", Code: "invalid"})
+		return
+	}
+	repo := s.addRepo(o, c.GetName())
+	repo.Description = c.Description
+	if v != "" {
+		setVisibility(repo, v)
+	}
+	if c.GetAutoInit() {
+		o.branches[c.GetName()][DefaultBranch] = true
+	}
+	write(w, http.StatusCreated, repo)
+}
+
+// serveGenerateRepo generates a repository from the supplied template
+// repository. The branches of the template are copied when CopyRepo is called.
+func (s *Server) serveGenerateRepo(w http.ResponseWriter, r *http.Request, src *org, name string) {
+	tmpl := src.repos[name]
+	if !tmpl.GetIsTemplate() {
+		writeError(w, http.StatusUnprocessableEntity, "Validation Failed", github.Error{Resource: "Repository", Field: "template", Code: "invalid", Message: tmpl.GetFullName() + " is not a template repository"})
+		return
+	}
+	req := &github.TemplateRepoRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil || req.GetName() == "" {
+		writeError(w, http.StatusUnprocessableEntity, "Validation Failed", github.Error{Resource: "Repository", Field: "name", Code: "missing_field"})
+		return
+	}
+	owner := src
+	if req.Owner != nil {
+		if owner = s.orgs[req.GetOwner()]; owner == nil {
+			notFound(w)
+			return
+		}
+	}
+	if _, exists := owner.repos[req.GetName()]; exists {
+		writeError(w, http.StatusUnprocessableEntity, "Validation Failed", github.Error{Resource: "Repository", Field: "name", Code: "custom", Message: "name already exists on this account"})
+		return
+	}
+	repo := s.addRepo(owner, req.GetName())
+	repo.Description = req.Description
+	if req.GetPrivate() {
+		setVisibility(repo, "private")
+	}
+	repo.TemplateRepository = tmpl
+	owner.copying[req.GetName()] = map[string]bool{tmpl.GetDefaultBranch(): true}
+	if req.GetIncludeAllBranches() {
+		for b := range src.branches[name] {
+			owner.copying[req.GetName()][b] = true
+		}
+	}
+	write(w, http.StatusCreated, repo)
+}
+
+// serveForkRepo forks the supplied repository into the organization named by
+// the organization parameter, with the name parameter or the name of its
+// parent, accepting the fork with 202 Accepted as GitHub does. Parameters may
+// be supplied in the body or the query. Like GitHub it suffixes the name of
+// the fork if a repository with that name exists. The branches of the parent
+// are copied when CopyRepo is called.
+func (s *Server) serveForkRepo(w http.ResponseWriter, r *http.Request, src *org, name string) {
+	parent := src.repos[name]
+	params := struct {
+		Organization string `json:"organization"`
+		Name         string `json:"name"`
+	}{Organization: r.URL.Query().Get("organization"), Name: name}
+	_ = json.NewDecoder(r.Body).Decode(&params)
+	owner, ok := s.orgs[params.Organization]
+	if !ok {
+		writeError(w, http.StatusUnprocessableEntity, "Validation Failed", github.Error{Resource: "Fork", Field: "organization", Code: "invalid"})
+		return
+	}
+	fork := params.Name
+	for i := 1; owner.repos[fork] != nil; i++ {
+		fork = fmt.Sprintf("%s-%d", params.Name, i)
+	}
+	repo := s.addRepo(owner, fork)
+	repo.Description = parent.Description
+	setVisibility(repo, parent.GetVisibility())
+	repo.Fork = github.Bool(true)
+	repo.Parent = parent
+	repo.Source = parent
+	if parent.Source != nil {
+		repo.Source = parent.Source
+	}
+	owner.copying[fork] = map[string]bool{}
+	for b := range src.branches[name] {
+		owner.copying[fork][b] = true
+	}
+	write(w, http.StatusAccepted, repo)
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v45/github"
+)
+
+func TestPagination(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	s.AddOrg("crossplane")
+	for i := 0; i < 5; i++ {
+		s.AddTeam("crossplane", github.NewTeam{Name: fmt.Sprintf("team-%d", i)})
+	}
+
+	c := s.Client()
+	opts := &github.ListOptions{PerPage: 2}
+	var got []string
+	for {
+		teams, rsp, err := c.Teams.ListTeams(context.Background(), "crossplane", opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, t := range teams {
+			got = append(got, t.GetSlug())
+		}
+		if rsp.NextPage == 0 {
+			if diff := cmp.Diff([]int{1, 2}, []int{rsp.FirstPage, rsp.PrevPage}); diff != "" {
+				t.Errorf("ListTeams(...): last page should link to the first and previous pages: -want, +got:\n%s", diff)
+			}
+			break
+		}
+		if diff := cmp.Diff(3, rsp.LastPage); diff != "" {
+			t.Errorf("ListTeams(...): -want last page, +got last page:\n%s", diff)
+		}
+		opts.Page = rsp.NextPage
+	}
+
+	want := []string{"team-0", "team-1", "team-2", "team-3", "team-4"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ListTeams(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	s.AddOrg("crossplane")
+	s.SetRateLimit(1)
+
+	c := s.Client()
+	_, rsp, err := c.Organizations.Get(context.Background(), "crossplane")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(github.Rate{Limit: DefaultRateLimit, Remaining: 0, Reset: rsp.Rate.Reset}, rsp.Rate); diff != "" {
+		t.Errorf("Get(...): -want rate, +got rate:\n%s", diff)
+	}
+
+	_, _, err = c.Organizations.Get(context.Background(), "crossplane")
+	rle := &github.RateLimitError{}
+	if !errors.As(err, &rle) {
+		t.Errorf("Get(...): want *github.RateLimitError once the rate limit is exceeded, got %T", err)
+	}
+}
+
+func TestStatusCodes(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	s.AddOrg("crossplane")
+	c := s.Client()
+
+	cases := map[string]struct {
+		reason string
+		call   func() (*github.Response, error)
+		want   int
+	}{
+		"OrgNotFound": {
+			reason: "Getting an organization that does not exist should return 404.",
+			call: func() (*github.Response, error) {
+				_, rsp, err := c.Organizations.Get(context.Background(), "nope")
+				return rsp, err
+			},
+			want: 404,
+		},
+		"TeamCreated": {
+			reason: "Creating a team should return 201.",
+			call: func() (*github.Response, error) {
+				_, rsp, err := c.Teams.CreateTeam(context.Background(), "crossplane", github.NewTeam{Name: "Cool Team"})
+				return rsp, err
+			},
+			want: 201,
+		},
+		"TeamExists": {
+			reason: "Creating a team that already exists should return 422.",
+			call: func() (*github.Response, error) {
+				s.AddTeam("crossplane", github.NewTeam{Name: "Existing"})
+				_, rsp, err := c.Teams.CreateTeam(context.Background(), "crossplane", github.NewTeam{Name: "existing"})
+				return rsp, err
+			},
+			want: 422,
+		},
+		"TeamDeleted": {
+			reason: "Deleting a team should return 204.",
+			call: func() (*github.Response, error) {
+				s.AddTeam("crossplane", github.NewTeam{Name: "Doomed"})
+				return c.Teams.DeleteTeamBySlug(context.Background(), "crossplane", "doomed")
+			},
+			want: 204,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			rsp, _ := tc.call()
+			if diff := cmp.Diff(tc.want, rsp.StatusCode); diff != "" {
+				t.Errorf("\n%s\n-want status, +got status:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
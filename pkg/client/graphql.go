@@ -0,0 +1,309 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/v45/github"
+	"github.com/pkg/errors"
+)
+
+const (
+	errMarshalQuery    = "cannot marshal GraphQL query"
+	errGraphQLRequest  = "cannot make GraphQL request"
+	errGraphQLResponse = "cannot decode GraphQL response"
+	errGraphQLStatus   = "unexpected GraphQL response status"
+	errGraphQL         = "GraphQL query failed"
+)
+
+// A GraphQLClient queries the GitHub GraphQL API.
+type GraphQLClient struct {
+	client *http.Client
+	url    string
+}
+
+// NewGraphQLClient returns a GraphQLClient that uses the HTTP client and API
+// host of the supplied REST client.
+func NewGraphQLClient(c *github.Client) *GraphQLClient {
+	u := *c.BaseURL
+	// The REST API of GitHub Enterprise Server is served at /api/v3/, and its
+	// GraphQL API at /api/graphql.
+	u.Path = strings.TrimSuffix(strings.TrimSuffix(u.Path, "/"), "/v3") + "/graphql"
+	return &GraphQLClient{client: c.Client(), url: u.String()}
+}
+
+type graphQLRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables,omitempty"`
+}
+
+type graphQLError struct {
+	Type    string `json:"type,omitempty"`
+	Message string `json:"message"`
+}
+
+type graphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []graphQLError  `json:"errors,omitempty"`
+}
+
+// Query the GraphQL API, decoding the data of the response into the supplied
+// value.
+func (c *GraphQLClient) Query(ctx context.Context, query string, variables map[string]interface{}, data interface{}) error {
+	body, err := json.Marshal(graphQLRequest{Query: query, Variables: variables})
+	if err != nil {
+		return errors.Wrap(err, errMarshalQuery)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, errGraphQLRequest)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	rsp, err := c.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, errGraphQLRequest)
+	}
+	defer rsp.Body.Close() //nolint:errcheck // Nothing useful to do with this error.
+
+	if rsp.StatusCode != http.StatusOK {
+		return errors.Errorf("%s: %s", errGraphQLStatus, rsp.Status)
+	}
+
+	gr := &graphQLResponse{}
+	if err := json.NewDecoder(rsp.Body).Decode(gr); err != nil {
+		return errors.Wrap(err, errGraphQLResponse)
+	}
+	if len(gr.Errors) > 0 {
+		msgs := make([]string, len(gr.Errors))
+		for i, e := range gr.Errors {
+			msgs[i] = e.Message
+		}
+		return errors.Errorf("%s: %s", errGraphQL, strings.Join(msgs, "; "))
+	}
+	return errors.Wrap(json.Unmarshal(gr.Data, data), errGraphQLResponse)
+}
+
+// graphQLPageSize is the largest page size the GitHub GraphQL API allows.
+const graphQLPageSize = 100
+
+type pageInfo struct {
+	HasNextPage bool   `json:"hasNextPage"`
+	EndCursor   string `json:"endCursor"`
+}
+
+const queryTeamMembers = `query($org: String!, $team: String!, $first: Int!, $cursor: String) {
+  organization(login: $org) {
+    team(slug: $team) {
+      members(first: $first, after: $cursor, membership: IMMEDIATE) {
+        pageInfo { hasNextPage endCursor }
+        edges { role node { login } }
+      }
+    }
+  }
+}`
+
+type teamMembersData struct {
+	Organization *struct {
+		Team *struct {
+			Members struct {
+				PageInfo pageInfo `json:"pageInfo"`
+				Edges    []struct {
+					Role string `json:"role"`
+					Node struct {
+						Login string `json:"login"`
+					} `json:"node"`
+				} `json:"edges"`
+			} `json:"members"`
+		} `json:"team"`
+	} `json:"organization"`
+}
+
+// TeamMembers returns the active memberships of the supplied team, keyed by
+// user login. Pending memberships are not visible via the GraphQL API.
+func (c *GraphQLClient) TeamMembers(ctx context.Context, org, team string) (map[string]*github.Membership, error) {
+	members := map[string]*github.Membership{}
+	vars := map[string]interface{}{"org": org, "team": team, "first": graphQLPageSize}
+	for {
+		d := &teamMembersData{}
+		if err := c.Query(ctx, queryTeamMembers, vars, d); err != nil {
+			return nil, err
+		}
+		if d.Organization == nil || d.Organization.Team == nil {
+			return nil, errors.Errorf("cannot find team %s/%s", org, team)
+		}
+		m := d.Organization.Team.Members
+		for _, e := range m.Edges {
+			members[e.Node.Login] = &github.Membership{
+				Role:  github.String(strings.ToLower(e.Role)),
+				State: github.String("active"),
+			}
+		}
+		if !m.PageInfo.HasNextPage {
+			return members, nil
+		}
+		vars["cursor"] = m.PageInfo.EndCursor
+	}
+}
+
+const queryTeams = `query($org: String!, $first: Int!, $cursor: String) {
+  organization(login: $org) {
+    teams(first: $first, after: $cursor) {
+      pageInfo { hasNextPage endCursor }
+      nodes { id databaseId slug name description privacy parentTeam { slug } }
+    }
+  }
+}`
+
+type teamsData struct {
+	Organization *struct {
+		Teams struct {
+			PageInfo pageInfo `json:"pageInfo"`
+			Nodes    []struct {
+				ID          string  `json:"id"`
+				DatabaseID  int64   `json:"databaseId"`
+				Slug        string  `json:"slug"`
+				Name        string  `json:"name"`
+				Description *string `json:"description"`
+				Privacy     string  `json:"privacy"`
+				ParentTeam  *struct {
+					Slug string `json:"slug"`
+				} `json:"parentTeam"`
+			} `json:"nodes"`
+		} `json:"teams"`
+	} `json:"organization"`
+}
+
+// privacy converts GraphQL team privacy to its REST representation.
+func privacy(p string) string {
+	if p == "VISIBLE" {
+		return "closed"
+	}
+	return strings.ToLower(p)
+}
+
+// Teams returns the teams of the supplied organization, keyed by slug. Their
+// notification setting is not queried, because GitHub Enterprise Server
+// versions that do not support it reject any query for it, so it must be read
+// via the REST API.
+func (c *GraphQLClient) Teams(ctx context.Context, org string) (map[string]*Team, error) {
+	teams := map[string]*Team{}
+	vars := map[string]interface{}{"org": org, "first": graphQLPageSize}
+	for {
+		d := &teamsData{}
+		if err := c.Query(ctx, queryTeams, vars, d); err != nil {
+			return nil, err
+		}
+		if d.Organization == nil {
+			return nil, errors.Errorf("cannot find organization %s", org)
+		}
+		t := d.Organization.Teams
+		for _, n := range t.Nodes {
+			team := &Team{Team: github.Team{
+				ID:          github.Int64(n.DatabaseID),
+				NodeID:      github.String(n.ID),
+				Slug:        github.String(n.Slug),
+				Name:        github.String(n.Name),
+				Description: n.Description,
+				Privacy:     github.String(privacy(n.Privacy)),
+			}}
+			if n.ParentTeam != nil {
+				team.Parent = &github.Team{Slug: github.String(n.ParentTeam.Slug)}
+			}
+			teams[n.Slug] = team
+		}
+		if !t.PageInfo.HasNextPage {
+			return teams, nil
+		}
+		vars["cursor"] = t.PageInfo.EndCursor
+	}
+}
+
+const queryExternalIdentities = `query($org: String!, $first: Int!, $cursor: String) {
+  organization(login: $org) {
+    samlIdentityProvider {
+      externalIdentities(first: $first, after: $cursor) {
+        pageInfo { hasNextPage endCursor }
+        nodes { samlIdentity { nameId } scimIdentity { username } user { login } }
+      }
+    }
+  }
+}`
+
+type externalIdentitiesData struct {
+	Organization *struct {
+		SAMLIdentityProvider *struct {
+			ExternalIdentities struct {
+				PageInfo pageInfo `json:"pageInfo"`
+				Nodes    []struct {
+					SAMLIdentity *struct {
+						NameID string `json:"nameId"`
+					} `json:"samlIdentity"`
+					SCIMIdentity *struct {
+						Username string `json:"username"`
+					} `json:"scimIdentity"`
+					// The user is null until the identity is linked to a
+					// GitHub user.
+					User *struct {
+						Login string `json:"login"`
+					} `json:"user"`
+				} `json:"nodes"`
+			} `json:"externalIdentities"`
+		} `json:"samlIdentityProvider"`
+	} `json:"organization"`
+}
+
+// ExternalIdentities returns the logins of the users linked to the supplied
+// organization's identity provider, keyed by both their SAML NameID and their
+// SCIM username. Identities that are not yet linked to a user are omitted.
+func (c *GraphQLClient) ExternalIdentities(ctx context.Context, org string) (map[string]string, error) {
+	logins := map[string]string{}
+	vars := map[string]interface{}{"org": org, "first": graphQLPageSize}
+	for {
+		d := &externalIdentitiesData{}
+		if err := c.Query(ctx, queryExternalIdentities, vars, d); err != nil {
+			return nil, err
+		}
+		if d.Organization == nil {
+			return nil, errors.Errorf("cannot find organization %s", org)
+		}
+		if d.Organization.SAMLIdentityProvider == nil {
+			return nil, errors.Errorf("organization %s does not use SAML single sign-on", org)
+		}
+		ei := d.Organization.SAMLIdentityProvider.ExternalIdentities
+		for _, n := range ei.Nodes {
+			if n.User == nil {
+				continue
+			}
+			if n.SAMLIdentity != nil && n.SAMLIdentity.NameID != "" {
+				logins[n.SAMLIdentity.NameID] = n.User.Login
+			}
+			if n.SCIMIdentity != nil && n.SCIMIdentity.Username != "" {
+				logins[n.SCIMIdentity.Username] = n.User.Login
+			}
+		}
+		if !ei.PageInfo.HasNextPage {
+			return logins, nil
+		}
+		vars["cursor"] = ei.PageInfo.EndCursor
+	}
+}
@@ -0,0 +1,287 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v45/github"
+)
+
+// A fakeGraphQL server serves the team and team member queries made by a
+// GraphQLClient from fixed data, paginating it with the supplied page size.
+type fakeGraphQL struct {
+	pageSize int
+	teams    []string
+	members  map[string][]string // Logins keyed by team slug.
+	nameIDs  []string            // The login of each user is its NameID.
+	fail     bool
+
+	queries int32
+}
+
+func (f *fakeGraphQL) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	atomic.AddInt32(&f.queries, 1)
+	if r.URL.Path != "/graphql" || r.Method != http.MethodPost {
+		http.NotFound(w, r)
+		return
+	}
+	if f.fail {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"errors": []map[string]string{{"type": "RATE_LIMITED", "message": "API rate limit exceeded"}},
+		})
+		return
+	}
+
+	req := &graphQLRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	start := 0
+	if c, ok := req.Variables["cursor"].(string); ok {
+		start, _ = strconv.Atoi(c)
+	}
+
+	page := func(all []string) ([]string, map[string]interface{}) {
+		end := start + f.pageSize
+		if end > len(all) {
+			end = len(all)
+		}
+		return all[start:end], map[string]interface{}{"hasNextPage": end < len(all), "endCursor": strconv.Itoa(end)}
+	}
+
+	var data map[string]interface{}
+	switch {
+	case strings.Contains(req.Query, "members("):
+		logins, ok := f.members[req.Variables["team"].(string)]
+		if !ok {
+			data = map[string]interface{}{"organization": map[string]interface{}{"team": nil}}
+			break
+		}
+		logins, pi := page(logins)
+		edges := make([]map[string]interface{}, len(logins))
+		for i, l := range logins {
+			role := "MEMBER"
+			if strings.HasPrefix(l, "maintainer") {
+				role = "MAINTAINER"
+			}
+			edges[i] = map[string]interface{}{"role": role, "node": map[string]string{"login": l}}
+		}
+		data = map[string]interface{}{"organization": map[string]interface{}{"team": map[string]interface{}{
+			"members": map[string]interface{}{"pageInfo": pi, "edges": edges},
+		}}}
+	case strings.Contains(req.Query, "externalIdentities("):
+		ids, pi := page(f.nameIDs)
+		nodes := make([]map[string]interface{}, len(ids))
+		for i, id := range ids {
+			nodes[i] = map[string]interface{}{"samlIdentity": map[string]string{"nameId": id}, "scimIdentity": nil, "user": map[string]string{"login": id}}
+		}
+		data = map[string]interface{}{"organization": map[string]interface{}{"samlIdentityProvider": map[string]interface{}{
+			"externalIdentities": map[string]interface{}{"pageInfo": pi, "nodes": nodes},
+		}}}
+	case strings.Contains(req.Query, "teams("):
+		slugs, pi := page(f.teams)
+		nodes := make([]map[string]interface{}, len(slugs))
+		for i, s := range slugs {
+			nodes[i] = map[string]interface{}{"id": "T_" + s, "databaseId": i, "slug": s, "name": s, "description": nil, "privacy": "VISIBLE", "parentTeam": nil}
+		}
+		data = map[string]interface{}{"organization": map[string]interface{}{"teams": map[string]interface{}{
+			"pageInfo": pi, "nodes": nodes,
+		}}}
+	}
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+}
+
+func newGraphQLClient(t *testing.T, h http.Handler) *GraphQLClient {
+	t.Helper()
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+	c := github.NewClient(srv.Client())
+	c.BaseURL, _ = url.Parse(srv.URL + "/")
+	return NewGraphQLClient(c)
+}
+
+func TestNewGraphQLClient(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		baseURL string
+		want    string
+	}{
+		"GitHub": {
+			reason:  "The GraphQL API of github.com should be served at /graphql.",
+			baseURL: "https://api.github.com/",
+			want:    "https://api.github.com/graphql",
+		},
+		"EnterpriseServer": {
+			reason:  "The GraphQL API of GitHub Enterprise Server should be served at /api/graphql.",
+			baseURL: "https://github.example.org/api/v3/",
+			want:    "https://github.example.org/api/graphql",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := github.NewClient(nil)
+			c.BaseURL, _ = url.Parse(tc.baseURL)
+			got := NewGraphQLClient(c).url
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nNewGraphQLClient(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestTeamMembers(t *testing.T) {
+	f := &fakeGraphQL{pageSize: 2, members: map[string][]string{
+		"platform": {"maintainer-a", "b", "c", "d", "e"},
+	}}
+	q := newGraphQLClient(t, f)
+
+	got, err := q.TeamMembers(context.Background(), "crossplane", "platform")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]*github.Membership{
+		"maintainer-a": {Role: github.String("maintainer"), State: github.String("active")},
+		"b":            {Role: github.String("member"), State: github.String("active")},
+		"c":            {Role: github.String("member"), State: github.String("active")},
+		"d":            {Role: github.String("member"), State: github.String("active")},
+		"e":            {Role: github.String("member"), State: github.String("active")},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("TeamMembers(...): -want, +got:\n%s", diff)
+	}
+	if diff := cmp.Diff(int32(3), f.queries); diff != "" {
+		t.Errorf("TeamMembers(...): should page through all members: -want queries, +got queries:\n%s", diff)
+	}
+
+	if _, err := q.TeamMembers(context.Background(), "crossplane", "nope"); err == nil {
+		t.Errorf("TeamMembers(...): should return an error when the team does not exist")
+	}
+}
+
+func TestTeams(t *testing.T) {
+	f := &fakeGraphQL{pageSize: 100, teams: []string{"platform"}}
+	q := newGraphQLClient(t, f)
+
+	got, err := q.Teams(context.Background(), "crossplane")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]*Team{
+		"platform": {
+			Team: github.Team{
+				ID:      github.Int64(0),
+				NodeID:  github.String("T_platform"),
+				Slug:    github.String("platform"),
+				Name:    github.String("platform"),
+				Privacy: github.String("closed"),
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Teams(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestExternalIdentities(t *testing.T) {
+	f := &fakeGraphQL{pageSize: 2, nameIDs: []string{"a", "b", "c", "d", "hubot"}}
+	q := newGraphQLClient(t, f)
+
+	got, err := q.ExternalIdentities(context.Background(), "crossplane")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"a": "a", "b": "b", "c": "c", "d": "d", "hubot": "hubot"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ExternalIdentities(...): should page through all identities: -want, +got:\n%s", diff)
+	}
+}
+
+func TestObservationCache(t *testing.T) {
+	f := &fakeGraphQL{pageSize: 100, members: map[string][]string{"platform": {"a", "b"}}}
+	q := newGraphQLClient(t, f)
+
+	now := time.Now()
+	c := NewObservationCache(time.Minute)
+	c.now = func() time.Time { return now }
+
+	// Concurrent observations of the same team should share one query.
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.TeamMembers(context.Background(), q, "default", "crossplane", "platform"); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	if diff := cmp.Diff(int32(1), atomic.LoadInt32(&f.queries)); diff != "" {
+		t.Errorf("TeamMembers(...): concurrent observations should share results: -want queries, +got queries:\n%s", diff)
+	}
+
+	// Observations using different credentials should not share results.
+	if _, err := c.TeamMembers(context.Background(), q, "other", "crossplane", "platform"); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(int32(2), atomic.LoadInt32(&f.queries)); diff != "" {
+		t.Errorf("TeamMembers(...): observations of different scopes should not share results: -want queries, +got queries:\n%s", diff)
+	}
+
+	// Results should be refreshed once they expire.
+	now = now.Add(2 * time.Minute)
+	if _, err := c.TeamMembers(context.Background(), q, "default", "crossplane", "platform"); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(int32(3), atomic.LoadInt32(&f.queries)); diff != "" {
+		t.Errorf("TeamMembers(...): expired results should be refreshed: -want queries, +got queries:\n%s", diff)
+	}
+
+	// Results should be refreshed once they are invalidated.
+	c.InvalidateTeamMembers("default", "crossplane", "platform")
+	if _, err := c.TeamMembers(context.Background(), q, "default", "crossplane", "platform"); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(int32(4), atomic.LoadInt32(&f.queries)); diff != "" {
+		t.Errorf("TeamMembers(...): invalidated results should be refreshed: -want queries, +got queries:\n%s", diff)
+	}
+
+	// Errors should be returned, and not cached.
+	f.fail = true
+	c.InvalidateTeamMembers("default", "crossplane", "platform")
+	if _, err := c.TeamMembers(context.Background(), q, "default", "crossplane", "platform"); err == nil {
+		t.Errorf("TeamMembers(...): should return GraphQL errors")
+	}
+	f.fail = false
+	if _, err := c.TeamMembers(context.Background(), q, "default", "crossplane", "platform"); err != nil {
+		t.Errorf("TeamMembers(...): errors should not be cached: %s", err)
+	}
+}
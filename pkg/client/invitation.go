@@ -0,0 +1,64 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v45/github"
+)
+
+// InvitationTTL is how long an invitation to an organization remains valid.
+// GitHub expires invitations that are not accepted within 7 days.
+const InvitationTTL = 7 * 24 * time.Hour
+
+// GetPendingOrgInvitation returns the supplied user's pending invitation to
+// the supplied organization, or nil if they have none. Logins are
+// case-insensitive.
+func GetPendingOrgInvitation(ctx context.Context, c *github.Client, org, login string) (*github.Invitation, error) {
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		l, rsp, err := c.Organizations.ListPendingOrgInvitations(ctx, org, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, i := range l {
+			if strings.EqualFold(i.GetLogin(), login) {
+				return i, nil
+			}
+		}
+		if rsp.NextPage == 0 {
+			return nil, nil
+		}
+		opts.Page = rsp.NextPage
+	}
+}
+
+// CancelOrgInvitation cancels the supplied invitation to the supplied
+// organization. OrganizationsService does not yet support this endpoint.
+func CancelOrgInvitation(ctx context.Context, c *github.Client, org string, id int64) error {
+	req, err := c.NewRequest(http.MethodDelete, fmt.Sprintf("orgs/%v/invitations/%v", org, id), nil)
+	if err != nil {
+		return err
+	}
+	_, err = c.Do(ctx, req, nil)
+	return err
+}
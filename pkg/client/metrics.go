@@ -0,0 +1,149 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	metricsNamespace = "github"
+	metricsSubsystem = "api"
+
+	labelProviderConfig = "provider_config"
+	labelMethod         = "method"
+	labelEndpoint       = "endpoint"
+	labelCode           = "code"
+	labelResource       = "resource"
+
+	headerRateRemaining = "X-RateLimit-Remaining"
+	headerRateResource  = "X-RateLimit-Resource"
+
+	// codeError is recorded as the status code of requests that did not
+	// produce a response, for example because the connection failed.
+	codeError = "error"
+)
+
+var (
+	requestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "requests_total",
+		Help:      "Total number of requests made to the GitHub API.",
+	}, []string{labelProviderConfig, labelMethod, labelEndpoint, labelCode})
+
+	requestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "request_duration_seconds",
+		Help:      "Latency of requests made to the GitHub API.",
+		Buckets:   []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10},
+	}, []string{labelProviderConfig, labelMethod, labelEndpoint})
+
+	rateLimitRemaining = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "rate_limit_remaining",
+		Help:      "Number of requests remaining in the current GitHub API rate limit window.",
+	}, []string{labelProviderConfig, labelResource})
+)
+
+func init() {
+	metrics.Registry.MustRegister(requestsTotal, requestDuration, rateLimitRemaining)
+}
+
+// An InstrumentedTransport is an http.RoundTripper that records metrics about
+// the GitHub API requests it makes on behalf of a ProviderConfig.
+type InstrumentedTransport struct {
+	// Base is the RoundTripper used to make requests. http.DefaultTransport
+	// is used if it is nil.
+	Base http.RoundTripper
+
+	// ProviderConfig is the name of the ProviderConfig whose credentials are
+	// used to make requests.
+	ProviderConfig string
+}
+
+// RoundTrip makes the supplied request and records its outcome.
+func (t *InstrumentedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	endpoint := Endpoint(req.URL.Path)
+	start := time.Now()
+	rsp, err := base.RoundTrip(req)
+	requestDuration.WithLabelValues(t.ProviderConfig, req.Method, endpoint).Observe(time.Since(start).Seconds())
+
+	if err != nil {
+		requestsTotal.WithLabelValues(t.ProviderConfig, req.Method, endpoint, codeError).Inc()
+		return rsp, err
+	}
+	requestsTotal.WithLabelValues(t.ProviderConfig, req.Method, endpoint, strconv.Itoa(rsp.StatusCode)).Inc()
+
+	if remaining, err := strconv.ParseFloat(rsp.Header.Get(headerRateRemaining), 64); err == nil {
+		resource := rsp.Header.Get(headerRateResource)
+		if resource == "" {
+			resource = "core"
+		}
+		rateLimitRemaining.WithLabelValues(t.ProviderConfig, resource).Set(remaining)
+	}
+	return rsp, nil
+}
+
+// literalSegments are the path segments of the GitHub REST API that name a
+// collection or an action rather than an individual object.
+var literalSegments = map[string]bool{
+	"actions": true, "api": true, "blocks": true, "branches": true,
+	"codeowners": true, "contents": true, "custom_roles": true,
+	"errors": true, "failed_invitations": true, "forks": true,
+	"generate": true, "graphql": true, "group-mappings": true,
+	"groups": true, "installation": true, "invitations": true,
+	"members": true, "memberships": true, "orgs": true,
+	"outside_collaborators": true, "permissions": true,
+	"registration-token": true, "repos": true, "repositories": true,
+	"runner-groups": true, "runners": true, "security-managers": true,
+	"selected-actions": true, "team-sync": true, "teams": true,
+	"user": true, "users": true, "v3": true,
+}
+
+// Endpoint reduces the supplied GitHub API request path to a template by
+// replacing the names of individual objects with a placeholder, so that
+// metrics are not labelled with unbounded values such as user logins. For
+// example /orgs/crossplane/teams/maintainers becomes /orgs/{}/teams/{}.
+func Endpoint(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, s := range segments {
+		if s == "" || literalSegments[s] {
+			continue
+		}
+		segments[i] = "{}"
+		// The path of a file in the contents API may span many segments.
+		if i > 0 && segments[i-1] == "contents" {
+			segments = segments[:i+1]
+			break
+		}
+	}
+	return "/" + strings.Join(segments, "/")
+}
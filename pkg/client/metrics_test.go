@@ -0,0 +1,211 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func TestEndpoint(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		path   string
+		want   string
+	}{
+		"Root": {
+			reason: "The root path should be labelled as such.",
+			path:   "/",
+			want:   "/",
+		},
+		"Org": {
+			reason: "The name of an organization should be replaced.",
+			path:   "/orgs/crossplane",
+			want:   "/orgs/{}",
+		},
+		"EnterpriseServer": {
+			reason: "The /api/v3 prefix of GitHub Enterprise Server should be kept.",
+			path:   "/api/v3/orgs/crossplane/teams/maintainers",
+			want:   "/api/v3/orgs/{}/teams/{}",
+		},
+		"EnterpriseServerGraphQL": {
+			reason: "The GraphQL API of GitHub Enterprise Server should be labelled as such.",
+			path:   "/api/graphql",
+			want:   "/api/graphql",
+		},
+		"GraphQL": {
+			reason: "The GraphQL API should be labelled as such.",
+			path:   "/graphql",
+			want:   "/graphql",
+		},
+		"Contents": {
+			reason: "The path of a file should be replaced by one placeholder, however many segments it spans.",
+			path:   "/repos/crossplane/infra/contents/.github/workflows/ci.yml",
+			want:   "/repos/{}/{}/contents/{}",
+		},
+		"EnterpriseServerContents": {
+			reason: "The path of a file should be replaced by one placeholder behind the /api/v3 prefix.",
+			path:   "/api/v3/repos/crossplane/infra/contents/docs/README.md",
+			want:   "/api/v3/repos/{}/{}/contents/{}",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := Endpoint(tc.path)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nEndpoint(%q): -want, +got:\n%s\n", tc.reason, tc.path, diff)
+			}
+		})
+	}
+}
+
+// TestEndpointCalls tests the endpoints of the requests made by the provider's
+// controllers, which must be labelled with a template rather than the names
+// of the objects they concern.
+func TestEndpointCalls(t *testing.T) {
+	cases := map[string]string{
+		"/user":                      "/user",
+		"/user/42":                   "/user/{}",
+		"/users/hubot":               "/users/{}",
+		"/installation/repositories": "/installation/repositories",
+		"/orgs/crossplane/teams":     "/orgs/{}/teams",
+		"/orgs/crossplane/teams/maintainers/memberships/hubot":        "/orgs/{}/teams/{}/memberships/{}",
+		"/orgs/crossplane/teams/maintainers/members":                  "/orgs/{}/teams/{}/members",
+		"/orgs/crossplane/teams/maintainers/invitations":              "/orgs/{}/teams/{}/invitations",
+		"/orgs/crossplane/teams/maintainers/team-sync/group-mappings": "/orgs/{}/teams/{}/team-sync/group-mappings",
+		"/orgs/crossplane/teams/maintainers/repos/crossplane/infra":   "/orgs/{}/teams/{}/repos/{}/{}",
+		"/orgs/crossplane/team-sync/groups":                           "/orgs/{}/team-sync/groups",
+		"/orgs/crossplane/memberships/hubot":                          "/orgs/{}/memberships/{}",
+		"/orgs/crossplane/members/hubot":                              "/orgs/{}/members/{}",
+		"/orgs/crossplane/invitations":                                "/orgs/{}/invitations",
+		"/orgs/crossplane/invitations/42":                             "/orgs/{}/invitations/{}",
+		"/orgs/crossplane/failed_invitations":                         "/orgs/{}/failed_invitations",
+		"/orgs/crossplane/outside_collaborators":                      "/orgs/{}/outside_collaborators",
+		"/orgs/crossplane/outside_collaborators/hubot":                "/orgs/{}/outside_collaborators/{}",
+		"/orgs/crossplane/blocks/hubot":                               "/orgs/{}/blocks/{}",
+		"/orgs/crossplane/custom_roles":                               "/orgs/{}/custom_roles",
+		"/orgs/crossplane/custom_roles/42":                            "/orgs/{}/custom_roles/{}",
+		"/orgs/crossplane/security-managers":                          "/orgs/{}/security-managers",
+		"/orgs/crossplane/security-managers/teams/maintainers":        "/orgs/{}/security-managers/teams/{}",
+		"/orgs/crossplane/repos":                                      "/orgs/{}/repos",
+		"/repos/crossplane/infra":                                     "/repos/{}/{}",
+		"/repos/crossplane/template/generate":                         "/repos/{}/{}/generate",
+		"/repos/crossplane/upstream/forks":                            "/repos/{}/{}/forks",
+		"/repos/crossplane/infra/branches":                            "/repos/{}/{}/branches",
+		"/repos/crossplane/infra/codeowners/errors":                   "/repos/{}/{}/codeowners/errors",
+		"/orgs/crossplane/actions/permissions":                        "/orgs/{}/actions/permissions",
+		"/orgs/crossplane/actions/permissions/selected-actions":       "/orgs/{}/actions/permissions/selected-actions",
+		"/orgs/crossplane/actions/permissions/repositories":           "/orgs/{}/actions/permissions/repositories",
+		"/orgs/crossplane/actions/permissions/repositories/42":        "/orgs/{}/actions/permissions/repositories/{}",
+		"/orgs/crossplane/actions/runner-groups":                      "/orgs/{}/actions/runner-groups",
+		"/orgs/crossplane/actions/runner-groups/42":                   "/orgs/{}/actions/runner-groups/{}",
+		"/orgs/crossplane/actions/runner-groups/42/repositories":      "/orgs/{}/actions/runner-groups/{}/repositories",
+		"/orgs/crossplane/actions/runner-groups/42/runners/7":         "/orgs/{}/actions/runner-groups/{}/runners/{}",
+		"/orgs/crossplane/actions/runners/registration-token":         "/orgs/{}/actions/runners/registration-token",
+	}
+
+	for path, want := range cases {
+		t.Run(path, func(t *testing.T) {
+			if diff := cmp.Diff(want, Endpoint(path)); diff != "" {
+				t.Errorf("Endpoint(%q): -want, +got:\n%s\n", path, diff)
+			}
+		})
+	}
+}
+
+// A roundTripperFunc is an http.RoundTripper that calls itself.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (fn roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) { return fn(req) }
+
+func TestInstrumentedTransportRoundTrip(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/orgs/crossplane/teams/nope" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set(headerRateRemaining, "4999")
+		w.Header().Set(headerRateResource, "core")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	errBoom := errors.New("boom")
+
+	cases := map[string]struct {
+		reason    string
+		base      http.RoundTripper
+		path      string
+		code      string
+		err       error
+		remaining float64
+	}{
+		"OK": {
+			reason:    "A successful request should be counted with its status code, and the remaining rate limit recorded.",
+			path:      "/orgs/crossplane/teams/maintainers",
+			code:      "200",
+			remaining: 4999,
+		},
+		"NotFound": {
+			reason: "An unsuccessful request should be counted with its status code.",
+			path:   "/orgs/crossplane/teams/nope",
+			code:   "404",
+		},
+		"Error": {
+			reason: "A request that did not produce a response should be counted as an error.",
+			base:   roundTripperFunc(func(*http.Request) (*http.Response, error) { return nil, errBoom }),
+			path:   "/orgs/crossplane/teams/maintainers",
+			code:   codeError,
+			err:    errBoom,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			// Each case counts requests made on behalf of its own
+			// ProviderConfig, since metrics are registered globally.
+			pc := "roundtrip-" + name
+			rt := &InstrumentedTransport{Base: tc.base, ProviderConfig: pc}
+
+			requests := requestsTotal.WithLabelValues(pc, http.MethodGet, Endpoint(tc.path), tc.code)
+			before := testutil.ToFloat64(requests)
+
+			req, _ := http.NewRequest(http.MethodGet, srv.URL+tc.path, nil)
+			rsp, err := rt.RoundTrip(req)
+			if rsp != nil {
+				rsp.Body.Close() //nolint:errcheck // Nothing useful to do with this error.
+			}
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nRoundTrip(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if got := testutil.ToFloat64(requests) - before; got != 1 {
+				t.Errorf("\n%s\nRoundTrip(...): want 1 request with code %s, got %v", tc.reason, tc.code, got)
+			}
+			if got := testutil.ToFloat64(rateLimitRemaining.WithLabelValues(pc, "core")); got != tc.remaining {
+				t.Errorf("\n%s\nRoundTrip(...): want %v remaining requests, got %v", tc.reason, tc.remaining, got)
+			}
+		})
+	}
+}
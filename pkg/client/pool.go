@@ -0,0 +1,89 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"sync"
+
+	"github.com/google/go-github/v45/github"
+
+	apisv1alpha1 "github.com/hasheddan/kc-provider-github/apis/v1alpha1"
+)
+
+type pooledClient struct {
+	hash   string
+	client *github.Client
+}
+
+// A Pool of GitHub clients. Clients are keyed by the name of the
+// ProviderConfig whose credentials they use, and are shared by all managed
+// resources that use that ProviderConfig so that they may reuse connections
+// and token sources.
+type Pool struct {
+	mu      sync.Mutex
+	clients map[string]pooledClient
+}
+
+// NewPool returns an empty Pool.
+func NewPool() *Pool {
+	return &Pool{clients: map[string]pooledClient{}}
+}
+
+// defaultPool is shared by all controllers.
+var defaultPool = NewPool()
+
+// credentialHash identifies a ProviderConfig and the credentials read from it.
+// It changes when the ProviderConfig is recreated, when its spec changes, or
+// when its credentials change.
+func credentialHash(pc *apisv1alpha1.ProviderConfig, token string) string {
+	h := sha256.New()
+	for _, s := range []string{string(pc.GetUID()), strconv.FormatInt(pc.GetGeneration(), 10), token} {
+		h.Write([]byte(s))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Get returns a client for the supplied ProviderConfig that authenticates
+// using the supplied token. The pooled client is returned unless the
+// ProviderConfig or token have changed since it was created, in which case it
+// is replaced.
+func (p *Pool) Get(pc *apisv1alpha1.ProviderConfig, token string) (*github.Client, error) {
+	hash := credentialHash(pc, token)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if c, ok := p.clients[pc.GetName()]; ok && c.hash == hash {
+		return c.client, nil
+	}
+
+	svc, err := NewClient(token, pc.GetName())
+	if err != nil {
+		return nil, err
+	}
+	if pc.Spec.BaseURL != nil {
+		if err := setBaseURL(svc, *pc.Spec.BaseURL); err != nil {
+			return nil, err
+		}
+	}
+	p.clients[pc.GetName()] = pooledClient{hash: hash, client: svc}
+	return svc, nil
+}
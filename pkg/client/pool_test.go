@@ -0,0 +1,97 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v45/github"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apisv1alpha1 "github.com/hasheddan/kc-provider-github/apis/v1alpha1"
+)
+
+func TestPool(t *testing.T) {
+	pc := func(name string, generation int64) *apisv1alpha1.ProviderConfig {
+		return &apisv1alpha1.ProviderConfig{ObjectMeta: metav1.ObjectMeta{Name: name, UID: "cool-uid", Generation: generation}}
+	}
+
+	p := NewPool()
+	first, err := p.Get(pc("default", 1), "token")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]struct {
+		reason string
+		pc     *apisv1alpha1.ProviderConfig
+		token  string
+		reuse  bool
+	}{
+		"Unchanged": {
+			reason: "The pooled client should be reused if neither the ProviderConfig nor its credentials changed.",
+			pc:     pc("default", 1),
+			token:  "token",
+			reuse:  true,
+		},
+		"TokenChanged": {
+			reason: "A new client should be created if the credentials changed.",
+			pc:     pc("default", 1),
+			token:  "new-token",
+		},
+		"ProviderConfigChanged": {
+			reason: "A new client should be created if the ProviderConfig changed.",
+			pc:     pc("default", 2),
+			token:  "token",
+		},
+		"OtherProviderConfig": {
+			reason: "Clients should not be shared between ProviderConfigs.",
+			pc:     pc("other", 1),
+			token:  "token",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			p := NewPool()
+			p.clients["default"] = pooledClient{hash: credentialHash(pc("default", 1), "token"), client: first}
+
+			got, err := p.Get(tc.pc, tc.token)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if (got == first) != tc.reuse {
+				t.Errorf("\n%s\nGet(...): reused client: want %t, got %t", tc.reason, tc.reuse, got == first)
+			}
+		})
+	}
+
+	if _, err := p.Get(pc("default", 1), ""); err == nil {
+		t.Errorf("Get(...): should return an error when no token is supplied")
+	}
+
+	enterprise := pc("enterprise", 1)
+	enterprise.Spec.BaseURL = github.String("https://github.example.org/api/v3")
+	c, err := p.Get(enterprise, "token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff("https://github.example.org/api/v3/", c.BaseURL.String()); diff != "" {
+		t.Errorf("Get(...): should use the ProviderConfig's base URL: -want, +got:\n%s", diff)
+	}
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-github/v45/github"
+	"github.com/pkg/errors"
+)
+
+// CreateFork forks the supplied repository into the supplied organization,
+// with the supplied name. RepositoriesService cannot name a fork. GitHub
+// accepts the fork and creates it asynchronously, so the returned repository
+// may not yet have any branches.
+func CreateFork(ctx context.Context, c *github.Client, owner, repo, org, name string) (*github.Repository, error) {
+	body := &struct {
+		Organization string `json:"organization"`
+		Name         string `json:"name,omitempty"`
+	}{Organization: org, Name: name}
+	req, err := c.NewRequest(http.MethodPost, fmt.Sprintf("repos/%v/%v/forks", owner, repo), body)
+	if err != nil {
+		return nil, err
+	}
+	r := &github.Repository{}
+	_, err = c.Do(ctx, req, r)
+	var aerr *github.AcceptedError
+	if errors.As(err, &aerr) {
+		// The fork is returned with 202 Accepted, which is reported as an
+		// error whose raw body is the fork.
+		return r, json.Unmarshal(aerr.Raw, r)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
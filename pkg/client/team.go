@@ -0,0 +1,104 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-github/v45/github"
+)
+
+// A Team extends github.Team with settings that it does not yet support.
+type Team struct {
+	github.Team
+	NotificationSetting *string `json:"notification_setting,omitempty"`
+}
+
+// A NewTeam extends github.NewTeam with settings that it does not yet
+// support.
+type NewTeam struct {
+	github.NewTeam
+	NotificationSetting *string `json:"notification_setting,omitempty"`
+}
+
+// GetTeamBySlug gets the supplied team, using the same endpoint as
+// TeamsService.GetTeamBySlug.
+func GetTeamBySlug(ctx context.Context, c *github.Client, org, slug string) (*Team, error) {
+	return doTeam(ctx, c, http.MethodGet, fmt.Sprintf("orgs/%v/teams/%v", org, slug), nil)
+}
+
+// CreateTeam creates the supplied team, using the same endpoint as
+// TeamsService.CreateTeam.
+func CreateTeam(ctx context.Context, c *github.Client, org string, t NewTeam) (*Team, error) {
+	return doTeam(ctx, c, http.MethodPost, fmt.Sprintf("orgs/%v/teams", org), t)
+}
+
+// EditTeamBySlug edits the supplied team, using the same endpoint as
+// TeamsService.EditTeamBySlug.
+func EditTeamBySlug(ctx context.Context, c *github.Client, org, slug string, t NewTeam) (*Team, error) {
+	return doTeam(ctx, c, http.MethodPatch, fmt.Sprintf("orgs/%v/teams/%v", org, slug), t)
+}
+
+func doTeam(ctx context.Context, c *github.Client, method, u string, body interface{}) (*Team, error) {
+	req, err := c.NewRequest(method, u, body)
+	if err != nil {
+		return nil, err
+	}
+	t := &Team{}
+	if _, err := c.Do(ctx, req, t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// ListSecurityManagerTeams lists the teams that are assigned the security
+// manager role of the supplied organization. TeamsService does not yet
+// support the security manager endpoints.
+func ListSecurityManagerTeams(ctx context.Context, c *github.Client, org string) ([]*github.Team, error) {
+	req, err := c.NewRequest(http.MethodGet, fmt.Sprintf("orgs/%v/security-managers", org), nil)
+	if err != nil {
+		return nil, err
+	}
+	var teams []*github.Team
+	if _, err := c.Do(ctx, req, &teams); err != nil {
+		return nil, err
+	}
+	return teams, nil
+}
+
+// AddSecurityManagerTeam assigns the security manager role of the supplied
+// organization to the supplied team.
+func AddSecurityManagerTeam(ctx context.Context, c *github.Client, org, slug string) error {
+	return doSecurityManagerTeam(ctx, c, http.MethodPut, org, slug)
+}
+
+// RemoveSecurityManagerTeam removes the security manager role of the
+// supplied organization from the supplied team.
+func RemoveSecurityManagerTeam(ctx context.Context, c *github.Client, org, slug string) error {
+	return doSecurityManagerTeam(ctx, c, http.MethodDelete, org, slug)
+}
+
+func doSecurityManagerTeam(ctx context.Context, c *github.Client, method, org, slug string) error {
+	req, err := c.NewRequest(method, fmt.Sprintf("orgs/%v/security-managers/teams/%v", org, slug), nil)
+	if err != nil {
+		return err
+	}
+	_, err = c.Do(ctx, req, nil)
+	return err
+}
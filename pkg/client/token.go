@@ -0,0 +1,169 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v45/github"
+	"github.com/pkg/errors"
+)
+
+const (
+	errGetAuthenticatedUser = "cannot get the authenticated user"
+	errListInstallRepos     = "cannot list the repositories accessible to the installation"
+)
+
+// Types of token, identified by their prefix. See
+// https://github.blog/2021-04-05-behind-githubs-new-authentication-token-formats/
+const (
+	TokenTypeClassic      = "classic"
+	TokenTypeFineGrained  = "fine-grained"
+	TokenTypeOAuth        = "oauth"
+	TokenTypeUserToServer = "user-to-server"
+	TokenTypeInstallation = "installation"
+	TokenTypeUnknown      = "unknown"
+)
+
+var tokenPrefixes = []struct {
+	prefix string
+	typ    string
+}{
+	{prefix: "ghp_", typ: TokenTypeClassic},
+	{prefix: "github_pat_", typ: TokenTypeFineGrained},
+	{prefix: "gho_", typ: TokenTypeOAuth},
+	{prefix: "ghu_", typ: TokenTypeUserToServer},
+	{prefix: "ghs_", typ: TokenTypeInstallation},
+}
+
+// RequiredScopes are the OAuth scopes a classic or OAuth token must be granted
+// in order to manage teams and their members.
+var RequiredScopes = []string{"admin:org"}
+
+// Response headers that describe the token used to make a request.
+const (
+	headerOAuthScopes     = "X-OAuth-Scopes"
+	headerTokenExpiration = "GitHub-Authentication-Token-Expiration"
+)
+
+// Layouts of the GitHub-Authentication-Token-Expiration header.
+var expirationLayouts = []string{"2006-01-02 15:04:05 MST", "2006-01-02 15:04:05 -0700"}
+
+// TokenInfo describes a token.
+type TokenInfo struct {
+	// Type of the token.
+	Type string
+
+	// Scopes granted to the token. Only classic and OAuth tokens have scopes.
+	Scopes []string
+
+	// ExpiresAt is when the token expires, if it does.
+	ExpiresAt *time.Time
+
+	// Login of the user the token authenticates as. Installation tokens do
+	// not authenticate as a user.
+	Login string
+}
+
+// TokenType returns the type of the supplied token.
+func TokenType(token string) string {
+	for _, p := range tokenPrefixes {
+		if strings.HasPrefix(token, p.prefix) {
+			return p.typ
+		}
+	}
+	return TokenTypeUnknown
+}
+
+// Introspect validates the supplied token by making a request to the GitHub
+// API using the supplied client, which must authenticate using the token. It
+// returns a description of the token.
+func Introspect(ctx context.Context, c *github.Client, token string) (*TokenInfo, error) {
+	ti := &TokenInfo{Type: TokenType(token)}
+
+	// Installation tokens cannot get the authenticated user.
+	if ti.Type == TokenTypeInstallation {
+		_, rsp, err := c.Apps.ListRepos(ctx, &github.ListOptions{PerPage: 1})
+		if err != nil {
+			return nil, errors.Wrap(err, errListInstallRepos)
+		}
+		ti.ExpiresAt = expiration(rsp.Header)
+		return ti, nil
+	}
+
+	u, rsp, err := c.Users.Get(ctx, "")
+	if err != nil {
+		return nil, errors.Wrap(err, errGetAuthenticatedUser)
+	}
+	ti.Login = u.GetLogin()
+	ti.ExpiresAt = expiration(rsp.Header)
+
+	// Tokens that predate the prefixed formats are classic or OAuth tokens,
+	// and are the only tokens that are granted scopes.
+	if s, ok := rsp.Header[http.CanonicalHeaderKey(headerOAuthScopes)]; ok {
+		ti.Scopes = scopes(strings.Join(s, ","))
+		if ti.Type == TokenTypeUnknown {
+			ti.Type = TokenTypeClassic
+		}
+	}
+	return ti, nil
+}
+
+// MissingScopes returns the RequiredScopes that the supplied token was not
+// granted. Only classic and OAuth tokens have scopes; the permissions of other
+// tokens cannot be introspected, so no scopes are reported missing for them.
+func MissingScopes(ti *TokenInfo) []string {
+	if ti.Type != TokenTypeClassic && ti.Type != TokenTypeOAuth {
+		return nil
+	}
+	granted := map[string]bool{}
+	for _, s := range ti.Scopes {
+		granted[s] = true
+	}
+	var missing []string
+	for _, s := range RequiredScopes {
+		if !granted[s] {
+			missing = append(missing, s)
+		}
+	}
+	return missing
+}
+
+func scopes(header string) []string {
+	var s []string
+	for _, scope := range strings.Split(header, ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			s = append(s, scope)
+		}
+	}
+	sort.Strings(s)
+	return s
+}
+
+func expiration(h http.Header) *time.Time {
+	v := h.Get(headerTokenExpiration)
+	for _, l := range expirationLayouts {
+		if t, err := time.Parse(l, v); err == nil {
+			return &t
+		}
+	}
+	return nil
+}
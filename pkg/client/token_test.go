@@ -0,0 +1,122 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hasheddan/kc-provider-github/pkg/client/fake"
+)
+
+func TestIntrospect(t *testing.T) {
+	expiration := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	type want struct {
+		ti      *TokenInfo
+		missing []string
+		err     bool
+	}
+
+	cases := map[string]struct {
+		reason     string
+		token      string
+		login      string
+		scopes     []string
+		expiration time.Time
+		want       want
+	}{
+		"Classic": {
+			reason:     "The login, scopes and expiration of a classic token should be reported.",
+			token:      "ghp_cool",
+			login:      "hubot",
+			scopes:     []string{"repo", "admin:org"},
+			expiration: expiration,
+			want: want{
+				ti: &TokenInfo{Type: TokenTypeClassic, Login: "hubot", Scopes: []string{"admin:org", "repo"}, ExpiresAt: &expiration},
+			},
+		},
+		"MissingScopes": {
+			reason: "Required scopes that were not granted to a classic token should be reported missing.",
+			token:  "ghp_cool",
+			login:  "hubot",
+			scopes: []string{"read:org"},
+			want: want{
+				ti:      &TokenInfo{Type: TokenTypeClassic, Login: "hubot", Scopes: []string{"read:org"}},
+				missing: []string{"admin:org"},
+			},
+		},
+		"Unprefixed": {
+			reason: "A token without a known prefix that was granted scopes should be identified as a classic token.",
+			token:  "0123456789abcdef",
+			login:  "hubot",
+			scopes: []string{},
+			want: want{
+				ti:      &TokenInfo{Type: TokenTypeClassic, Login: "hubot"},
+				missing: []string{"admin:org"},
+			},
+		},
+		"FineGrained": {
+			reason: "No scopes should be reported missing for a fine-grained token.",
+			token:  "github_pat_cool",
+			login:  "hubot",
+			want: want{
+				ti: &TokenInfo{Type: TokenTypeFineGrained, Login: "hubot"},
+			},
+		},
+		"Installation": {
+			reason:     "An installation token should be validated without getting the authenticated user.",
+			token:      "ghs_cool",
+			expiration: expiration,
+			want: want{
+				ti: &TokenInfo{Type: TokenTypeInstallation, ExpiresAt: &expiration},
+			},
+		},
+		"NotInstallation": {
+			reason: "An error should be returned if a token cannot get the authenticated user.",
+			token:  "ghp_cool",
+			want: want{
+				err: true,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := fake.NewServer()
+			defer s.Close()
+			s.SetToken(tc.login, tc.scopes, tc.expiration)
+
+			got, err := Introspect(context.Background(), s.Client(), tc.token)
+			if (err != nil) != tc.want.err {
+				t.Fatalf("\n%s\nIntrospect(...): want error %t, got %v", tc.reason, tc.want.err, err)
+			}
+			if diff := cmp.Diff(tc.want.ti, got); diff != "" {
+				t.Errorf("\n%s\nIntrospect(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if got == nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want.missing, MissingScopes(got)); diff != "" {
+				t.Errorf("\n%s\nMissingScopes(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/hasheddan/kc-provider-github/apis/v1alpha1"
+	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
+)
+
+const (
+	errGetPC            = "cannot get ProviderConfig"
+	errUpdateStatus     = "cannot update ProviderConfig status"
+	errFmtMissingScopes = "token is missing required scopes: %s"
+
+	reasonUnhealthy event.Reason = "UnhealthyCredentials"
+)
+
+// healthInterval is how often the credentials of a ProviderConfig are checked.
+const healthInterval = 10 * time.Minute
+
+// A healthReconciler checks that the credentials of a ProviderConfig can be
+// used to authenticate to GitHub, after the wrapped reconciler has accounted
+// for its usage. It records what it learns about the credentials in the
+// ProviderConfig's status, along with a Ready condition.
+type healthReconciler struct {
+	wrapped reconcile.Reconciler
+	kube    client.Client
+	log     logging.Logger
+	record  event.Recorder
+}
+
+func (r *healthReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	result, err := r.wrapped.Reconcile(ctx, req)
+	if err != nil {
+		return result, err
+	}
+
+	pc := &v1alpha1.ProviderConfig{}
+	if err := r.kube.Get(ctx, req.NamespacedName, pc); err != nil {
+		return reconcile.Result{}, errors.Wrap(resource.IgnoreNotFound(err), errGetPC)
+	}
+	if meta.WasDeleted(pc) {
+		return result, nil
+	}
+
+	// Usage of the ProviderConfig changes far more often than its
+	// credentials, so they are only checked periodically, or when it or their
+	// Secret changes.
+	version := r.secretVersion(ctx, pc)
+	unchanged := pc.Status.CheckedGeneration == pc.GetGeneration() && pc.Status.CheckedSecretVersion == version
+	if last := pc.Status.LastCheckTime; last != nil && unchanged {
+		if wait := healthInterval - time.Since(last.Time); wait > 0 {
+			if result.RequeueAfter == 0 || result.RequeueAfter > wait {
+				result.RequeueAfter = wait
+			}
+			return result, nil
+		}
+	}
+
+	now := metav1.Now()
+	pc.Status.LastCheckTime = &now
+	pc.Status.CheckedGeneration = pc.GetGeneration()
+	pc.Status.CheckedSecretVersion = version
+	if err := r.check(ctx, pc); err != nil {
+		r.log.Debug("ProviderConfig credentials are unhealthy", "request", req, "error", err)
+		r.record.Event(pc, event.Warning(reasonUnhealthy, err))
+		pc.SetConditions(xpv1.Unavailable().WithMessage(err.Error()))
+	} else {
+		pc.SetConditions(xpv1.Available())
+	}
+	if err := r.kube.Status().Update(ctx, pc); err != nil {
+		return reconcile.Result{}, errors.Wrap(err, errUpdateStatus)
+	}
+
+	if result.RequeueAfter == 0 || result.RequeueAfter > healthInterval {
+		result.RequeueAfter = healthInterval
+	}
+	return result, nil
+}
+
+// secretVersion returns the resource version of the credentials Secret of the
+// supplied ProviderConfig, or an empty string if it cannot be read. Reading the
+// credentials reports why not.
+func (r *healthReconciler) secretVersion(ctx context.Context, pc *v1alpha1.ProviderConfig) string {
+	ref := pc.Spec.Credentials.SecretRef
+	if ref == nil {
+		return ""
+	}
+	s := &corev1.Secret{}
+	if err := r.kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
+		return ""
+	}
+	return s.GetResourceVersion()
+}
+
+// credentialsOf returns a function that maps a Secret to requests to reconcile
+// the ProviderConfigs whose credentials it holds, so that rotated credentials
+// are checked promptly.
+func credentialsOf(kube client.Reader) handler.MapFunc {
+	return func(o client.Object) []reconcile.Request {
+		l := &v1alpha1.ProviderConfigList{}
+		if err := kube.List(context.Background(), l); err != nil {
+			return nil
+		}
+		var reqs []reconcile.Request
+		for _, pc := range l.Items {
+			ref := pc.Spec.Credentials.SecretRef
+			if ref != nil && ref.Namespace == o.GetNamespace() && ref.Name == o.GetName() {
+				reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{Name: pc.GetName()}})
+			}
+		}
+		return reqs
+	}
+}
+
+// check the credentials of the supplied ProviderConfig, recording what it
+// learns about them in its status.
+func (r *healthReconciler) check(ctx context.Context, pc *v1alpha1.ProviderConfig) error {
+	token, err := kcgitclient.Token(ctx, r.kube, pc)
+	if err != nil {
+		return err
+	}
+	svc, err := kcgitclient.PooledClient(pc, token)
+	if err != nil {
+		return err
+	}
+	ti, err := kcgitclient.Introspect(ctx, svc, token)
+	if err != nil {
+		return err
+	}
+
+	pc.Status.TokenType = ti.Type
+	pc.Status.Scopes = ti.Scopes
+	pc.Status.Login = ti.Login
+	pc.Status.ExpiresAt = nil
+	if ti.ExpiresAt != nil {
+		t := metav1.NewTime(*ti.ExpiresAt)
+		pc.Status.ExpiresAt = &t
+	}
+
+	if missing := kcgitclient.MissingScopes(ti); len(missing) > 0 {
+		return errors.Errorf(errFmtMissingScopes, strings.Join(missing, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,222 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v45/github"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/hasheddan/kc-provider-github/apis/v1alpha1"
+	"github.com/hasheddan/kc-provider-github/pkg/client/fake"
+)
+
+func TestHealthReconcile(t *testing.T) {
+	type token struct {
+		token  string
+		login  string
+		scopes []string
+	}
+	type want struct {
+		checked bool
+		ready   xpv1.Condition
+		status  v1alpha1.ProviderConfigStatus
+		result  reconcile.Result
+	}
+
+	recently := metav1.NewTime(time.Now().Add(-time.Minute))
+
+	cases := map[string]struct {
+		reason string
+		token  token
+		status v1alpha1.ProviderConfigStatus
+		want   want
+	}{
+		"Healthy": {
+			reason: "A ProviderConfig whose token was granted the required scopes should be available.",
+			token:  token{token: "ghp_cool", login: "hubot", scopes: []string{"admin:org"}},
+			want: want{
+				checked: true,
+				ready:   xpv1.Available(),
+				status: v1alpha1.ProviderConfigStatus{
+					TokenType:            "classic",
+					Scopes:               []string{"admin:org"},
+					Login:                "hubot",
+					CheckedSecretVersion: "1",
+				},
+				result: reconcile.Result{RequeueAfter: healthInterval},
+			},
+		},
+		"MissingScopes": {
+			reason: "A ProviderConfig whose token was not granted the required scopes should be unavailable.",
+			token:  token{token: "ghp_cool", login: "hubot", scopes: []string{"read:org"}},
+			want: want{
+				checked: true,
+				ready:   xpv1.Unavailable().WithMessage("token is missing required scopes: admin:org"),
+				status: v1alpha1.ProviderConfigStatus{
+					TokenType:            "classic",
+					Scopes:               []string{"read:org"},
+					Login:                "hubot",
+					CheckedSecretVersion: "1",
+				},
+				result: reconcile.Result{RequeueAfter: healthInterval},
+			},
+		},
+		"Unauthenticated": {
+			reason: "A ProviderConfig whose token cannot authenticate should be unavailable.",
+			token:  token{token: "ghp_cool"},
+			want: want{
+				checked: true,
+				ready:   xpv1.Unavailable().WithMessage("cannot get the authenticated user"),
+				status:  v1alpha1.ProviderConfigStatus{CheckedSecretVersion: "1"},
+				result:  reconcile.Result{RequeueAfter: healthInterval},
+			},
+		},
+		"RecentlyChecked": {
+			reason: "A ProviderConfig whose unchanged credentials were recently checked should not be checked again until they are due.",
+			token:  token{token: "ghp_cool", login: "hubot", scopes: []string{"admin:org"}},
+			status: v1alpha1.ProviderConfigStatus{LastCheckTime: &recently, CheckedSecretVersion: "1"},
+			want: want{
+				result: reconcile.Result{RequeueAfter: healthInterval - time.Minute},
+			},
+		},
+		"SecretChanged": {
+			reason: "A ProviderConfig whose credentials Secret changed since they were last checked should be checked again.",
+			token:  token{token: "ghp_cool", login: "hubot", scopes: []string{"admin:org"}},
+			status: v1alpha1.ProviderConfigStatus{LastCheckTime: &recently, CheckedSecretVersion: "0"},
+			want: want{
+				checked: true,
+				ready:   xpv1.Available(),
+				status: v1alpha1.ProviderConfigStatus{
+					TokenType:            "classic",
+					Scopes:               []string{"admin:org"},
+					Login:                "hubot",
+					CheckedSecretVersion: "1",
+				},
+				result: reconcile.Result{RequeueAfter: healthInterval},
+			},
+		},
+		"Changed": {
+			reason: "A ProviderConfig that changed since its credentials were last checked should be checked again.",
+			token:  token{token: "ghp_cool", login: "hubot", scopes: []string{"admin:org"}},
+			status: v1alpha1.ProviderConfigStatus{LastCheckTime: &recently, CheckedGeneration: 1, CheckedSecretVersion: "1"},
+			want: want{
+				checked: true,
+				ready:   xpv1.Available(),
+				status: v1alpha1.ProviderConfigStatus{
+					TokenType:            "classic",
+					Scopes:               []string{"admin:org"},
+					Login:                "hubot",
+					CheckedSecretVersion: "1",
+				},
+				result: reconcile.Result{RequeueAfter: healthInterval},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := fake.NewServer()
+			defer s.Close()
+			s.SetToken(tc.token.login, tc.token.scopes, time.Time{})
+
+			var got *v1alpha1.ProviderConfig
+			kube := &test.MockClient{
+				MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+					switch o := obj.(type) {
+					case *v1alpha1.ProviderConfig:
+						*o = v1alpha1.ProviderConfig{
+							ObjectMeta: metav1.ObjectMeta{Name: "default", UID: types.UID(name)},
+							Spec: v1alpha1.ProviderConfigSpec{
+								Credentials: v1alpha1.ProviderCredentials{
+									Source: xpv1.CredentialsSourceSecret,
+									CommonCredentialSelectors: xpv1.CommonCredentialSelectors{SecretRef: &xpv1.SecretKeySelector{
+										SecretReference: xpv1.SecretReference{Namespace: "crossplane-system", Name: "github"},
+										Key:             "token",
+									}},
+								},
+								BaseURL: github.String(s.URL),
+							},
+							Status: *tc.status.DeepCopy(),
+						}
+					case *corev1.Secret:
+						o.ResourceVersion = "1"
+						o.Data = map[string][]byte{"token": []byte(tc.token.token)}
+					}
+					return nil
+				},
+				MockStatusUpdate: func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+					got = obj.(*v1alpha1.ProviderConfig)
+					return nil
+				},
+			}
+			r := &healthReconciler{
+				wrapped: reconcile.Func(func(context.Context, reconcile.Request) (reconcile.Result, error) {
+					return reconcile.Result{}, nil
+				}),
+				kube:   kube,
+				log:    logging.NewNopLogger(),
+				record: event.NewNopRecorder(),
+			}
+
+			result, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "default"}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			// When the next check is due depends on how long the test takes.
+			if diff := cmp.Diff(tc.want.result, result, cmp.Comparer(func(a, b time.Duration) bool {
+				return a-b < time.Second && b-a < time.Second
+			})); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want result, +got result:\n%s", tc.reason, diff)
+			}
+			if !tc.want.checked {
+				if got != nil {
+					t.Errorf("\n%s\nr.Reconcile(...): credentials were checked", tc.reason)
+				}
+				return
+			}
+			if got.Status.LastCheckTime == nil {
+				t.Errorf("\n%s\nr.Reconcile(...): last check time was not recorded", tc.reason)
+			}
+			got.Status.LastCheckTime = nil
+			// The messages of API errors include the address of the fake
+			// server, so only the start of the message is compared.
+			ready := got.Status.GetCondition(xpv1.TypeReady)
+			if ready.Reason != tc.want.ready.Reason || !strings.HasPrefix(ready.Message, tc.want.ready.Message) {
+				t.Errorf("\n%s\nr.Reconcile(...): want Ready condition %v, got %v", tc.reason, tc.want.ready, ready)
+			}
+			got.Status.ConditionedStatus = xpv1.ConditionedStatus{}
+			if diff := cmp.Diff(tc.want.status, got.Status); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want status, +got status:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -19,22 +19,51 @@ package controller
 import (
 	ctrl "sigs.k8s.io/controller-runtime"
 
-	"github.com/crossplane/crossplane-runtime/pkg/logging"
-
 	"github.com/hasheddan/kc-provider-github/pkg/controller/config"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/options"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/org/actionsorganizationpermissions"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/org/actionsrunnergroup"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/org/blockeduser"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/org/codeowners"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/org/customrepositoryrole"
 	"github.com/hasheddan/kc-provider-github/pkg/controller/org/membership"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/org/organization"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/org/organizationinvitation"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/org/outsidecollaborator"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/org/repository"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/org/repositoryfile"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/org/securitymanagerteam"
 	"github.com/hasheddan/kc-provider-github/pkg/controller/org/team"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/org/teamidpgroupmapping"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/org/teammembers"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/org/teamrepository"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/org/user"
 )
 
-// Setup creates all Template controllers with the supplied logger and adds them to
-// the supplied manager.
-func Setup(mgr ctrl.Manager, l logging.Logger) error {
-	for _, setup := range []func(ctrl.Manager, logging.Logger) error{
+// Setup creates all Template controllers with the supplied options and adds
+// them to the supplied manager.
+func Setup(mgr ctrl.Manager, o options.Options) error {
+	for _, setup := range []func(ctrl.Manager, options.Options) error{
 		config.Setup,
+		actionsorganizationpermissions.SetupActionsOrganizationPermissions,
+		actionsrunnergroup.SetupActionsRunnerGroup,
+		blockeduser.SetupBlockedUser,
+		codeowners.SetupCodeOwners,
+		customrepositoryrole.SetupCustomRepositoryRole,
 		membership.SetupMembership,
+		organization.SetupOrganization,
+		organizationinvitation.SetupOrganizationInvitation,
+		outsidecollaborator.SetupOutsideCollaborator,
+		repository.SetupRepository,
+		repositoryfile.SetupRepositoryFile,
+		securitymanagerteam.SetupSecurityManagerTeam,
 		team.SetupTeam,
+		teamidpgroupmapping.SetupTeamIdPGroupMapping,
+		teammembers.SetupTeamMembers,
+		teamrepository.SetupTeamRepository,
+		user.SetupUser,
 	} {
-		if err := setup(mgr, l); err != nil {
+		if err := setup(mgr, o); err != nil {
 			return err
 		}
 	}
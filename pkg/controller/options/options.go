@@ -0,0 +1,34 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package options contains the options shared by the GitHub controllers.
+package options
+
+import (
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+
+	"github.com/hasheddan/kc-provider-github/pkg/receiver"
+)
+
+// Options used by the GitHub controllers.
+type Options struct {
+	controller.Options
+
+	// Receiver of GitHub webhook deliveries. Controllers may subscribe to it
+	// in order to be reconciled as soon as GitHub reports a change. It is nil
+	// unless the webhook receiver is enabled.
+	Receiver *receiver.Receiver
+}
@@ -0,0 +1,257 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package actionsorganizationpermissions
+
+import (
+	"context"
+	"sort"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v45/github"
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/hasheddan/kc-provider-github/apis/org/v1beta1"
+	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/options"
+)
+
+const (
+	errNotPermissions      = "managed resource is not an ActionsOrganizationPermissions custom resource"
+	errCreateService       = "failed to create client service"
+	errGetPermissions      = "cannot get Actions permissions"
+	errListEnabledRepos    = "cannot list repositories in which Actions is enabled"
+	errGetSelectedActions  = "cannot get selected actions"
+	errEditPermissions     = "cannot edit Actions permissions"
+	errGetRepos            = "cannot get repositories in which to enable Actions"
+	errSetEnabledRepos     = "cannot set repositories in which Actions is enabled"
+	errEditSelectedActions = "cannot edit selected actions"
+	errCreate              = "Actions permissions cannot be created by the provider, only managed once their organization exists"
+)
+
+// SetupActionsOrganizationPermissions adds a controller that reconciles
+// ActionsOrganizationPermissions managed resources.
+func SetupActionsOrganizationPermissions(mgr ctrl.Manager, o options.Options) error {
+	name := managed.ControllerName(v1beta1.ActionsOrganizationPermissionsGroupKind)
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1beta1.ActionsOrganizationPermissionsGroupVersionKind),
+		managed.WithExternalConnecter(&connector{kube: mgr.GetClient()}),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1beta1.ActionsOrganizationPermissions{}).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect produces an ExternalClient that uses the credentials of the managed
+// resource's ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1beta1.ActionsOrganizationPermissions)
+	if !ok {
+		return nil, errors.New(errNotPermissions)
+	}
+	svc, err := kcgitclient.UseProviderConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errCreateService)
+	}
+	org, err := kcgitclient.Organization(ctx, c.kube, mg, cr.Spec.ForProvider.Org)
+	if err != nil {
+		return nil, err
+	}
+	return &external{service: svc, org: org}, nil
+}
+
+// An ExternalClient observes and updates the GitHub Actions permissions of an
+// organization.
+type external struct {
+	service *github.Client
+
+	// The organization of the managed resource, which may be the default
+	// organization of its ProviderConfig.
+	org string
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1beta1.ActionsOrganizationPermissions)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotPermissions)
+	}
+
+	// Actions permissions are never deleted. Report that they no longer exist
+	// so that the managed resource's finalizer is removed.
+	if meta.WasDeleted(cr) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	p := cr.Spec.ForProvider
+	a, _, err := c.service.Organizations.GetActionsPermissions(ctx, c.org)
+	if kcgitclient.IsNotFound(err) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetPermissions)
+	}
+
+	upToDate := a.GetEnabledRepositories() == p.EnabledRepositories &&
+		(p.AllowedActions == nil || a.GetAllowedActions() == *p.AllowedActions)
+
+	cr.Status.AtProvider = v1beta1.ActionsOrganizationPermissionsObservation{}
+	if a.GetEnabledRepositories() == v1beta1.EnabledRepositoriesSelected {
+		names, ids, err := c.enabledRepos(ctx)
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errListEnabledRepos)
+		}
+		cr.Status.AtProvider.SelectedRepositoryIDs = ids
+		upToDate = upToDate && cmp.Equal(sorted(names), sorted(p.SelectedRepositories))
+	}
+
+	// Selected actions are only readable while only selected actions are
+	// allowed.
+	if selectsActions(p) && a.GetAllowedActions() == v1beta1.AllowedActionsSelected {
+		s, _, err := c.service.Organizations.GetActionsAllowed(ctx, c.org)
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errGetSelectedActions)
+		}
+		upToDate = upToDate && selectedActionsUpToDate(*p.SelectedActions, s)
+	}
+
+	cr.SetConditions(xpv1.Available())
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: upToDate,
+	}, nil
+}
+
+// enabledRepos returns the names and IDs of the repositories in which Actions
+// is enabled.
+func (c *external) enabledRepos(ctx context.Context) ([]string, []int64, error) {
+	var names []string
+	var ids []int64
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		l, rsp, err := c.service.Actions.ListEnabledReposInOrg(ctx, c.org, opts)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, r := range l.Repositories {
+			names = append(names, r.GetName())
+			ids = append(ids, r.GetID())
+		}
+		if rsp.NextPage == 0 {
+			return names, ids, nil
+		}
+		opts.Page = rsp.NextPage
+	}
+}
+
+// selectsActions returns true if the supplied parameters allow only selected
+// actions, and select them.
+func selectsActions(p v1beta1.ActionsOrganizationPermissionsParameters) bool {
+	return p.AllowedActions != nil && *p.AllowedActions == v1beta1.AllowedActionsSelected && p.SelectedActions != nil
+}
+
+// selectedActionsUpToDate returns true if the supplied selected actions match
+// the supplied parameters. The order of their patterns is not significant.
+func selectedActionsUpToDate(p v1beta1.SelectedActions, a *github.ActionsAllowed) bool {
+	if p.GitHubOwnedAllowed != nil && a.GetGithubOwnedAllowed() != *p.GitHubOwnedAllowed {
+		return false
+	}
+	if p.VerifiedAllowed != nil && a.GetVerifiedAllowed() != *p.VerifiedAllowed {
+		return false
+	}
+	return cmp.Equal(sorted(p.PatternsAllowed), sorted(a.PatternsAllowed))
+}
+
+// sorted returns a sorted copy of the supplied strings. It never returns nil.
+func sorted(s []string) []string {
+	out := append([]string{}, s...)
+	sort.Strings(out)
+	return out
+}
+
+func (c *external) Create(_ context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	if _, ok := mg.(*v1beta1.ActionsOrganizationPermissions); !ok {
+		return managed.ExternalCreation{}, errors.New(errNotPermissions)
+	}
+	return managed.ExternalCreation{}, errors.New(errCreate)
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1beta1.ActionsOrganizationPermissions)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotPermissions)
+	}
+	p := cr.Spec.ForProvider
+
+	// Repositories and actions may only be selected once the permissions
+	// allow selecting them.
+	a := github.ActionsPermissions{EnabledRepositories: github.String(p.EnabledRepositories), AllowedActions: p.AllowedActions}
+	if _, _, err := c.service.Organizations.EditActionsPermissions(ctx, c.org, a); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errEditPermissions)
+	}
+
+	if p.EnabledRepositories == v1beta1.EnabledRepositoriesSelected {
+		ids, err := kcgitclient.RepositoryIDs(ctx, c.service, c.org, sorted(p.SelectedRepositories))
+		if err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errGetRepos)
+		}
+		if _, err := c.service.Actions.SetEnabledReposInOrg(ctx, c.org, ids); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errSetEnabledRepos)
+		}
+	}
+
+	if selectsActions(p) {
+		s := github.ActionsAllowed{
+			GithubOwnedAllowed: p.SelectedActions.GitHubOwnedAllowed,
+			VerifiedAllowed:    p.SelectedActions.VerifiedAllowed,
+			PatternsAllowed:    sorted(p.SelectedActions.PatternsAllowed),
+		}
+		if _, _, err := c.service.Organizations.EditActionsAllowed(ctx, c.org, s); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errEditSelectedActions)
+		}
+	}
+
+	return managed.ExternalUpdate{}, nil
+}
+
+// Delete leaves the Actions permissions of the organization as they are; they
+// cannot be deleted.
+func (c *external) Delete(_ context.Context, mg resource.Managed) error {
+	if _, ok := mg.(*v1beta1.ActionsOrganizationPermissions); !ok {
+		return errors.New(errNotPermissions)
+	}
+	return nil
+}
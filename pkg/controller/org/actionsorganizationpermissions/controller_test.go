@@ -0,0 +1,283 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package actionsorganizationpermissions
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/go-github/v45/github"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/hasheddan/kc-provider-github/apis/org/v1beta1"
+	"github.com/hasheddan/kc-provider-github/pkg/client/fake"
+)
+
+const org = "crossplane"
+
+type permissionsModifier func(*v1beta1.ActionsOrganizationPermissions)
+
+func withSelectedRepositories(names ...string) permissionsModifier {
+	return func(cr *v1beta1.ActionsOrganizationPermissions) {
+		cr.Spec.ForProvider.EnabledRepositories = v1beta1.EnabledRepositoriesSelected
+		cr.Spec.ForProvider.SelectedRepositories = names
+	}
+}
+
+func withSelectedActions(a v1beta1.SelectedActions) permissionsModifier {
+	return func(cr *v1beta1.ActionsOrganizationPermissions) {
+		cr.Spec.ForProvider.AllowedActions = github.String(v1beta1.AllowedActionsSelected)
+		cr.Spec.ForProvider.SelectedActions = &a
+	}
+}
+
+func withEnabledRepositories(e string) permissionsModifier {
+	return func(cr *v1beta1.ActionsOrganizationPermissions) { cr.Spec.ForProvider.EnabledRepositories = e }
+}
+
+func withSelectedRepositoryIDs(ids ...int64) permissionsModifier {
+	return func(cr *v1beta1.ActionsOrganizationPermissions) { cr.Status.AtProvider.SelectedRepositoryIDs = ids }
+}
+
+func withDeletionTimestamp() permissionsModifier {
+	return func(cr *v1beta1.ActionsOrganizationPermissions) {
+		cr.SetDeletionTimestamp(&metav1.Time{Time: time.Unix(0, 0)})
+	}
+}
+
+func withConditions(c ...xpv1.Condition) permissionsModifier {
+	return func(cr *v1beta1.ActionsOrganizationPermissions) { cr.SetConditions(c...) }
+}
+
+func permissions(m ...permissionsModifier) *v1beta1.ActionsOrganizationPermissions {
+	cr := &v1beta1.ActionsOrganizationPermissions{
+		ObjectMeta: metav1.ObjectMeta{Name: org},
+		Spec: v1beta1.ActionsOrganizationPermissionsSpec{
+			ResourceSpec: xpv1.ResourceSpec{ProviderConfigReference: &xpv1.Reference{Name: "default"}},
+			ForProvider: v1beta1.ActionsOrganizationPermissionsParameters{
+				Org:                 org,
+				EnabledRepositories: v1beta1.EnabledRepositoriesAll,
+			},
+		},
+	}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+// newServer returns a fake server with an organization, whose Actions
+// permissions are the supplied permissions if any, and two repositories: api,
+// whose ID is 2, and web, whose ID is 3.
+func newServer(a *fake.ActionsPermissions) *fake.Server {
+	s := fake.NewServer()
+	s.AddOrg(org)
+	s.AddRepo(org, "api")
+	s.AddRepo(org, "web")
+	if a != nil {
+		s.SetActionsPermissions(org, *a)
+	}
+	return s
+}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		mg  resource.Managed
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		perms  *fake.ActionsPermissions
+		mg     resource.Managed
+		want   want
+	}{
+		"NotActionsOrganizationPermissions": {
+			reason: "An error should be returned if the managed resource is not an ActionsOrganizationPermissions.",
+			mg:     &v1beta1.Team{},
+			want: want{
+				mg:  &v1beta1.Team{},
+				err: errors.New(errNotPermissions),
+			},
+		},
+		"Deleted": {
+			reason: "Actions permissions whose managed resource was deleted should be reported as not existing.",
+			mg:     permissions(withDeletionTimestamp()),
+			want: want{
+				mg: permissions(withDeletionTimestamp()),
+				o:  managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"UpToDate": {
+			reason: "Actions permissions that match their parameters should be reported as existing, available and up to date.",
+			mg:     permissions(),
+			want: want{
+				mg: permissions(withConditions(xpv1.Available())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"EnabledRepositoriesDiffer": {
+			reason: "Actions permissions that enable Actions in different repositories should be reported as needing an update.",
+			mg:     permissions(withEnabledRepositories(v1beta1.EnabledRepositoriesNone)),
+			want: want{
+				mg: permissions(withEnabledRepositories(v1beta1.EnabledRepositoriesNone), withConditions(xpv1.Available())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+		"SelectedRepositoriesUpToDate": {
+			reason: "Actions permissions that enable Actions in the selected repositories should be reported as up to date.",
+			perms:  &fake.ActionsPermissions{EnabledRepositories: "selected", AllowedActions: "all", SelectedRepositoryIDs: []int64{3, 2}},
+			mg:     permissions(withSelectedRepositories("api", "web")),
+			want: want{
+				mg: permissions(withSelectedRepositories("api", "web"), withSelectedRepositoryIDs(3, 2), withConditions(xpv1.Available())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"SelectedRepositoriesDiffer": {
+			reason: "Actions permissions that enable Actions in other repositories than those selected should be reported as needing an update.",
+			perms:  &fake.ActionsPermissions{EnabledRepositories: "selected", AllowedActions: "all", SelectedRepositoryIDs: []int64{2}},
+			mg:     permissions(withSelectedRepositories("api", "web")),
+			want: want{
+				mg: permissions(withSelectedRepositories("api", "web"), withSelectedRepositoryIDs(2), withConditions(xpv1.Available())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+		"SelectedActionsUpToDate": {
+			reason: "Actions permissions that allow the selected actions should be reported as up to date.",
+			perms: &fake.ActionsPermissions{EnabledRepositories: "all", AllowedActions: "selected", SelectedActions: github.ActionsAllowed{
+				GithubOwnedAllowed: github.Bool(true),
+				PatternsAllowed:    []string{"crossplane/*", "hashicorp/setup-terraform@*"},
+			}},
+			mg: permissions(withSelectedActions(v1beta1.SelectedActions{GitHubOwnedAllowed: github.Bool(true), PatternsAllowed: []string{"hashicorp/setup-terraform@*", "crossplane/*"}})),
+			want: want{
+				mg: permissions(withSelectedActions(v1beta1.SelectedActions{GitHubOwnedAllowed: github.Bool(true), PatternsAllowed: []string{"hashicorp/setup-terraform@*", "crossplane/*"}}), withConditions(xpv1.Available())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"SelectedActionsDiffer": {
+			reason: "Actions permissions that allow other actions than those selected should be reported as needing an update.",
+			perms: &fake.ActionsPermissions{EnabledRepositories: "all", AllowedActions: "selected", SelectedActions: github.ActionsAllowed{
+				PatternsAllowed: []string{"crossplane/*"},
+			}},
+			mg: permissions(withSelectedActions(v1beta1.SelectedActions{PatternsAllowed: []string{"hashicorp/*"}})),
+			want: want{
+				mg: permissions(withSelectedActions(v1beta1.SelectedActions{PatternsAllowed: []string{"hashicorp/*"}}), withConditions(xpv1.Available())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := newServer(tc.perms)
+			defer s.Close()
+
+			got, err := (&external{service: s.Client(), org: org}).Observe(context.Background(), tc.mg)
+			if diff := fake.DiffErrors(tc.want.err, err); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want managed resource, +got managed resource:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	s := newServer(nil)
+	defer s.Close()
+
+	_, err := (&external{service: s.Client(), org: org}).Create(context.Background(), permissions())
+	if diff := cmp.Diff(errors.New(errCreate), err, test.EquateErrors()); diff != "" {
+		t.Errorf("\nActions permissions cannot be created.\ne.Create(...): -want error, +got error:\n%s\n", diff)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		mg     *v1beta1.ActionsOrganizationPermissions
+		want   fake.ActionsPermissions
+		err    error
+	}{
+		"Disabled": {
+			reason: "Actions should be disabled in all repositories.",
+			mg:     permissions(withEnabledRepositories(v1beta1.EnabledRepositoriesNone)),
+			want:   fake.ActionsPermissions{EnabledRepositories: "none", AllowedActions: "all"},
+		},
+		"Selected": {
+			reason: "Actions should be enabled in the selected repositories, which may use only the selected actions.",
+			mg: permissions(
+				withSelectedRepositories("web", "api"),
+				withSelectedActions(v1beta1.SelectedActions{VerifiedAllowed: github.Bool(true), PatternsAllowed: []string{"crossplane/*"}}),
+			),
+			want: fake.ActionsPermissions{
+				EnabledRepositories:   "selected",
+				AllowedActions:        "selected",
+				SelectedActions:       github.ActionsAllowed{VerifiedAllowed: github.Bool(true), PatternsAllowed: []string{"crossplane/*"}},
+				SelectedRepositoryIDs: []int64{2, 3},
+			},
+		},
+		"NoSuchRepository": {
+			reason: "An error should be returned if a selected repository does not exist.",
+			mg:     permissions(withSelectedRepositories("nope")),
+			want:   fake.ActionsPermissions{EnabledRepositories: "selected", AllowedActions: "all"},
+			err:    cmpopts.AnyError,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := newServer(nil)
+			defer s.Close()
+
+			_, err := (&external{service: s.Client(), org: org}).Update(context.Background(), tc.mg)
+			if diff := fake.DiffErrors(tc.err, err); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want, s.ActionsPermissions(org), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want permissions, +got permissions:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	s := newServer(&fake.ActionsPermissions{EnabledRepositories: "none", AllowedActions: "local_only"})
+	defer s.Close()
+
+	if err := (&external{service: s.Client(), org: org}).Delete(context.Background(), permissions()); err != nil {
+		t.Errorf("\nDeleting Actions permissions should succeed.\ne.Delete(...): %s", err)
+	}
+	want := fake.ActionsPermissions{EnabledRepositories: "none", AllowedActions: "local_only"}
+	if diff := cmp.Diff(want, s.ActionsPermissions(org), cmpopts.EquateEmpty()); diff != "" {
+		t.Errorf("\nDeleting Actions permissions should leave them as they are.\ne.Delete(...): -want permissions, +got permissions:\n%s\n", diff)
+	}
+}
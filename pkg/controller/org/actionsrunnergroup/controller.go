@@ -0,0 +1,359 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package actionsrunnergroup
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v45/github"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/hasheddan/kc-provider-github/apis/org/v1beta1"
+	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/options"
+)
+
+const (
+	errNotRunnerGroup    = "managed resource is not an ActionsRunnerGroup custom resource"
+	errCreateService     = "failed to create client service"
+	errListRunnerGroups  = "cannot list runner groups"
+	errGetRunnerGroup    = "cannot get runner group"
+	errListRepoAccess    = "cannot list repositories that may use runner group"
+	errCreateRunnerGroup = "cannot create runner group"
+	errUpdateRunnerGroup = "cannot update runner group"
+	errGetRepos          = "cannot get repositories that may use runner group"
+	errSetRepoAccess     = "cannot set repositories that may use runner group"
+	errDeleteRunnerGroup = "cannot delete runner group"
+	errRegistrationToken = "cannot create runner registration token"
+)
+
+// Keys of the connection details of an ActionsRunnerGroup.
+const (
+	keyToken        = "token"
+	keyExpiresAt    = "expiresAt"
+	keyOrganization = "organization"
+	keyRunnerGroup  = "runnerGroup"
+)
+
+// Registration tokens expire an hour after they are created. A new token is
+// published to the connection secret once the published token expires within
+// tokenRefresh.
+const tokenRefresh = 15 * time.Minute
+
+// SetupActionsRunnerGroup adds a controller that reconciles ActionsRunnerGroup
+// managed resources.
+func SetupActionsRunnerGroup(mgr ctrl.Manager, o options.Options) error {
+	name := managed.ControllerName(v1beta1.ActionsRunnerGroupGroupKind)
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1beta1.ActionsRunnerGroupGroupVersionKind),
+		managed.WithExternalConnecter(&connector{kube: mgr.GetClient()}),
+		// The external name of an ActionsRunnerGroup is the ID GitHub assigns
+		// its runner group, rather than its name.
+		managed.WithInitializers(),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1beta1.ActionsRunnerGroup{}).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect produces an ExternalClient that uses the credentials of the managed
+// resource's ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1beta1.ActionsRunnerGroup)
+	if !ok {
+		return nil, errors.New(errNotRunnerGroup)
+	}
+	svc, err := kcgitclient.UseProviderConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errCreateService)
+	}
+	org, err := kcgitclient.Organization(ctx, c.kube, mg, cr.Spec.ForProvider.Org)
+	if err != nil {
+		return nil, err
+	}
+	return &external{service: svc, org: org}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes a
+// self-hosted runner group of an organization.
+type external struct {
+	service *github.Client
+
+	// The organization of the managed resource, which may be the default
+	// organization of its ProviderConfig.
+	org string
+}
+
+// find the ID of the runner group with the supplied name, or return 0 if
+// there is no such runner group.
+func (c *external) find(ctx context.Context, name string) (int64, error) {
+	opts := &github.ListOrgRunnerGroupOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		l, rsp, err := c.service.Actions.ListOrganizationRunnerGroups(ctx, c.org, opts)
+		if err != nil {
+			return 0, errors.Wrap(err, errListRunnerGroups)
+		}
+		for _, g := range l.RunnerGroups {
+			if g.GetName() == name {
+				return g.GetID(), nil
+			}
+		}
+		if rsp.NextPage == 0 {
+			return 0, nil
+		}
+		opts.Page = rsp.NextPage
+	}
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1beta1.ActionsRunnerGroup)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotRunnerGroup)
+	}
+	p := cr.Spec.ForProvider
+
+	// A runner group that was not yet created by the ActionsRunnerGroup is
+	// adopted if one with its name exists.
+	lateInitialized := false
+	id, err := strconv.ParseInt(meta.GetExternalName(cr), 10, 64)
+	if err != nil {
+		if id, err = c.find(ctx, p.Name); err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		if id == 0 {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		meta.SetExternalName(cr, strconv.FormatInt(id, 10))
+		lateInitialized = true
+	}
+
+	g, err := kcgitclient.GetRunnerGroup(ctx, c.service, c.org, id)
+	if kcgitclient.IsNotFound(err) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetRunnerGroup)
+	}
+	cr.Status.AtProvider.ID = g.GetID()
+
+	upToDate := upToDate(p, g)
+	if upToDate && selectsRepos(p) {
+		names, err := c.repoAccess(ctx, id)
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errListRepoAccess)
+		}
+		upToDate = cmp.Equal(sorted(names), sorted(p.SelectedRepositories))
+	}
+
+	cd, err := c.registrationToken(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errRegistrationToken)
+	}
+
+	cr.SetConditions(xpv1.Available())
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceUpToDate:        upToDate,
+		ResourceLateInitialized: lateInitialized,
+		ConnectionDetails:       cd,
+	}, nil
+}
+
+// repoAccess returns the names of the repositories that may use the supplied
+// runner group.
+func (c *external) repoAccess(ctx context.Context, id int64) ([]string, error) {
+	var names []string
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		l, rsp, err := c.service.Actions.ListRepositoryAccessRunnerGroup(ctx, c.org, id, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range l.Repositories {
+			names = append(names, r.GetName())
+		}
+		if rsp.NextPage == 0 {
+			return names, nil
+		}
+		opts.Page = rsp.NextPage
+	}
+}
+
+// registrationToken returns connection details containing a new runner
+// registration token if the ActionsRunnerGroup has a connection secret, and
+// the token last published to it expires soon. It returns nil otherwise.
+func (c *external) registrationToken(ctx context.Context, cr *v1beta1.ActionsRunnerGroup) (managed.ConnectionDetails, error) {
+	if cr.GetWriteConnectionSecretToReference() == nil {
+		return nil, nil
+	}
+	if exp := cr.Status.AtProvider.RegistrationTokenExpiresAt; exp != nil && time.Until(exp.Time) > tokenRefresh {
+		return nil, nil
+	}
+	t, _, err := c.service.Actions.CreateOrganizationRegistrationToken(ctx, c.org)
+	if err != nil {
+		return nil, err
+	}
+	cr.Status.AtProvider.RegistrationTokenExpiresAt = &metav1.Time{Time: t.GetExpiresAt().Time}
+	return managed.ConnectionDetails{
+		keyToken:        []byte(t.GetToken()),
+		keyExpiresAt:    []byte(t.GetExpiresAt().Format(time.RFC3339)),
+		keyOrganization: []byte(c.org),
+		keyRunnerGroup:  []byte(cr.Spec.ForProvider.Name),
+	}, nil
+}
+
+// upToDate returns true if the supplied runner group matches the supplied
+// parameters, excepting the repositories that may use it. The order of its
+// selected workflows is not significant.
+func upToDate(p v1beta1.ActionsRunnerGroupParameters, g *kcgitclient.RunnerGroup) bool {
+	if g.GetName() != p.Name {
+		return false
+	}
+	if p.Visibility != nil && g.GetVisibility() != *p.Visibility {
+		return false
+	}
+	if p.AllowsPublicRepositories != nil && g.GetAllowsPublicRepositories() != *p.AllowsPublicRepositories {
+		return false
+	}
+	if p.RestrictedToWorkflows == nil {
+		return true
+	}
+	restricted := g.RestrictedToWorkflows != nil && *g.RestrictedToWorkflows
+	if restricted != *p.RestrictedToWorkflows {
+		return false
+	}
+	return !restricted || cmp.Equal(sorted(p.SelectedWorkflows), sorted(g.SelectedWorkflows))
+}
+
+// sorted returns a sorted copy of the supplied strings. It never returns nil.
+func sorted(s []string) []string {
+	out := append([]string{}, s...)
+	sort.Strings(out)
+	return out
+}
+
+// selectsRepos returns true if the supplied parameters select the repositories
+// that may use the runner group.
+func selectsRepos(p v1beta1.ActionsRunnerGroupParameters) bool {
+	return p.Visibility != nil && *p.Visibility == v1beta1.RunnerGroupVisibilitySelected
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1beta1.ActionsRunnerGroup)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotRunnerGroup)
+	}
+	p := cr.Spec.ForProvider
+
+	req := kcgitclient.CreateRunnerGroupRequest{
+		CreateRunnerGroupRequest: github.CreateRunnerGroupRequest{
+			Name:                     github.String(p.Name),
+			Visibility:               p.Visibility,
+			AllowsPublicRepositories: p.AllowsPublicRepositories,
+		},
+		RestrictedToWorkflows: p.RestrictedToWorkflows,
+		SelectedWorkflows:     sorted(p.SelectedWorkflows),
+	}
+	if selectsRepos(p) {
+		ids, err := kcgitclient.RepositoryIDs(ctx, c.service, c.org, sorted(p.SelectedRepositories))
+		if err != nil {
+			return managed.ExternalCreation{}, errors.Wrap(err, errGetRepos)
+		}
+		req.SelectedRepositoryIDs = ids
+	}
+
+	g, err := kcgitclient.CreateRunnerGroup(ctx, c.service, c.org, req)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateRunnerGroup)
+	}
+	meta.SetExternalName(cr, strconv.FormatInt(g.GetID(), 10))
+	return managed.ExternalCreation{}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1beta1.ActionsRunnerGroup)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotRunnerGroup)
+	}
+	p := cr.Spec.ForProvider
+	id, err := strconv.ParseInt(meta.GetExternalName(cr), 10, 64)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateRunnerGroup)
+	}
+
+	req := kcgitclient.UpdateRunnerGroupRequest{
+		UpdateRunnerGroupRequest: github.UpdateRunnerGroupRequest{
+			Name:                     github.String(p.Name),
+			Visibility:               p.Visibility,
+			AllowsPublicRepositories: p.AllowsPublicRepositories,
+		},
+		RestrictedToWorkflows: p.RestrictedToWorkflows,
+		SelectedWorkflows:     sorted(p.SelectedWorkflows),
+	}
+	if _, err := kcgitclient.UpdateRunnerGroup(ctx, c.service, c.org, id, req); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateRunnerGroup)
+	}
+
+	if !selectsRepos(p) {
+		return managed.ExternalUpdate{}, nil
+	}
+	ids, err := kcgitclient.RepositoryIDs(ctx, c.service, c.org, sorted(p.SelectedRepositories))
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errGetRepos)
+	}
+	_, err = c.service.Actions.SetRepositoryAccessRunnerGroup(ctx, c.org, id, github.SetRepoAccessRunnerGroupRequest{SelectedRepositoryIDs: ids})
+	return managed.ExternalUpdate{}, errors.Wrap(err, errSetRepoAccess)
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1beta1.ActionsRunnerGroup)
+	if !ok {
+		return errors.New(errNotRunnerGroup)
+	}
+	id, err := strconv.ParseInt(meta.GetExternalName(cr), 10, 64)
+	if err != nil {
+		return nil
+	}
+	_, err = c.service.Actions.DeleteOrganizationRunnerGroup(ctx, c.org, id)
+	return errors.Wrap(resource.Ignore(kcgitclient.IsNotFound, err), errDeleteRunnerGroup)
+}
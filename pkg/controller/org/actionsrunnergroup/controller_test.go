@@ -0,0 +1,431 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package actionsrunnergroup
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/go-github/v45/github"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/hasheddan/kc-provider-github/apis/org/v1beta1"
+	"github.com/hasheddan/kc-provider-github/pkg/client/fake"
+)
+
+const (
+	org      = "crossplane"
+	workflow = "crossplane/api/.github/workflows/deploy.yaml@main"
+)
+
+type runnerGroupModifier func(*v1beta1.ActionsRunnerGroup)
+
+func withExternalName(n string) runnerGroupModifier {
+	return func(cr *v1beta1.ActionsRunnerGroup) { meta.SetExternalName(cr, n) }
+}
+
+func withSelectedRepositories(names ...string) runnerGroupModifier {
+	return func(cr *v1beta1.ActionsRunnerGroup) {
+		cr.Spec.ForProvider.Visibility = github.String(v1beta1.RunnerGroupVisibilitySelected)
+		cr.Spec.ForProvider.SelectedRepositories = names
+	}
+}
+
+func withSelectedWorkflows(w ...string) runnerGroupModifier {
+	return func(cr *v1beta1.ActionsRunnerGroup) {
+		cr.Spec.ForProvider.RestrictedToWorkflows = github.Bool(true)
+		cr.Spec.ForProvider.SelectedWorkflows = w
+	}
+}
+
+func withConnectionSecret() runnerGroupModifier {
+	return func(cr *v1beta1.ActionsRunnerGroup) {
+		cr.SetWriteConnectionSecretToReference(&xpv1.SecretReference{Namespace: "crossplane-system", Name: "runners"})
+	}
+}
+
+func withTokenExpiresAt(t time.Time) runnerGroupModifier {
+	return func(cr *v1beta1.ActionsRunnerGroup) {
+		cr.Status.AtProvider.RegistrationTokenExpiresAt = &metav1.Time{Time: t}
+	}
+}
+
+func withID(id int64) runnerGroupModifier {
+	return func(cr *v1beta1.ActionsRunnerGroup) { cr.Status.AtProvider.ID = id }
+}
+
+func withConditions(c ...xpv1.Condition) runnerGroupModifier {
+	return func(cr *v1beta1.ActionsRunnerGroup) { cr.SetConditions(c...) }
+}
+
+func runnerGroup(name string, m ...runnerGroupModifier) *v1beta1.ActionsRunnerGroup {
+	cr := &v1beta1.ActionsRunnerGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: v1beta1.ActionsRunnerGroupSpec{
+			ResourceSpec: xpv1.ResourceSpec{ProviderConfigReference: &xpv1.Reference{Name: "default"}},
+			ForProvider:  v1beta1.ActionsRunnerGroupParameters{Org: org, Name: name},
+		},
+	}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+// newServer returns a fake server with an organization, two repositories: api,
+// whose ID is 2, and web, whose ID is 3, and a runner group named deploy,
+// whose ID is 4, which only the deploy workflow of api may use.
+func newServer() *fake.Server {
+	s := fake.NewServer()
+	s.AddOrg(org)
+	s.AddRepo(org, "api")
+	s.AddRepo(org, "web")
+	s.AddRunnerGroup(org, fake.RunnerGroup{
+		Name:                  "deploy",
+		Visibility:            "selected",
+		SelectedRepositoryIDs: []int64{2},
+		RestrictedToWorkflows: true,
+		SelectedWorkflows:     []string{workflow},
+	})
+	return s
+}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		mg  resource.Managed
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		mg     resource.Managed
+		want   want
+	}{
+		"NotActionsRunnerGroup": {
+			reason: "An error should be returned if the managed resource is not an ActionsRunnerGroup.",
+			mg:     &v1beta1.Team{},
+			want: want{
+				mg:  &v1beta1.Team{},
+				err: errors.New(errNotRunnerGroup),
+			},
+		},
+		"NotFound": {
+			reason: "A runner group that does not exist should be reported as not existing.",
+			mg:     runnerGroup("build"),
+			want: want{
+				mg: runnerGroup("build"),
+				o:  managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"Adopted": {
+			reason: "An existing runner group with the name of the ActionsRunnerGroup should be adopted.",
+			mg:     runnerGroup("deploy", withSelectedRepositories("api"), withSelectedWorkflows(workflow)),
+			want: want{
+				mg: runnerGroup("deploy", withSelectedRepositories("api"), withSelectedWorkflows(workflow),
+					withExternalName("4"), withID(4), withConditions(xpv1.Available())),
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ResourceLateInitialized: true},
+			},
+		},
+		"RepositoriesDiffer": {
+			reason: "A runner group that other repositories than those selected may use should be reported as needing an update.",
+			mg:     runnerGroup("deploy", withExternalName("4"), withSelectedRepositories("api", "web")),
+			want: want{
+				mg: runnerGroup("deploy", withExternalName("4"), withSelectedRepositories("api", "web"), withID(4), withConditions(xpv1.Available())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+		"WorkflowsDiffer": {
+			reason: "A runner group that other workflows than those selected may use should be reported as needing an update.",
+			mg:     runnerGroup("deploy", withExternalName("4"), withSelectedWorkflows("crossplane/web/.github/workflows/deploy.yaml@main")),
+			want: want{
+				mg: runnerGroup("deploy", withExternalName("4"), withSelectedWorkflows("crossplane/web/.github/workflows/deploy.yaml@main"), withID(4), withConditions(xpv1.Available())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+		"Unrestricted": {
+			reason: "A runner group that is restricted to workflows should be reported as needing an update if it should not be.",
+			mg: runnerGroup("deploy", withExternalName("4"), func(cr *v1beta1.ActionsRunnerGroup) {
+				cr.Spec.ForProvider.RestrictedToWorkflows = github.Bool(false)
+			}),
+			want: want{
+				mg: runnerGroup("deploy", withExternalName("4"), func(cr *v1beta1.ActionsRunnerGroup) {
+					cr.Spec.ForProvider.RestrictedToWorkflows = github.Bool(false)
+				}, withID(4), withConditions(xpv1.Available())),
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := newServer()
+			defer s.Close()
+
+			got, err := (&external{service: s.Client(), org: org}).Observe(context.Background(), tc.mg)
+			if diff := fake.DiffErrors(tc.want.err, err); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want managed resource, +got managed resource:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestRegistrationToken(t *testing.T) {
+	type want struct {
+		cd      managed.ConnectionDetails
+		created int
+	}
+
+	cases := map[string]struct {
+		reason string
+		mg     *v1beta1.ActionsRunnerGroup
+		want   want
+	}{
+		"NoConnectionSecret": {
+			reason: "No registration token should be created if the ActionsRunnerGroup has no connection secret.",
+			mg:     runnerGroup("deploy", withExternalName("4")),
+		},
+		"NoToken": {
+			reason: "A registration token should be published if none was.",
+			mg:     runnerGroup("deploy", withExternalName("4"), withConnectionSecret()),
+			want: want{
+				cd: managed.ConnectionDetails{
+					keyToken:        []byte("RT_crossplane_1"),
+					keyOrganization: []byte(org),
+					keyRunnerGroup:  []byte("deploy"),
+				},
+				created: 1,
+			},
+		},
+		"TokenExpiresSoon": {
+			reason: "A new registration token should be published if the last one published expires soon.",
+			mg:     runnerGroup("deploy", withExternalName("4"), withConnectionSecret(), withTokenExpiresAt(time.Now().Add(5*time.Minute))),
+			want: want{
+				cd: managed.ConnectionDetails{
+					keyToken:        []byte("RT_crossplane_1"),
+					keyOrganization: []byte(org),
+					keyRunnerGroup:  []byte("deploy"),
+				},
+				created: 1,
+			},
+		},
+		"TokenValid": {
+			reason: "No registration token should be created if the last one published does not expire soon.",
+			mg:     runnerGroup("deploy", withExternalName("4"), withConnectionSecret(), withTokenExpiresAt(time.Now().Add(time.Hour))),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := newServer()
+			defer s.Close()
+
+			before := tc.mg.Status.AtProvider.RegistrationTokenExpiresAt
+			o, err := (&external{service: s.Client(), org: org}).Observe(context.Background(), tc.mg)
+			if err != nil {
+				t.Fatalf("\n%s\ne.Observe(...): %s", tc.reason, err)
+			}
+			// The expiry of the token is set by the fake server.
+			if diff := cmp.Diff(tc.want.cd, o.ConnectionDetails, cmpopts.IgnoreMapEntries(func(k string, _ []byte) bool { return k == keyExpiresAt })); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want connection details, +got connection details:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.created, s.RegistrationTokens(org)); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want registration tokens created, +got:\n%s\n", tc.reason, diff)
+			}
+			if tc.want.created == 0 {
+				if diff := cmp.Diff(before, tc.mg.Status.AtProvider.RegistrationTokenExpiresAt); diff != "" {
+					t.Errorf("\n%s\ne.Observe(...): -want token expiry, +got token expiry:\n%s\n", tc.reason, diff)
+				}
+				return
+			}
+			exp := tc.mg.Status.AtProvider.RegistrationTokenExpiresAt
+			if exp == nil || string(o.ConnectionDetails[keyExpiresAt]) != exp.Format(time.RFC3339) {
+				t.Errorf("\n%s\ne.Observe(...): published token expiry %q does not match status %v", tc.reason, o.ConnectionDetails[keyExpiresAt], exp)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	type want struct {
+		externalName string
+		group        fake.RunnerGroup
+		err          error
+	}
+
+	cases := map[string]struct {
+		reason string
+		mg     *v1beta1.ActionsRunnerGroup
+		want   want
+	}{
+		"Created": {
+			reason: "A runner group should be created that only the selected workflows of the selected repositories may use.",
+			mg:     runnerGroup("build", withSelectedRepositories("web", "api"), withSelectedWorkflows(workflow)),
+			want: want{
+				externalName: "5",
+				group: fake.RunnerGroup{
+					ID:                    5,
+					Name:                  "build",
+					Visibility:            "selected",
+					SelectedRepositoryIDs: []int64{2, 3},
+					RestrictedToWorkflows: true,
+					SelectedWorkflows:     []string{workflow},
+				},
+			},
+		},
+		"AlreadyExists": {
+			reason: "An error should be returned if a runner group with the same name exists.",
+			mg:     runnerGroup("deploy"),
+			want:   want{err: cmpopts.AnyError},
+		},
+		"NoSuchRepository": {
+			reason: "An error should be returned if a selected repository does not exist.",
+			mg:     runnerGroup("build", withSelectedRepositories("nope")),
+			want:   want{err: cmpopts.AnyError},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := newServer()
+			defer s.Close()
+
+			_, err := (&external{service: s.Client(), org: org}).Create(context.Background(), tc.mg)
+			if diff := fake.DiffErrors(tc.want.err, err); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.externalName, meta.GetExternalName(tc.mg)); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want external name, +got external name:\n%s\n", tc.reason, diff)
+			}
+			if tc.want.err != nil {
+				return
+			}
+			g, _ := s.RunnerGroup(org, tc.want.group.ID)
+			if diff := cmp.Diff(tc.want.group, g); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want runner group, +got runner group:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		mg     *v1beta1.ActionsRunnerGroup
+		want   fake.RunnerGroup
+		err    error
+	}{
+		"Updated": {
+			reason: "The runner group should be updated so that any workflow of the selected repositories may use it.",
+			mg: runnerGroup("deploy", withExternalName("4"), withSelectedRepositories("api", "web"), func(cr *v1beta1.ActionsRunnerGroup) {
+				cr.Spec.ForProvider.RestrictedToWorkflows = github.Bool(false)
+				cr.Spec.ForProvider.AllowsPublicRepositories = github.Bool(true)
+			}),
+			want: fake.RunnerGroup{
+				ID:                       4,
+				Name:                     "deploy",
+				Visibility:               "selected",
+				SelectedRepositoryIDs:    []int64{2, 3},
+				AllowsPublicRepositories: true,
+			},
+		},
+		"AllRepositories": {
+			reason: "The runner group should be updated so that all repositories may use it.",
+			mg: runnerGroup("deploy", withExternalName("4"), func(cr *v1beta1.ActionsRunnerGroup) {
+				cr.Spec.ForProvider.Visibility = github.String(v1beta1.RunnerGroupVisibilityAll)
+			}),
+			want: fake.RunnerGroup{
+				ID:                    4,
+				Name:                  "deploy",
+				Visibility:            "all",
+				SelectedRepositoryIDs: []int64{2},
+				RestrictedToWorkflows: true,
+				SelectedWorkflows:     []string{workflow},
+			},
+		},
+		"NotFound": {
+			reason: "An error should be returned if the runner group does not exist.",
+			mg:     runnerGroup("build", withExternalName("42")),
+			err:    cmpopts.AnyError,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := newServer()
+			defer s.Close()
+
+			_, err := (&external{service: s.Client(), org: org}).Update(context.Background(), tc.mg)
+			if diff := fake.DiffErrors(tc.err, err); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if tc.err != nil {
+				return
+			}
+			g, _ := s.RunnerGroup(org, tc.want.ID)
+			if diff := cmp.Diff(tc.want, g, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want runner group, +got runner group:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		mg     *v1beta1.ActionsRunnerGroup
+	}{
+		"Deleted": {
+			reason: "The runner group should be deleted.",
+			mg:     runnerGroup("deploy", withExternalName("4")),
+		},
+		"NotFound": {
+			reason: "No error should be returned if the runner group no longer exists.",
+			mg:     runnerGroup("build", withExternalName("42")),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := newServer()
+			defer s.Close()
+
+			if err := (&external{service: s.Client(), org: org}).Delete(context.Background(), tc.mg); err != nil {
+				t.Errorf("\n%s\ne.Delete(...): %s", tc.reason, err)
+			}
+			if _, ok := s.RunnerGroup(org, 4); ok && meta.GetExternalName(tc.mg) == "4" {
+				t.Errorf("\n%s\ne.Delete(...): runner group still exists", tc.reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package blockeduser
+
+import (
+	"context"
+
+	"github.com/google/go-github/v45/github"
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/hasheddan/kc-provider-github/apis/org/v1beta1"
+	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/options"
+)
+
+const (
+	errNotBlockedUser = "managed resource is not a BlockedUser custom resource"
+	errCreateService  = "failed to create client service"
+	errCheck          = "cannot check whether user is blocked"
+	errBlock          = "cannot block user"
+	errUnblock        = "cannot unblock user"
+)
+
+// SetupBlockedUser adds a controller that reconciles BlockedUser managed
+// resources.
+func SetupBlockedUser(mgr ctrl.Manager, o options.Options) error {
+	name := managed.ControllerName(v1beta1.BlockedUserGroupKind)
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1beta1.BlockedUserGroupVersionKind),
+		managed.WithExternalConnecter(&connector{kube: mgr.GetClient()}),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1beta1.BlockedUser{}).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect produces an ExternalClient that uses the credentials of the managed
+// resource's ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1beta1.BlockedUser)
+	if !ok {
+		return nil, errors.New(errNotBlockedUser)
+	}
+	svc, err := kcgitclient.UseProviderConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errCreateService)
+	}
+	org, err := kcgitclient.Organization(ctx, c.kube, mg, cr.Spec.ForProvider.Org)
+	if err != nil {
+		return nil, err
+	}
+	return &external{service: svc, org: org}, nil
+}
+
+// An ExternalClient blocks and unblocks a user from an organization.
+type external struct {
+	service *github.Client
+
+	// The organization of the managed resource, which may be the default
+	// organization of its ProviderConfig.
+	org string
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1beta1.BlockedUser)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotBlockedUser)
+	}
+
+	blocked, _, err := c.service.Organizations.IsBlocked(ctx, c.org, cr.Spec.ForProvider.User)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errCheck)
+	}
+	if !blocked {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	cr.SetConditions(xpv1.Available())
+	return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+}
+
+// Create blocks the user. Blocking a user removes them from the organization,
+// its teams, and its repositories, and cancels any invitation to it.
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1beta1.BlockedUser)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotBlockedUser)
+	}
+	_, err := c.service.Organizations.BlockUser(ctx, c.org, cr.Spec.ForProvider.User)
+	return managed.ExternalCreation{}, errors.Wrap(err, errBlock)
+}
+
+// Update is never called, since a blocked user is always up to date.
+func (c *external) Update(_ context.Context, _ resource.Managed) (managed.ExternalUpdate, error) {
+	return managed.ExternalUpdate{}, nil
+}
+
+// Delete unblocks the user. Unblocking a user does not restore the access they
+// lost when they were blocked.
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1beta1.BlockedUser)
+	if !ok {
+		return errors.New(errNotBlockedUser)
+	}
+	_, err := c.service.Organizations.UnblockUser(ctx, c.org, cr.Spec.ForProvider.User)
+	return errors.Wrap(resource.Ignore(kcgitclient.IsNotFound, err), errUnblock)
+}
@@ -0,0 +1,172 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package blockeduser
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/hasheddan/kc-provider-github/apis/org/v1beta1"
+	"github.com/hasheddan/kc-provider-github/pkg/client/fake"
+)
+
+const org = "crossplane"
+
+func blocked(user string, c ...xpv1.Condition) *v1beta1.BlockedUser {
+	cr := &v1beta1.BlockedUser{
+		ObjectMeta: metav1.ObjectMeta{Name: user},
+		Spec: v1beta1.BlockedUserSpec{
+			ResourceSpec: xpv1.ResourceSpec{ProviderConfigReference: &xpv1.Reference{Name: "default"}},
+			ForProvider:  v1beta1.BlockedUserParameters{Org: org, User: user},
+		},
+	}
+	cr.SetConditions(c...)
+	return cr
+}
+
+// newServer returns a fake server with an organization, a member whose login
+// is member, and a blocked user whose login is troll.
+func newServer() *fake.Server {
+	s := fake.NewServer()
+	s.AddOrg(org)
+	s.AddOrgMember(org, "member", "member")
+	s.BlockUser(org, "troll")
+	return s
+}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		mg  resource.Managed
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		mg     resource.Managed
+		want   want
+	}{
+		"NotBlockedUser": {
+			reason: "An error should be returned if the managed resource is not a BlockedUser.",
+			mg:     &v1beta1.Team{},
+			want: want{
+				mg:  &v1beta1.Team{},
+				err: errors.New(errNotBlockedUser),
+			},
+		},
+		"NotBlocked": {
+			reason: "A user who is not blocked should be reported as not existing.",
+			mg:     blocked("member"),
+			want: want{
+				mg: blocked("member"),
+				o:  managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"Blocked": {
+			reason: "A blocked user should be reported as existing and available.",
+			mg:     blocked("troll"),
+			want: want{
+				mg: blocked("troll", xpv1.Available()),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := newServer()
+			defer s.Close()
+
+			got, err := (&external{service: s.Client(), org: org}).Observe(context.Background(), tc.mg)
+			if diff := fake.DiffErrors(tc.want.err, err); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want managed resource, +got managed resource:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		user   string
+		err    error
+	}{
+		"Member": {
+			reason: "A member should be blocked, and removed from the organization.",
+			user:   "member",
+		},
+		"AlreadyBlocked": {
+			reason: "An error should be returned if the user is already blocked.",
+			user:   "troll",
+			err:    cmpopts.AnyError,
+		},
+		"NoSuchUser": {
+			reason: "An error should be returned if the user does not exist.",
+			user:   "nope",
+			err:    cmpopts.AnyError,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := newServer()
+			defer s.Close()
+
+			_, err := (&external{service: s.Client(), org: org}).Create(context.Background(), blocked(tc.user))
+			if diff := fake.DiffErrors(tc.err, err); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if tc.err != nil {
+				return
+			}
+			if !s.IsBlocked(org, tc.user) {
+				t.Errorf("\n%s\ne.Create(...): user is not blocked", tc.reason)
+			}
+			if _, ok := s.OrgMembership(org, tc.user); ok {
+				t.Errorf("\n%s\ne.Create(...): user is still a member of the organization", tc.reason)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	s := newServer()
+	defer s.Close()
+
+	if err := (&external{service: s.Client(), org: org}).Delete(context.Background(), blocked("troll")); err != nil {
+		t.Fatal(err)
+	}
+	if s.IsBlocked(org, "troll") {
+		t.Errorf("e.Delete(...): user is still blocked")
+	}
+}
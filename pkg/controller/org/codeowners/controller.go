@@ -0,0 +1,262 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package codeowners
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/google/go-github/v45/github"
+	"github.com/pkg/errors"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/hasheddan/kc-provider-github/apis/org/v1beta1"
+	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/options"
+)
+
+const (
+	errNotCodeOwners = "managed resource is not a CodeOwners custom resource"
+	errCreateService = "failed to create client service"
+	errGetFile       = "cannot get CODEOWNERS file"
+	errNotFile       = "CODEOWNERS path is not a file"
+	errListErrors    = "cannot list CODEOWNERS errors"
+	errCreateFile    = "cannot create CODEOWNERS file"
+	errUpdateFile    = "cannot update CODEOWNERS file"
+	errDeleteFile    = "cannot delete CODEOWNERS file"
+)
+
+// DefaultPath is the path of a CODEOWNERS file, unless another is specified.
+const DefaultPath = ".github/CODEOWNERS"
+
+// header is the first line of a rendered CODEOWNERS file.
+const header = "# Generated by Crossplane from a CodeOwners resource. Changes made to this file will be overwritten.\n"
+
+// SetupCodeOwners adds a controller that reconciles CodeOwners managed
+// resources.
+func SetupCodeOwners(mgr ctrl.Manager, o options.Options) error {
+	name := managed.ControllerName(v1beta1.CodeOwnersGroupKind)
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1beta1.CodeOwnersGroupVersionKind),
+		managed.WithExternalConnecter(&connector{kube: mgr.GetClient()}),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1beta1.CodeOwners{}).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect produces an ExternalClient that uses the credentials of the managed
+// resource's ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1beta1.CodeOwners)
+	if !ok {
+		return nil, errors.New(errNotCodeOwners)
+	}
+	svc, err := kcgitclient.UseProviderConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errCreateService)
+	}
+	org, err := kcgitclient.Organization(ctx, c.kube, mg, cr.Spec.ForProvider.Org)
+	if err != nil {
+		return nil, err
+	}
+	return &external{service: svc, org: org}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes the
+// CODEOWNERS file of a repository.
+type external struct {
+	service *github.Client
+
+	// The organization of the managed resource, which may be the default
+	// organization of its ProviderConfig.
+	org string
+}
+
+// render returns the content of a CODEOWNERS file with the supplied rules.
+// Teams are named by their slug within the supplied organization. The owners
+// of each rule are sorted, since their order is not significant.
+func render(org string, rules []v1beta1.CodeOwnersRule) string {
+	b := &strings.Builder{}
+	b.WriteString(header)
+	for _, r := range rules {
+		teams := make([]string, 0, len(r.Teams))
+		for _, t := range r.Teams {
+			teams = append(teams, "@"+org+"/"+t)
+		}
+		users := make([]string, 0, len(r.Users))
+		for _, u := range r.Users {
+			users = append(users, "@"+u)
+		}
+		sort.Strings(teams)
+		sort.Strings(users)
+		b.WriteString(strings.Join(append(append([]string{r.Pattern}, teams...), users...), " "))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1beta1.CodeOwners)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotCodeOwners)
+	}
+
+	p := cr.Spec.ForProvider
+	branch := pointer.StringDeref(p.Branch, "")
+	f, _, _, err := c.service.Repositories.GetContents(ctx, c.org, p.Repository, pointer.StringDeref(p.Path, DefaultPath), &github.RepositoryContentGetOptions{Ref: branch})
+	if kcgitclient.IsNotFound(err) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetFile)
+	}
+	if f == nil {
+		return managed.ExternalObservation{}, errors.New(errNotFile)
+	}
+
+	errs, err := kcgitclient.ListCodeOwnersErrors(ctx, c.service, c.org, p.Repository, branch)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errListErrors)
+	}
+
+	cr.Status.AtProvider.SHA = f.GetSHA()
+	cr.Status.AtProvider.Errors = nil
+	msgs := make([]string, len(errs))
+	for i, e := range errs {
+		// GitHub's messages quote the offending line after a blank line.
+		msg := strings.SplitN(e.Message, "\n", 2)[0]
+		cr.Status.AtProvider.Errors = append(cr.Status.AtProvider.Errors, v1beta1.CodeOwnersError{Line: e.Line, Kind: e.Kind, Message: msg})
+		msgs[i] = msg
+	}
+	if len(errs) > 0 {
+		cr.SetConditions(v1beta1.InvalidOwners().WithMessage(strings.Join(msgs, "; ")))
+	} else {
+		cr.SetConditions(xpv1.Available())
+	}
+
+	// Files are compared by their blob SHA, so their content need not be
+	// decoded.
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: f.GetSHA() == kcgitclient.BlobSHA([]byte(render(c.org, p.Rules))),
+	}, nil
+}
+
+// fileOptions returns the options with which to commit the supplied
+// CodeOwners' CODEOWNERS file, with the supplied default message.
+func (c *external) fileOptions(p v1beta1.CodeOwnersParameters, message string) *github.RepositoryContentFileOptions {
+	o := &github.RepositoryContentFileOptions{
+		Message: github.String(pointer.StringDeref(p.CommitMessage, message)),
+		Content: []byte(render(c.org, p.Rules)),
+		Branch:  p.Branch,
+	}
+	if a := p.CommitAuthor; a != nil {
+		o.Author = &github.CommitAuthor{Name: github.String(a.Name), Email: github.String(a.Email)}
+	}
+	return o
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1beta1.CodeOwners)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotCodeOwners)
+	}
+
+	p := cr.Spec.ForProvider
+	path := pointer.StringDeref(p.Path, DefaultPath)
+	rsp, _, err := c.service.Repositories.CreateFile(ctx, c.org, p.Repository, path, c.fileOptions(p, "Create "+path))
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateFile)
+	}
+	cr.Status.AtProvider.SHA = rsp.GetContent().GetSHA()
+	cr.Status.AtProvider.CommitSHA = rsp.Commit.GetSHA()
+	return managed.ExternalCreation{}, nil
+}
+
+// Update overwrites the CODEOWNERS file, including any changes made to it
+// outside the provider.
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1beta1.CodeOwners)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotCodeOwners)
+	}
+
+	p := cr.Spec.ForProvider
+	path := pointer.StringDeref(p.Path, DefaultPath)
+	o := c.fileOptions(p, "Update "+path)
+	o.SHA = github.String(cr.Status.AtProvider.SHA)
+	rsp, _, err := c.service.Repositories.UpdateFile(ctx, c.org, p.Repository, path, o)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateFile)
+	}
+	cr.Status.AtProvider.SHA = rsp.GetContent().GetSHA()
+	cr.Status.AtProvider.CommitSHA = rsp.Commit.GetSHA()
+	return managed.ExternalUpdate{}, nil
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1beta1.CodeOwners)
+	if !ok {
+		return errors.New(errNotCodeOwners)
+	}
+
+	p := cr.Spec.ForProvider
+	path := pointer.StringDeref(p.Path, DefaultPath)
+	sha := cr.Status.AtProvider.SHA
+	if sha == "" {
+		// The file was not observed, so its SHA is read.
+		f, _, _, err := c.service.Repositories.GetContents(ctx, c.org, p.Repository, path, &github.RepositoryContentGetOptions{Ref: pointer.StringDeref(p.Branch, "")})
+		if kcgitclient.IsNotFound(err) {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrap(err, errGetFile)
+		}
+		if f == nil {
+			return errors.New(errNotFile)
+		}
+		sha = f.GetSHA()
+	}
+	o := c.fileOptions(p, "")
+	o.Message = github.String("Delete " + path)
+	o.Content = nil
+	o.SHA = github.String(sha)
+	_, _, err := c.service.Repositories.DeleteFile(ctx, c.org, p.Repository, path, o)
+	return errors.Wrap(resource.Ignore(kcgitclient.IsNotFound, err), errDeleteFile)
+}
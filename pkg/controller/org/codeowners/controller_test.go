@@ -0,0 +1,283 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package codeowners
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v45/github"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/hasheddan/kc-provider-github/apis/org/v1beta1"
+	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
+	"github.com/hasheddan/kc-provider-github/pkg/client/fake"
+)
+
+const (
+	org  = "crossplane"
+	repo = "infra"
+)
+
+var rules = []v1beta1.CodeOwnersRule{
+	{Pattern: "*", Teams: []string{"platform"}},
+	{Pattern: "/docs/", Teams: []string{"platform"}, Users: []string{"hubot"}},
+}
+
+type codeOwnersModifier func(*v1beta1.CodeOwners)
+
+func withRules(r ...v1beta1.CodeOwnersRule) codeOwnersModifier {
+	return func(cr *v1beta1.CodeOwners) { cr.Spec.ForProvider.Rules = r }
+}
+
+func withObservation(o v1beta1.CodeOwnersObservation) codeOwnersModifier {
+	return func(cr *v1beta1.CodeOwners) { cr.Status.AtProvider = o }
+}
+
+func withConditions(c ...xpv1.Condition) codeOwnersModifier {
+	return func(cr *v1beta1.CodeOwners) { cr.SetConditions(c...) }
+}
+
+func codeOwners(m ...codeOwnersModifier) *v1beta1.CodeOwners {
+	cr := &v1beta1.CodeOwners{
+		ObjectMeta: metav1.ObjectMeta{Name: "infra"},
+		Spec: v1beta1.CodeOwnersSpec{
+			ResourceSpec: xpv1.ResourceSpec{ProviderConfigReference: &xpv1.Reference{Name: "default"}},
+			ForProvider: v1beta1.CodeOwnersParameters{
+				Org:        org,
+				Repository: repo,
+				Rules:      rules,
+			},
+		},
+	}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+// newServer returns a fake server with an organization, a repository, a member
+// whose login is hubot, a team named platform with write access to the
+// repository, and a team named docs with read access to it.
+func newServer() *fake.Server {
+	s := fake.NewServer()
+	s.AddOrg(org)
+	s.AddRepo(org, repo)
+	s.AddOrgMember(org, "hubot", "member")
+	s.AddTeam(org, github.NewTeam{Name: "platform"})
+	s.AddTeam(org, github.NewTeam{Name: "docs"})
+	s.AddTeamRepo(org, "platform", repo, "push")
+	s.AddTeamRepo(org, "docs", repo, "pull")
+	return s
+}
+
+func TestRender(t *testing.T) {
+	r := []v1beta1.CodeOwnersRule{
+		{Pattern: "*", Teams: []string{"platform", "docs"}, Users: []string{"hubot", "bender"}},
+		{Pattern: "/vendor/"},
+	}
+	want := header +
+		"* @crossplane/docs @crossplane/platform @bender @hubot\n" +
+		"/vendor/\n"
+	if diff := cmp.Diff(want, render(org, r)); diff != "" {
+		t.Errorf("render(...): -want, +got:\n%s\n", diff)
+	}
+}
+
+func TestObserve(t *testing.T) {
+	type args struct {
+		server func(s *fake.Server)
+		mg     resource.Managed
+	}
+	type want struct {
+		mg  resource.Managed
+		o   managed.ExternalObservation
+		err error
+	}
+
+	rendered := render(org, rules)
+	sha := kcgitclient.BlobSHA([]byte(rendered))
+	commit := func(c string) func(s *fake.Server) {
+		return func(s *fake.Server) { s.CommitFile(org, repo, fake.DefaultBranch, DefaultPath, c) }
+	}
+	invalid := []v1beta1.CodeOwnersRule{{Pattern: "/docs/", Teams: []string{"docs"}}}
+	invalidSHA := kcgitclient.BlobSHA([]byte(render(org, invalid)))
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NotCodeOwners": {
+			reason: "An error should be returned if the managed resource is not a CodeOwners.",
+			args: args{
+				mg: &v1beta1.Team{},
+			},
+			want: want{
+				mg:  &v1beta1.Team{},
+				err: errors.New(errNotCodeOwners),
+			},
+		},
+		"NotFound": {
+			reason: "A CODEOWNERS file that does not exist should be reported as not existing.",
+			args: args{
+				mg: codeOwners(),
+			},
+			want: want{
+				mg: codeOwners(),
+				o:  managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"UpToDate": {
+			reason: "A CODEOWNERS file with the rendered rules and valid owners should be reported as up to date and available.",
+			args: args{
+				server: commit(rendered),
+				mg:     codeOwners(),
+			},
+			want: want{
+				mg: codeOwners(withObservation(v1beta1.CodeOwnersObservation{SHA: sha}), withConditions(xpv1.Available())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"Drifted": {
+			reason: "A CODEOWNERS file that differs from the rendered rules should be reported as not up to date.",
+			args: args{
+				server: commit("* @hubot\n"),
+				mg:     codeOwners(),
+			},
+			want: want{
+				mg: codeOwners(withObservation(v1beta1.CodeOwnersObservation{SHA: kcgitclient.BlobSHA([]byte("* @hubot\n"))}), withConditions(xpv1.Available())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+		"InvalidOwners": {
+			reason: "Owners that lack write access to the repository should be reported as errors, and the CodeOwners should not be ready.",
+			args: args{
+				server: commit(render(org, invalid)),
+				mg:     codeOwners(withRules(invalid...)),
+			},
+			want: want{
+				mg: codeOwners(withRules(invalid...), withObservation(v1beta1.CodeOwnersObservation{
+					SHA: invalidSHA,
+					Errors: []v1beta1.CodeOwnersError{{
+						Line:    2,
+						Kind:    "Unknown owner",
+						Message: "Unknown owner on line 2: make sure @crossplane/docs exists and has write access to the repository",
+					}},
+				}), withConditions(v1beta1.InvalidOwners().WithMessage("Unknown owner on line 2: make sure @crossplane/docs exists and has write access to the repository"))),
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := newServer()
+			defer s.Close()
+			if tc.args.server != nil {
+				tc.args.server(s)
+			}
+
+			got, err := (&external{service: s.Client(), org: org}).Observe(context.Background(), tc.args.mg)
+			if diff := fake.DiffErrors(tc.want.err, err); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.args.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want managed resource, +got managed resource:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	s := newServer()
+	defer s.Close()
+
+	cr := codeOwners()
+	if _, err := (&external{service: s.Client(), org: org}).Create(context.Background(), cr); err != nil {
+		t.Fatal(err)
+	}
+
+	got, _ := s.File(org, repo, fake.DefaultBranch, DefaultPath)
+	want := fake.RepoFile{Content: render(org, rules), SHA: kcgitclient.BlobSHA([]byte(render(org, rules))), Message: "Create " + DefaultPath}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("e.Create(...): -want file, +got file:\n%s\n", diff)
+	}
+	if cr.Status.AtProvider.SHA != want.SHA || cr.Status.AtProvider.CommitSHA == "" {
+		t.Errorf("e.Create(...): should record the committed file: got %+v", cr.Status.AtProvider)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	s := newServer()
+	defer s.Close()
+	sha := s.CommitFile(org, repo, fake.DefaultBranch, DefaultPath, "* @hubot\n")
+
+	cr := codeOwners(withObservation(v1beta1.CodeOwnersObservation{SHA: sha}))
+	if _, err := (&external{service: s.Client(), org: org}).Update(context.Background(), cr); err != nil {
+		t.Fatal(err)
+	}
+
+	got, _ := s.File(org, repo, fake.DefaultBranch, DefaultPath)
+	if diff := cmp.Diff(render(org, rules), got.Content); diff != "" {
+		t.Errorf("e.Update(...): -want content, +got content:\n%s\n", diff)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason   string
+		observed bool
+	}{
+		"Deleted": {
+			reason:   "The CODEOWNERS file should be deleted.",
+			observed: true,
+		},
+		"NotObserved": {
+			reason: "A CODEOWNERS file that was never observed should be read, then deleted.",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := newServer()
+			defer s.Close()
+			sha := s.CommitFile(org, repo, fake.DefaultBranch, DefaultPath, render(org, rules))
+			if !tc.observed {
+				sha = ""
+			}
+
+			cr := codeOwners(withObservation(v1beta1.CodeOwnersObservation{SHA: sha}))
+			if err := (&external{service: s.Client(), org: org}).Delete(context.Background(), cr); err != nil {
+				t.Errorf("\n%s\ne.Delete(...): %s", tc.reason, err)
+			}
+			if _, ok := s.File(org, repo, fake.DefaultBranch, DefaultPath); ok {
+				t.Errorf("\n%s\ne.Delete(...): CODEOWNERS file still exists", tc.reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,229 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package customrepositoryrole
+
+import (
+	"context"
+	"sort"
+	"strconv"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v45/github"
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/hasheddan/kc-provider-github/apis/org/v1beta1"
+	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/options"
+)
+
+const (
+	errNotRole       = "managed resource is not a CustomRepositoryRole custom resource"
+	errCreateService = "failed to create client service"
+	errListRoles     = "cannot list custom repository roles"
+	errGetRole       = "cannot get custom repository role"
+	errCreateRole    = "cannot create custom repository role"
+	errEditRole      = "cannot edit custom repository role"
+	errDeleteRole    = "cannot delete custom repository role"
+)
+
+// SetupCustomRepositoryRole adds a controller that reconciles
+// CustomRepositoryRole managed resources.
+func SetupCustomRepositoryRole(mgr ctrl.Manager, o options.Options) error {
+	name := managed.ControllerName(v1beta1.CustomRepositoryRoleGroupKind)
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1beta1.CustomRepositoryRoleGroupVersionKind),
+		managed.WithExternalConnecter(&connector{kube: mgr.GetClient()}),
+		// The external name of a CustomRepositoryRole is the ID GitHub assigns
+		// its role, rather than its name.
+		managed.WithInitializers(),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1beta1.CustomRepositoryRole{}).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect produces an ExternalClient that uses the credentials of the managed
+// resource's ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1beta1.CustomRepositoryRole)
+	if !ok {
+		return nil, errors.New(errNotRole)
+	}
+	svc, err := kcgitclient.UseProviderConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errCreateService)
+	}
+	org, err := kcgitclient.Organization(ctx, c.kube, mg, cr.Spec.ForProvider.Org)
+	if err != nil {
+		return nil, err
+	}
+	return &external{service: svc, org: org}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes a
+// custom repository role of an organization.
+type external struct {
+	service *github.Client
+
+	// The organization of the managed resource, which may be the default
+	// organization of its ProviderConfig.
+	org string
+}
+
+// find the ID of the role with the supplied name, or return 0 if there is no
+// such role.
+func (c *external) find(ctx context.Context, name string) (int64, error) {
+	l, err := kcgitclient.ListCustomRepoRoles(ctx, c.service, c.org)
+	if err != nil {
+		return 0, errors.Wrap(err, errListRoles)
+	}
+	for _, r := range l {
+		if r.Name == name {
+			return r.ID, nil
+		}
+	}
+	return 0, nil
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1beta1.CustomRepositoryRole)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotRole)
+	}
+
+	// A role that was not yet created by the CustomRepositoryRole is adopted
+	// if one with its name exists.
+	lateInitialized := false
+	id, err := strconv.ParseInt(meta.GetExternalName(cr), 10, 64)
+	if err != nil {
+		if id, err = c.find(ctx, cr.Spec.ForProvider.Name); err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		if id == 0 {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		meta.SetExternalName(cr, strconv.FormatInt(id, 10))
+		lateInitialized = true
+	}
+
+	r, err := kcgitclient.GetCustomRepoRole(ctx, c.service, c.org, id)
+	if kcgitclient.IsNotFound(err) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetRole)
+	}
+
+	cr.Status.AtProvider.ID = r.ID
+	cr.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceUpToDate:        upToDate(cr.Spec.ForProvider, r),
+		ResourceLateInitialized: lateInitialized,
+	}, nil
+}
+
+// upToDate returns true if the supplied role matches the supplied parameters.
+// The order of its permissions is not significant.
+func upToDate(p v1beta1.CustomRepositoryRoleParameters, r *kcgitclient.CustomRepoRole) bool {
+	if r.Name != p.Name || r.BaseRole != p.BaseRole {
+		return false
+	}
+	if p.Description != nil && r.Description != *p.Description {
+		return false
+	}
+	return cmp.Equal(sorted(p.Permissions), sorted(r.Permissions))
+}
+
+// sorted returns a sorted copy of the supplied strings. It never returns nil.
+func sorted(s []string) []string {
+	out := append([]string{}, s...)
+	sort.Strings(out)
+	return out
+}
+
+// roleOptions returns the options with which to create or edit the role of the
+// supplied CustomRepositoryRole.
+func roleOptions(p v1beta1.CustomRepositoryRoleParameters) kcgitclient.CustomRepoRoleOptions {
+	return kcgitclient.CustomRepoRoleOptions{
+		Name:        p.Name,
+		Description: p.Description,
+		BaseRole:    p.BaseRole,
+		Permissions: sorted(p.Permissions),
+	}
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1beta1.CustomRepositoryRole)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotRole)
+	}
+	r, err := kcgitclient.CreateCustomRepoRole(ctx, c.service, c.org, roleOptions(cr.Spec.ForProvider))
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateRole)
+	}
+	meta.SetExternalName(cr, strconv.FormatInt(r.ID, 10))
+	return managed.ExternalCreation{}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1beta1.CustomRepositoryRole)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotRole)
+	}
+	id, err := strconv.ParseInt(meta.GetExternalName(cr), 10, 64)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errEditRole)
+	}
+	_, err = kcgitclient.EditCustomRepoRole(ctx, c.service, c.org, id, roleOptions(cr.Spec.ForProvider))
+	return managed.ExternalUpdate{}, errors.Wrap(err, errEditRole)
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1beta1.CustomRepositoryRole)
+	if !ok {
+		return errors.New(errNotRole)
+	}
+	id, err := strconv.ParseInt(meta.GetExternalName(cr), 10, 64)
+	if err != nil {
+		return nil
+	}
+	err = kcgitclient.DeleteCustomRepoRole(ctx, c.service, c.org, id)
+	return errors.Wrap(resource.Ignore(kcgitclient.IsNotFound, err), errDeleteRole)
+}
@@ -0,0 +1,288 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package customrepositoryrole
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/hasheddan/kc-provider-github/apis/org/v1beta1"
+	"github.com/hasheddan/kc-provider-github/pkg/client/fake"
+)
+
+const org = "crossplane"
+
+type roleModifier func(*v1beta1.CustomRepositoryRole)
+
+func withExternalName(n string) roleModifier {
+	return func(cr *v1beta1.CustomRepositoryRole) { meta.SetExternalName(cr, n) }
+}
+
+func withName(n string) roleModifier {
+	return func(cr *v1beta1.CustomRepositoryRole) { cr.Spec.ForProvider.Name = n }
+}
+
+func withPermissions(p ...string) roleModifier {
+	return func(cr *v1beta1.CustomRepositoryRole) { cr.Spec.ForProvider.Permissions = p }
+}
+
+func withID(id int64) roleModifier {
+	return func(cr *v1beta1.CustomRepositoryRole) { cr.Status.AtProvider.ID = id }
+}
+
+func withConditions(c ...xpv1.Condition) roleModifier {
+	return func(cr *v1beta1.CustomRepositoryRole) { cr.SetConditions(c...) }
+}
+
+func role(m ...roleModifier) *v1beta1.CustomRepositoryRole {
+	cr := &v1beta1.CustomRepositoryRole{
+		ObjectMeta: metav1.ObjectMeta{Name: "release-manager"},
+		Spec: v1beta1.CustomRepositoryRoleSpec{
+			ResourceSpec: xpv1.ResourceSpec{ProviderConfigReference: &xpv1.Reference{Name: "default"}},
+			ForProvider: v1beta1.CustomRepositoryRoleParameters{
+				Org:         org,
+				Name:        "release-manager",
+				BaseRole:    v1beta1.BaseRoleWrite,
+				Permissions: []string{"create_tag", "delete_tag"},
+			},
+		},
+	}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+// newServer returns a fake server with an organization and a custom
+// repository role named release-manager, whose ID is 2.
+func newServer() *fake.Server {
+	s := fake.NewServer()
+	s.AddOrg(org)
+	s.AddCustomRole(org, fake.CustomRole{
+		Name:        "release-manager",
+		BaseRole:    v1beta1.BaseRoleWrite,
+		Permissions: []string{"delete_tag", "create_tag"},
+	})
+	return s
+}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		mg  resource.Managed
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		mg     resource.Managed
+		want   want
+	}{
+		"NotCustomRepositoryRole": {
+			reason: "An error should be returned if the managed resource is not a CustomRepositoryRole.",
+			mg:     &v1beta1.Team{},
+			want: want{
+				mg:  &v1beta1.Team{},
+				err: errors.New(errNotRole),
+			},
+		},
+		"Adopted": {
+			reason: "An existing role with the CustomRepositoryRole's name should be adopted, and identified by its ID.",
+			mg:     role(),
+			want: want{
+				mg: role(withExternalName("2"), withID(2), withConditions(xpv1.Available())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ResourceLateInitialized: true},
+			},
+		},
+		"NotCreated": {
+			reason: "A CustomRepositoryRole whose role was not created, and whose name is not taken, should be reported as not existing.",
+			mg:     role(withName("auditor")),
+			want: want{
+				mg: role(withName("auditor")),
+				o:  managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"Deleted": {
+			reason: "A CustomRepositoryRole whose role was deleted should be reported as not existing.",
+			mg:     role(withExternalName("42")),
+			want: want{
+				mg: role(withExternalName("42")),
+				o:  managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"UpToDate": {
+			reason: "A role should be up to date regardless of the order of its permissions.",
+			mg:     role(withExternalName("2"), withPermissions("delete_tag", "create_tag")),
+			want: want{
+				mg: role(withExternalName("2"), withPermissions("delete_tag", "create_tag"), withID(2), withConditions(xpv1.Available())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"Renamed": {
+			reason: "A role that was renamed should be found by its ID, and reported as not up to date.",
+			mg:     role(withExternalName("2"), withName("releaser")),
+			want: want{
+				mg: role(withExternalName("2"), withName("releaser"), withID(2), withConditions(xpv1.Available())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+		"PermissionsChanged": {
+			reason: "A role whose permissions differ should be reported as not up to date.",
+			mg:     role(withExternalName("2"), withPermissions("create_tag")),
+			want: want{
+				mg: role(withExternalName("2"), withPermissions("create_tag"), withID(2), withConditions(xpv1.Available())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := newServer()
+			defer s.Close()
+
+			got, err := (&external{service: s.Client(), org: org}).Observe(context.Background(), tc.mg)
+			if diff := fake.DiffErrors(tc.want.err, err); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want managed resource, +got managed resource:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserveRolePages(t *testing.T) {
+	s := newServer()
+	defer s.Close()
+	for i := 0; i < 150; i++ {
+		s.AddCustomRole(org, fake.CustomRole{Name: fmt.Sprintf("role-%03d", i), BaseRole: v1beta1.BaseRoleRead})
+	}
+
+	cr := role(withName("role-149"))
+	o, err := (&external{service: s.Client(), org: org}).Observe(context.Background(), cr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(true, o.ResourceExists); diff != "" {
+		t.Errorf("e.Observe(...): a role should be adopted from any page of roles: -want, +got:\n%s\n", diff)
+	}
+}
+
+func TestCreate(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		mg     *v1beta1.CustomRepositoryRole
+		want   string
+		err    error
+	}{
+		"Created": {
+			reason: "A role should be created, and its ID set as the external name.",
+			mg:     role(withName("auditor")),
+			want:   "3",
+		},
+		"NameTaken": {
+			reason: "An error should be returned if another role has the same name.",
+			mg:     role(),
+			err:    cmpopts.AnyError,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := newServer()
+			defer s.Close()
+
+			_, err := (&external{service: s.Client(), org: org}).Create(context.Background(), tc.mg)
+			if diff := fake.DiffErrors(tc.err, err); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if tc.err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want, meta.GetExternalName(tc.mg)); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want external name, +got external name:\n%s\n", tc.reason, diff)
+			}
+			want := fake.CustomRole{ID: 3, Name: "auditor", BaseRole: v1beta1.BaseRoleWrite, Permissions: []string{"create_tag", "delete_tag"}}
+			got, _ := s.CustomRole(org, 3)
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want role, +got role:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	s := newServer()
+	defer s.Close()
+
+	mg := role(withExternalName("2"), withName("releaser"), withPermissions("create_tag"))
+	mg.Spec.ForProvider.BaseRole = v1beta1.BaseRoleMaintain
+	if _, err := (&external{service: s.Client(), org: org}).Update(context.Background(), mg); err != nil {
+		t.Fatal(err)
+	}
+	want := fake.CustomRole{ID: 2, Name: "releaser", BaseRole: v1beta1.BaseRoleMaintain, Permissions: []string{"create_tag"}}
+	got, _ := s.CustomRole(org, 2)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("e.Update(...): -want role, +got role:\n%s\n", diff)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		mg     *v1beta1.CustomRepositoryRole
+	}{
+		"Deleted": {
+			reason: "The role should be deleted.",
+			mg:     role(withExternalName("2")),
+		},
+		"AlreadyDeleted": {
+			reason: "No error should be returned if the role was already deleted.",
+			mg:     role(withExternalName("42")),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := newServer()
+			defer s.Close()
+
+			if err := (&external{service: s.Client(), org: org}).Delete(context.Background(), tc.mg); err != nil {
+				t.Errorf("\n%s\ne.Delete(...): %s", tc.reason, err)
+			}
+			if _, ok := s.CustomRole(org, 2); ok && meta.GetExternalName(tc.mg) == "2" {
+				t.Errorf("\n%s\ne.Delete(...): role still exists", tc.reason)
+			}
+		})
+	}
+}
@@ -19,49 +19,97 @@ package membership
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/google/go-github/v45/github"
 	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/event"
-	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
-	"github.com/hasheddan/kc-provider-github/apis/org/v1alpha1"
+	"github.com/hasheddan/kc-provider-github/apis/org/v1beta1"
 	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/options"
+	"github.com/hasheddan/kc-provider-github/pkg/receiver"
 )
 
 const (
-	errNotMembership = "managed resource is not a MyType custom resource"
-	errCreateService = "failed to create client service"
+	errNotMembership   = "managed resource is not a MyType custom resource"
+	errCreateService   = "failed to create client service"
+	errListMemberships = "cannot list Memberships"
+	errGetInvitation   = "cannot get the user's invitation to the organization"
+	errReinvite        = "cannot cancel the user's expired invitation to the organization"
 )
 
 // SetupM adds a controller that reconciles MyType managed resources.
-func SetupMembership(mgr ctrl.Manager, l logging.Logger) error {
-	name := managed.ControllerName(v1alpha1.MembershipGroupKind)
+func SetupMembership(mgr ctrl.Manager, o options.Options) error {
+	name := managed.ControllerName(v1beta1.MembershipGroupKind)
 
 	r := managed.NewReconciler(mgr,
-		resource.ManagedKind(v1alpha1.MembershipGroupVersionKind),
+		resource.ManagedKind(v1beta1.MembershipGroupVersionKind),
 		managed.WithExternalConnecter(&connector{
-			kube: mgr.GetClient()},
+			kube:  mgr.GetClient(),
+			cache: kcgitclient.NewObservationCache(kcgitclient.DefaultObservationTTL)},
 		),
-		managed.WithLogger(l.WithValues("controller", name)),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
 		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
 
-	return ctrl.NewControllerManagedBy(mgr).
+	b := ctrl.NewControllerManagedBy(mgr).
 		Named(name).
-		For(&v1alpha1.Membership{}).
-		Complete(r)
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1beta1.Membership{})
+	if o.Receiver != nil {
+		src := o.Receiver.Subscribe(mapMemberships(mgr.GetClient()), receiver.EventMembership, receiver.EventTeam, receiver.EventOrganization)
+		b = b.Watches(src, &handler.EnqueueRequestForObject{})
+	}
+	return b.Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// mapMemberships returns a receiver.MapFunc that maps events to the
+// Memberships they may affect. An event that concerns a team but no particular
+// user, such as the team being deleted, maps to all of its Memberships. An
+// event that concerns a user but no particular team, such as the user leaving
+// the organization, maps to all of their Memberships.
+func mapMemberships(c client.Reader) receiver.MapFunc {
+	return func(ctx context.Context, e receiver.Event) ([]client.Object, error) {
+		l := &v1beta1.MembershipList{}
+		if err := c.List(ctx, l); err != nil {
+			return nil, errors.Wrap(err, errListMemberships)
+		}
+		var objs []client.Object
+		for i := range l.Items {
+			m := &l.Items[i]
+			// Memberships whose organization cannot be resolved cannot be
+			// affected by the event.
+			if org, err := kcgitclient.Organization(ctx, c, m, m.Spec.ForProvider.Org); err != nil || org != e.Org {
+				continue
+			}
+			if e.Team != "" && pointer.StringDeref(m.Spec.ForProvider.Team, "") != e.Team {
+				continue
+			}
+			if e.User != "" && m.Spec.ForProvider.User != e.User {
+				continue
+			}
+			objs = append(objs, m)
+		}
+		return objs, nil
+	}
 }
 
 // A connector is expected to produce an ExternalClient when its Connect method
 // is called.
 type connector struct {
-	kube client.Client
+	kube  client.Client
+	cache *kcgitclient.ObservationCache
 }
 
 // Connect typically produces an ExternalClient by:
@@ -70,7 +118,7 @@ type connector struct {
 // 3. Getting the ProviderConfig's credentials secret.
 // 4. Using the credentials secret to form a client.
 func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
-	_, ok := mg.(*v1alpha1.Membership)
+	cr, ok := mg.(*v1beta1.Membership)
 	if !ok {
 		return nil, errors.New(errNotMembership)
 	}
@@ -78,7 +126,17 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 	if err != nil {
 		return nil, errors.Wrap(err, errCreateService)
 	}
-	return &external{service: svc}, nil
+	org, err := kcgitclient.Organization(ctx, c.kube, mg, cr.Spec.ForProvider.Org)
+	if err != nil {
+		return nil, err
+	}
+	return &external{
+		service: svc,
+		org:     org,
+		graphql: kcgitclient.NewGraphQLClient(svc),
+		cache:   c.cache,
+		scope:   mg.GetProviderConfigReference().Name,
+	}, nil
 }
 
 // An ExternalClient observes, then either creates, updates, or deletes an
@@ -87,17 +145,41 @@ type external struct {
 	// A 'client' used to connect to the external resource API. In practice this
 	// would be something like an AWS SDK client.
 	service *github.Client
+
+	// The organization of the managed resource, which may be the default
+	// organization of its ProviderConfig.
+	org string
+
+	// Team members are observed in batches via the GraphQL API, shared between
+	// all Memberships that use the same ProviderConfig (the scope).
+	graphql *kcgitclient.GraphQLClient
+	cache   *kcgitclient.ObservationCache
+	scope   string
+}
+
+// getMembership returns the supplied user's membership of the supplied team.
+// It is read from the team's cached members if possible, falling back to the
+// REST API if the user is not cached or the members cannot be fetched. Pending
+// memberships are never cached.
+func (c *external) getMembership(ctx context.Context, org, team, user string) (*github.Membership, error) {
+	if members, err := c.cache.TeamMembers(ctx, c.graphql, c.scope, org, team); err == nil {
+		if m, ok := members[user]; ok {
+			return m, nil
+		}
+	}
+	m, _, err := c.service.Teams.GetTeamMembershipBySlug(ctx, org, team, user)
+	return m, err
 }
 
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
-	cr, ok := mg.(*v1alpha1.Membership)
+	cr, ok := mg.(*v1beta1.Membership)
 	if !ok {
 		return managed.ExternalObservation{}, errors.New(errNotMembership)
 	}
 
-	membership, _, err := c.service.Teams.GetTeamMembershipBySlug(
+	membership, err := c.getMembership(
 		ctx,
-		cr.Spec.ForProvider.Org,
+		c.org,
 		pointer.StringDeref(cr.Spec.ForProvider.Team, ""),
 		cr.Spec.ForProvider.User,
 	)
@@ -111,6 +193,35 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		cr.Status.AtProvider.State = *membership.State
 	}
 
+	upToDate := true
+	if cr.Spec.ForProvider.Role != nil && membership.GetRole() != *cr.Spec.ForProvider.Role {
+		upToDate = false
+	}
+
+	// A pending membership is not ready until the user accepts their
+	// invitation to the organization, which may expire first.
+	cr.Status.AtProvider.InvitationExpiresAt = nil
+	switch membership.GetState() {
+	case "pending":
+		i, err := kcgitclient.GetPendingOrgInvitation(ctx, c.service, c.org, cr.Spec.ForProvider.User)
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errGetInvitation)
+		}
+		if i != nil {
+			cr.Status.AtProvider.InvitationExpiresAt = &metav1.Time{Time: i.GetCreatedAt().Add(kcgitclient.InvitationTTL)}
+		}
+		if i == nil || !time.Now().Before(cr.Status.AtProvider.InvitationExpiresAt.Time) {
+			cr.SetConditions(v1beta1.InvitationExpired())
+			if pointer.BoolDeref(cr.Spec.ForProvider.ReinviteOnExpiry, false) {
+				upToDate = false
+			}
+			break
+		}
+		cr.SetConditions(v1beta1.InvitationPending())
+	default:
+		cr.SetConditions(xpv1.Available())
+	}
+
 	return managed.ExternalObservation{
 		// Return false when the external resource does not exist. This lets
 		// the managed resource reconciler know that it needs to call Create to
@@ -120,7 +231,7 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		// Return false when the external resource exists, but it not up to date
 		// with the desired managed resource state. This lets the managed
 		// resource reconciler know that it needs to call Update.
-		ResourceUpToDate: true,
+		ResourceUpToDate: upToDate,
 
 		ConnectionDetails: managed.ConnectionDetails{
 			"username": []byte(cr.Spec.ForProvider.User),
@@ -129,37 +240,66 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 }
 
 func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
-	cr, ok := mg.(*v1alpha1.Membership)
+	cr, ok := mg.(*v1beta1.Membership)
 	if !ok {
 		return managed.ExternalCreation{}, errors.New(errNotMembership)
 	}
 
 	fmt.Printf("Creating: %+v", cr)
 
+	return managed.ExternalCreation{}, c.addMembership(ctx, cr)
+}
+
+// addMembership adds or updates the supplied Membership's team membership.
+func (c *external) addMembership(ctx context.Context, cr *v1beta1.Membership) error {
 	_, _, err := c.service.Teams.AddTeamMembershipBySlug(
 		ctx,
-		cr.Spec.ForProvider.Org,
+		c.org,
 		pointer.StringDeref(cr.Spec.ForProvider.Team, ""),
 		cr.Spec.ForProvider.User,
-		nil,
+		&github.TeamAddTeamMembershipOptions{Role: pointer.StringDeref(cr.Spec.ForProvider.Role, "")},
 	)
-
-	return managed.ExternalCreation{}, err
+	c.cache.InvalidateTeamMembers(c.scope, c.org, pointer.StringDeref(cr.Spec.ForProvider.Team, ""))
+	return err
 }
 
 func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
-	cr, ok := mg.(*v1alpha1.Membership)
+	cr, ok := mg.(*v1beta1.Membership)
 	if !ok {
 		return managed.ExternalUpdate{}, errors.New(errNotMembership)
 	}
 
 	fmt.Printf("Updating: %+v", cr)
 
-	return managed.ExternalUpdate{}, nil
+	if pointer.BoolDeref(cr.Spec.ForProvider.ReinviteOnExpiry, false) && cr.GetCondition(xpv1.TypeReady).Reason == v1beta1.ReasonInvitationExpired {
+		if err := c.cancelInvitation(ctx, cr); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errReinvite)
+		}
+	}
+
+	// Adding an existing member to a team updates their role.
+	return managed.ExternalUpdate{}, c.addMembership(ctx, cr)
+}
+
+// cancelInvitation cancels the supplied Membership's expired invitation, and
+// its pending membership of the team, so that adding the user to the team
+// invites them to the organization again.
+func (c *external) cancelInvitation(ctx context.Context, cr *v1beta1.Membership) error {
+	i, err := kcgitclient.GetPendingOrgInvitation(ctx, c.service, c.org, cr.Spec.ForProvider.User)
+	if err != nil {
+		return err
+	}
+	if i != nil {
+		if err := kcgitclient.CancelOrgInvitation(ctx, c.service, c.org, i.GetID()); resource.Ignore(kcgitclient.IsNotFound, err) != nil {
+			return err
+		}
+	}
+	_, err = c.service.Teams.RemoveTeamMembershipBySlug(ctx, c.org, pointer.StringDeref(cr.Spec.ForProvider.Team, ""), cr.Spec.ForProvider.User)
+	return resource.Ignore(kcgitclient.IsNotFound, err)
 }
 
 func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
-	cr, ok := mg.(*v1alpha1.Membership)
+	cr, ok := mg.(*v1beta1.Membership)
 	if !ok {
 		return errors.New(errNotMembership)
 	}
@@ -168,10 +308,11 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
 
 	_, err := c.service.Teams.RemoveTeamMembershipBySlug(
 		ctx,
-		cr.Spec.ForProvider.Org,
+		c.org,
 		pointer.StringDeref(cr.Spec.ForProvider.Team, ""),
 		cr.Spec.ForProvider.User,
 	)
+	c.cache.InvalidateTeamMembers(c.scope, c.org, pointer.StringDeref(cr.Spec.ForProvider.Team, ""))
 
 	return err
 }
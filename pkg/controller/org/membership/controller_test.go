@@ -0,0 +1,505 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package membership
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/go-github/v45/github"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/hasheddan/kc-provider-github/apis/org/v1beta1"
+	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
+	"github.com/hasheddan/kc-provider-github/pkg/client/fake"
+)
+
+const (
+	org  = "crossplane"
+	slug = "platform"
+	user = "hubot"
+)
+
+var (
+	// Invited is when the user was invited to the organization, if their
+	// invitation has not yet expired.
+	invited = time.Now().Add(-24 * time.Hour).Truncate(time.Second)
+
+	// InvitedLongAgo is when the user was invited to the organization, if
+	// their invitation has expired.
+	invitedLongAgo = time.Now().Add(-8 * 24 * time.Hour).Truncate(time.Second)
+)
+
+type membershipModifier func(*v1beta1.Membership)
+
+func withState(s string) membershipModifier {
+	return func(cr *v1beta1.Membership) { cr.Status.AtProvider.State = s }
+}
+
+func withRole(r string) membershipModifier {
+	return func(cr *v1beta1.Membership) { cr.Spec.ForProvider.Role = &r }
+}
+
+func withReinviteOnExpiry() membershipModifier {
+	return func(cr *v1beta1.Membership) { cr.Spec.ForProvider.ReinviteOnExpiry = github.Bool(true) }
+}
+
+func withInvitationExpiresAt(t time.Time) membershipModifier {
+	return func(cr *v1beta1.Membership) {
+		cr.Status.AtProvider.InvitationExpiresAt = &metav1.Time{Time: t.Add(kcgitclient.InvitationTTL)}
+	}
+}
+
+func withConditions(c ...xpv1.Condition) membershipModifier {
+	return func(cr *v1beta1.Membership) { cr.SetConditions(c...) }
+}
+
+func membership(m ...membershipModifier) *v1beta1.Membership {
+	cr := &v1beta1.Membership{
+		ObjectMeta: metav1.ObjectMeta{Name: "cool-membership"},
+		Spec: v1beta1.MembershipSpec{
+			ResourceSpec: xpv1.ResourceSpec{ProviderConfigReference: &xpv1.Reference{Name: "default"}},
+			ForProvider: v1beta1.MembershipParameters{
+				Org:  org,
+				Team: github.String(slug),
+				User: user,
+			},
+		},
+	}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+func newExternal(s *fake.Server) *external {
+	c := s.Client()
+	return &external{
+		service: c,
+		org:     org,
+		graphql: kcgitclient.NewGraphQLClient(c),
+		cache:   kcgitclient.NewObservationCache(0),
+		scope:   "default",
+	}
+}
+
+// newServer returns a fake server with an organization that has a team.
+func newServer() *fake.Server {
+	s := fake.NewServer()
+	s.AddOrg(org)
+	s.AddTeam(org, github.NewTeam{Name: slug})
+	return s
+}
+
+func TestObserve(t *testing.T) {
+	type args struct {
+		server func(s *fake.Server)
+		mg     resource.Managed
+	}
+	type want struct {
+		mg  resource.Managed
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NotMembership": {
+			reason: "An error should be returned if the managed resource is not a Membership.",
+			args: args{
+				mg: &v1beta1.Team{},
+			},
+			want: want{
+				mg:  &v1beta1.Team{},
+				err: errors.New(errNotMembership),
+			},
+		},
+		"DoesNotExist": {
+			reason: "A membership that does not exist should be reported as such.",
+			args: args{
+				mg: membership(),
+			},
+			want: want{
+				mg: membership(),
+				o:  managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"Active": {
+			reason: "An active membership should be reported as existing and up to date.",
+			args: args{
+				server: func(s *fake.Server) {
+					s.AddTeamMember(org, slug, user, "member", "active")
+				},
+				mg: membership(),
+			},
+			want: want{
+				mg: membership(withState("active"), withConditions(xpv1.Available())),
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{"username": []byte(user)},
+				},
+			},
+		},
+		"Pending": {
+			reason: "A pending membership should be reported as not ready until its invitation is accepted.",
+			args: args{
+				server: func(s *fake.Server) {
+					s.AddTeamMember(org, slug, user, "member", "pending")
+					s.AddOrgInvitation(org, user, invited)
+				},
+				mg: membership(),
+			},
+			want: want{
+				mg: membership(withState("pending"), withInvitationExpiresAt(invited), withConditions(v1beta1.InvitationPending())),
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{"username": []byte(user)},
+				},
+			},
+		},
+		"InvitationExpired": {
+			reason: "A pending membership whose invitation expired should be reported as such.",
+			args: args{
+				server: func(s *fake.Server) {
+					s.AddTeamMember(org, slug, user, "member", "pending")
+					s.AddOrgInvitation(org, user, invitedLongAgo)
+				},
+				mg: membership(),
+			},
+			want: want{
+				mg: membership(withState("pending"), withInvitationExpiresAt(invitedLongAgo), withConditions(v1beta1.InvitationExpired())),
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{"username": []byte(user)},
+				},
+			},
+		},
+		"InvitationExpiredReinvite": {
+			reason: "A pending membership whose invitation expired should be reported as out of date if its user should be invited again.",
+			args: args{
+				server: func(s *fake.Server) {
+					s.AddTeamMember(org, slug, user, "member", "pending")
+					s.AddOrgInvitation(org, user, invitedLongAgo)
+				},
+				mg: membership(withReinviteOnExpiry()),
+			},
+			want: want{
+				mg: membership(withReinviteOnExpiry(), withState("pending"), withInvitationExpiresAt(invitedLongAgo), withConditions(v1beta1.InvitationExpired())),
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  false,
+					ConnectionDetails: managed.ConnectionDetails{"username": []byte(user)},
+				},
+			},
+		},
+		"RoleChanged": {
+			reason: "A membership whose role differs from the desired role should be reported as out of date.",
+			args: args{
+				server: func(s *fake.Server) {
+					s.AddTeamMember(org, slug, user, "member", "active")
+				},
+				mg: membership(withRole(v1beta1.RoleMaintainer)),
+			},
+			want: want{
+				mg: membership(withRole(v1beta1.RoleMaintainer), withState("active"), withConditions(xpv1.Available())),
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  false,
+					ConnectionDetails: managed.ConnectionDetails{"username": []byte(user)},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := newServer()
+			defer s.Close()
+			if tc.args.server != nil {
+				tc.args.server(s)
+			}
+
+			got, err := newExternal(s).Observe(context.Background(), tc.args.mg)
+			if diff := fake.DiffErrors(tc.want.err, err); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.args.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want managed resource, +got managed resource:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	type args struct {
+		server func(s *fake.Server)
+		mg     resource.Managed
+	}
+	type want struct {
+		membership *github.Membership
+		err        error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NotMembership": {
+			reason: "An error should be returned if the managed resource is not a Membership.",
+			args: args{
+				mg: &v1beta1.Team{},
+			},
+			want: want{
+				err: errors.New(errNotMembership),
+			},
+		},
+		"OrgMember": {
+			reason: "A member of the organization should be added to the team immediately.",
+			args: args{
+				server: func(s *fake.Server) {
+					s.AddOrgMember(org, user, "member")
+				},
+				mg: membership(),
+			},
+			want: want{
+				membership: &github.Membership{Role: github.String("member"), State: github.String("active")},
+			},
+		},
+		"NotOrgMember": {
+			reason: "A user who is not a member of the organization should be invited to the team.",
+			args: args{
+				server: func(s *fake.Server) {
+					s.AddUser(user)
+				},
+				mg: membership(),
+			},
+			want: want{
+				membership: &github.Membership{Role: github.String("member"), State: github.String("pending")},
+			},
+		},
+		"Maintainer": {
+			reason: "A user should be added to the team with the desired role.",
+			args: args{
+				server: func(s *fake.Server) {
+					s.AddOrgMember(org, user, "member")
+				},
+				mg: membership(withRole(v1beta1.RoleMaintainer)),
+			},
+			want: want{
+				membership: &github.Membership{Role: github.String("maintainer"), State: github.String("active")},
+			},
+		},
+		"UserDoesNotExist": {
+			reason: "An error should be returned if the user does not exist.",
+			args: args{
+				mg: membership(),
+			},
+			want: want{
+				err: cmpopts.AnyError,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := newServer()
+			defer s.Close()
+			if tc.args.server != nil {
+				tc.args.server(s)
+			}
+
+			_, err := newExternal(s).Create(context.Background(), tc.args.mg)
+			if diff := fake.DiffErrors(tc.want.err, err); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+
+			var got *github.Membership
+			if m, ok := s.TeamMembership(org, slug, user); ok {
+				got = &m
+			}
+			if diff := cmp.Diff(tc.want.membership, got); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want membership, +got membership:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	type args struct {
+		server func(s *fake.Server)
+		mg     resource.Managed
+	}
+	type want struct {
+		membership *github.Membership
+		reinvited  bool
+		err        error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NotMembership": {
+			reason: "An error should be returned if the managed resource is not a Membership.",
+			args: args{
+				mg: &v1beta1.Team{},
+			},
+			want: want{
+				err: errors.New(errNotMembership),
+			},
+		},
+		"Reinvited": {
+			reason: "A user whose invitation expired should be invited to the organization again.",
+			args: args{
+				server: func(s *fake.Server) {
+					s.AddTeamMember(org, slug, user, "member", "pending")
+					s.AddOrgInvitation(org, user, invitedLongAgo)
+				},
+				mg: membership(withReinviteOnExpiry(), withConditions(v1beta1.InvitationExpired())),
+			},
+			want: want{
+				membership: &github.Membership{Role: github.String("member"), State: github.String("pending")},
+				reinvited:  true,
+			},
+		},
+		"RoleUpdated": {
+			reason: "A member's role should be updated to the desired role.",
+			args: args{
+				server: func(s *fake.Server) {
+					s.AddTeamMember(org, slug, user, "member", "active")
+				},
+				mg: membership(withRole(v1beta1.RoleMaintainer)),
+			},
+			want: want{
+				membership: &github.Membership{Role: github.String("maintainer"), State: github.String("active")},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := newServer()
+			defer s.Close()
+			if tc.args.server != nil {
+				tc.args.server(s)
+			}
+
+			_, err := newExternal(s).Update(context.Background(), tc.args.mg)
+			if diff := fake.DiffErrors(tc.want.err, err); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+
+			var got *github.Membership
+			if m, ok := s.TeamMembership(org, slug, user); ok {
+				got = &m
+			}
+			if diff := cmp.Diff(tc.want.membership, got); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want membership, +got membership:\n%s\n", tc.reason, diff)
+			}
+			if i, ok := s.OrgInvitation(org, user); tc.want.reinvited && (!ok || !i.GetCreatedAt().After(invited)) {
+				t.Errorf("\n%s\ne.Update(...): the user should be invited to the organization again\n", tc.reason)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	type args struct {
+		server func(s *fake.Server)
+		mg     resource.Managed
+	}
+	type want struct {
+		exists bool
+		err    error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NotMembership": {
+			reason: "An error should be returned if the managed resource is not a Membership.",
+			args: args{
+				mg: &v1beta1.Team{},
+			},
+			want: want{
+				err: errors.New(errNotMembership),
+			},
+		},
+		"Deleted": {
+			reason: "The user should be removed from the team.",
+			args: args{
+				server: func(s *fake.Server) {
+					s.AddTeamMember(org, slug, user, "member", "active")
+				},
+				mg: membership(),
+			},
+			want: want{
+				exists: false,
+			},
+		},
+		"DoesNotExist": {
+			reason: "An error should be returned if the membership does not exist.",
+			args: args{
+				mg: membership(),
+			},
+			want: want{
+				err: cmpopts.AnyError,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := newServer()
+			defer s.Close()
+			if tc.args.server != nil {
+				tc.args.server(s)
+			}
+
+			err := newExternal(s).Delete(context.Background(), tc.args.mg)
+			if diff := fake.DiffErrors(tc.want.err, err); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			_, exists := s.TeamMembership(org, slug, user)
+			if diff := cmp.Diff(tc.want.exists, exists); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want exists, +got exists:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,236 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package organization
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/go-github/v45/github"
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/hasheddan/kc-provider-github/apis/org/v1beta1"
+	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/options"
+	"github.com/hasheddan/kc-provider-github/pkg/receiver"
+)
+
+const (
+	errNotOrganization   = "managed resource is not an Organization custom resource"
+	errCreateService     = "failed to create client service"
+	errListOrganizations = "cannot list Organizations"
+	errGetOrganization   = "cannot get organization"
+	errEditOrganization  = "cannot edit organization"
+	errCreate            = "organizations cannot be created by the provider, only managed once they exist"
+)
+
+// SetupOrganization adds a controller that reconciles Organization managed
+// resources.
+func SetupOrganization(mgr ctrl.Manager, o options.Options) error {
+	name := managed.ControllerName(v1beta1.OrganizationGroupKind)
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1beta1.OrganizationGroupVersionKind),
+		managed.WithExternalConnecter(&connector{kube: mgr.GetClient()}),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+
+	b := ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1beta1.Organization{})
+	if o.Receiver != nil {
+		b = b.Watches(o.Receiver.Subscribe(mapOrganizations(mgr.GetClient()), receiver.EventOrganization), &handler.EnqueueRequestForObject{})
+	}
+	return b.Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// mapOrganizations returns a receiver.MapFunc that maps events to the
+// Organizations whose external name is the login of the organization they
+// concern.
+func mapOrganizations(c client.Reader) receiver.MapFunc {
+	return func(ctx context.Context, e receiver.Event) ([]client.Object, error) {
+		l := &v1beta1.OrganizationList{}
+		if err := c.List(ctx, l); err != nil {
+			return nil, errors.Wrap(err, errListOrganizations)
+		}
+		var objs []client.Object
+		for i := range l.Items {
+			o := &l.Items[i]
+			if meta.GetExternalName(o) == e.Org {
+				objs = append(objs, o)
+			}
+		}
+		return objs, nil
+	}
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect produces an ExternalClient that uses the credentials of the managed
+// resource's ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	_, ok := mg.(*v1beta1.Organization)
+	if !ok {
+		return nil, errors.New(errNotOrganization)
+	}
+	svc, err := kcgitclient.UseProviderConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errCreateService)
+	}
+	return &external{service: svc}, nil
+}
+
+// An ExternalClient observes and updates the settings of an organization.
+type external struct {
+	service *github.Client
+}
+
+// orgSettings extend github.Organization with settings that it does not yet
+// support. They are read and written using the same endpoints as
+// OrganizationsService.Get and OrganizationsService.Edit.
+type orgSettings struct {
+	github.Organization
+	WebCommitSignoffRequired *bool `json:"web_commit_signoff_required,omitempty"`
+}
+
+func (c *external) get(ctx context.Context, login string) (*orgSettings, error) {
+	req, err := c.service.NewRequest(http.MethodGet, "orgs/"+login, nil)
+	if err != nil {
+		return nil, err
+	}
+	o := &orgSettings{}
+	_, err = c.service.Do(ctx, req, o)
+	return o, err
+}
+
+func (c *external) edit(ctx context.Context, login string, o *orgSettings) error {
+	req, err := c.service.NewRequest(http.MethodPatch, "orgs/"+login, o)
+	if err != nil {
+		return err
+	}
+	_, err = c.service.Do(ctx, req, nil)
+	return err
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1beta1.Organization)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotOrganization)
+	}
+
+	// Organizations are never deleted. Report that the organization no
+	// longer exists so that the managed resource's finalizer is removed.
+	if meta.WasDeleted(cr) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	o, err := c.get(ctx, meta.GetExternalName(cr))
+	if err != nil {
+		if kcgitclient.IsNotFound(err) {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetOrganization)
+	}
+
+	cr.Status.AtProvider.NodeID = o.GetNodeID()
+	cr.Status.AtProvider.TwoFactorRequirementEnabled = o.TwoFactorRequirementEnabled
+	cr.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: upToDate(cr.Spec.ForProvider, o),
+	}, nil
+}
+
+// upToDate returns true if the settings of the supplied organization match
+// those that are specified by the supplied parameters.
+func upToDate(p v1beta1.OrganizationParameters, o *orgSettings) bool {
+	return stringUpToDate(p.BillingEmail, o.BillingEmail) &&
+		stringUpToDate(p.Blog, o.Blog) &&
+		stringUpToDate(p.Location, o.Location) &&
+		stringUpToDate(p.Description, o.Description) &&
+		stringUpToDate(p.DefaultRepositoryPermission, o.DefaultRepoPermission) &&
+		boolUpToDate(p.MembersCanCreateRepositories, o.MembersCanCreateRepos) &&
+		boolUpToDate(p.MembersCanCreatePublicRepositories, o.MembersCanCreatePublicRepos) &&
+		boolUpToDate(p.MembersCanCreatePrivateRepositories, o.MembersCanCreatePrivateRepos) &&
+		boolUpToDate(p.MembersCanCreateInternalRepositories, o.MembersCanCreateInternalRepos) &&
+		boolUpToDate(p.WebCommitSignoffRequired, o.WebCommitSignoffRequired)
+}
+
+// A setting is up to date if it is not specified, or if it matches.
+func stringUpToDate(want, got *string) bool {
+	return want == nil || (got != nil && *want == *got)
+}
+
+func boolUpToDate(want, got *bool) bool {
+	return want == nil || (got != nil && *want == *got)
+}
+
+func (c *external) Create(_ context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	if _, ok := mg.(*v1beta1.Organization); !ok {
+		return managed.ExternalCreation{}, errors.New(errNotOrganization)
+	}
+	return managed.ExternalCreation{}, errors.New(errCreate)
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1beta1.Organization)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotOrganization)
+	}
+
+	p := cr.Spec.ForProvider
+	err := c.edit(ctx, meta.GetExternalName(cr), &orgSettings{
+		Organization: github.Organization{
+			BillingEmail:                  p.BillingEmail,
+			Blog:                          p.Blog,
+			Location:                      p.Location,
+			Description:                   p.Description,
+			DefaultRepoPermission:         p.DefaultRepositoryPermission,
+			MembersCanCreateRepos:         p.MembersCanCreateRepositories,
+			MembersCanCreatePublicRepos:   p.MembersCanCreatePublicRepositories,
+			MembersCanCreatePrivateRepos:  p.MembersCanCreatePrivateRepositories,
+			MembersCanCreateInternalRepos: p.MembersCanCreateInternalRepositories,
+		},
+		WebCommitSignoffRequired: p.WebCommitSignoffRequired,
+	})
+	return managed.ExternalUpdate{}, errors.Wrap(err, errEditOrganization)
+}
+
+// Delete does nothing. Organizations are never deleted by the provider.
+func (c *external) Delete(_ context.Context, mg resource.Managed) error {
+	if _, ok := mg.(*v1beta1.Organization); !ok {
+		return errors.New(errNotOrganization)
+	}
+	return nil
+}
@@ -0,0 +1,220 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package organization
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v45/github"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/hasheddan/kc-provider-github/apis/org/v1beta1"
+	"github.com/hasheddan/kc-provider-github/pkg/client/fake"
+)
+
+const org = "crossplane"
+
+type organizationModifier func(*v1beta1.Organization)
+
+func withBillingEmail(e string) organizationModifier {
+	return func(cr *v1beta1.Organization) { cr.Spec.ForProvider.BillingEmail = &e }
+}
+
+func withWebCommitSignoffRequired(r bool) organizationModifier {
+	return func(cr *v1beta1.Organization) { cr.Spec.ForProvider.WebCommitSignoffRequired = &r }
+}
+
+func withObservation(o v1beta1.OrganizationObservation) organizationModifier {
+	return func(cr *v1beta1.Organization) { cr.Status.AtProvider = o }
+}
+
+func withConditions(c ...xpv1.Condition) organizationModifier {
+	return func(cr *v1beta1.Organization) { cr.SetConditions(c...) }
+}
+
+func withDeletionTimestamp() organizationModifier {
+	return func(cr *v1beta1.Organization) {
+		now := metav1.NewTime(time.Now())
+		cr.SetDeletionTimestamp(&now)
+	}
+}
+
+func organization(m ...organizationModifier) *v1beta1.Organization {
+	cr := &v1beta1.Organization{
+		ObjectMeta: metav1.ObjectMeta{Name: "cool-org"},
+		Spec: v1beta1.OrganizationSpec{
+			ResourceSpec: xpv1.ResourceSpec{ProviderConfigReference: &xpv1.Reference{Name: "default"}},
+		},
+	}
+	meta.SetExternalName(cr, org)
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+// newServer returns a fake server with an organization.
+func newServer() *fake.Server {
+	s := fake.NewServer()
+	s.AddOrg(org)
+	s.EditOrg(org, func(o *github.Organization) {
+		o.BillingEmail = github.String("billing@example.org")
+		o.TwoFactorRequirementEnabled = github.Bool(true)
+	})
+	return s
+}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		mg  resource.Managed
+		o   managed.ExternalObservation
+		err error
+	}
+
+	observed := v1beta1.OrganizationObservation{NodeID: "O_1", TwoFactorRequirementEnabled: github.Bool(true)}
+
+	cases := map[string]struct {
+		reason string
+		mg     resource.Managed
+		want   want
+	}{
+		"NotOrganization": {
+			reason: "An error should be returned if the managed resource is not an Organization.",
+			mg:     &v1beta1.Team{},
+			want: want{
+				mg:  &v1beta1.Team{},
+				err: errors.New(errNotOrganization),
+			},
+		},
+		"DoesNotExist": {
+			reason: "An organization that does not exist should be reported as such.",
+			mg: organization(func(cr *v1beta1.Organization) {
+				meta.SetExternalName(cr, "nope")
+			}),
+			want: want{
+				mg: organization(func(cr *v1beta1.Organization) {
+					meta.SetExternalName(cr, "nope")
+				}),
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"UpToDate": {
+			reason: "An organization whose settings match those specified should be up to date.",
+			mg:     organization(withBillingEmail("billing@example.org")),
+			want: want{
+				mg: organization(withBillingEmail("billing@example.org"), withObservation(observed), withConditions(xpv1.Available())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"NotUpToDate": {
+			reason: "An organization whose settings do not match those specified should not be up to date.",
+			mg:     organization(withBillingEmail("new@example.org")),
+			want: want{
+				mg: organization(withBillingEmail("new@example.org"), withObservation(observed), withConditions(xpv1.Available())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+		"Deleted": {
+			reason: "An organization should be reported as not existing once its managed resource is deleted.",
+			mg:     organization(withDeletionTimestamp()),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := newServer()
+			defer s.Close()
+
+			e := &external{service: s.Client()}
+			got, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if tc.want.mg == nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want managed resource, +got managed resource:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	e := &external{}
+	_, err := e.Create(context.Background(), organization())
+	if diff := cmp.Diff(errors.New(errCreate), err, test.EquateErrors()); diff != "" {
+		t.Errorf("e.Create(...): organizations should never be created: -want error, +got error:\n%s\n", diff)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	s := newServer()
+	defer s.Close()
+
+	e := &external{service: s.Client()}
+	cr := organization(withBillingEmail("new@example.org"), withWebCommitSignoffRequired(true))
+	if _, err := e.Update(context.Background(), cr); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := e.get(context.Background(), org)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff("new@example.org", got.GetBillingEmail()); diff != "" {
+		t.Errorf("e.Update(...): -want billing email, +got billing email:\n%s\n", diff)
+	}
+	if diff := cmp.Diff(github.Bool(true), got.WebCommitSignoffRequired); diff != "" {
+		t.Errorf("e.Update(...): -want web commit signoff required, +got web commit signoff required:\n%s\n", diff)
+	}
+	if diff := cmp.Diff(github.Bool(true), got.TwoFactorRequirementEnabled); diff != "" {
+		t.Errorf("e.Update(...): settings that are not specified should not be changed: -want, +got:\n%s\n", diff)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	s := newServer()
+	defer s.Close()
+
+	e := &external{service: s.Client()}
+	if err := e.Delete(context.Background(), organization()); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := s.Org(org); !ok {
+		t.Errorf("e.Delete(...): organizations should never be deleted")
+	}
+	if got := s.Requests(); got != 0 {
+		t.Errorf("e.Delete(...): should make no requests: got %d", got)
+	}
+}
@@ -0,0 +1,283 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package organizationinvitation
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/google/go-github/v45/github"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/hasheddan/kc-provider-github/apis/org/v1beta1"
+	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/options"
+)
+
+const (
+	errNotInvitation = "managed resource is not an OrganizationInvitation custom resource"
+	errCreateService = "failed to create client service"
+	errInvitee       = "exactly one of email or inviteeID must be set"
+	errListPending   = "cannot list the organization's pending invitations"
+	errListFailed    = "cannot list the organization's failed invitations"
+	errGetInvitee    = "cannot get invitee"
+	errGetMembership = "cannot get the invitee's organization membership"
+	errCreate        = "cannot create invitation"
+	errCancel        = "cannot cancel invitation"
+	errFmtGetTeam    = "cannot get team %s"
+)
+
+// SetupOrganizationInvitation adds a controller that reconciles
+// OrganizationInvitation managed resources.
+func SetupOrganizationInvitation(mgr ctrl.Manager, o options.Options) error {
+	name := managed.ControllerName(v1beta1.OrganizationInvitationGroupKind)
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1beta1.OrganizationInvitationGroupVersionKind),
+		managed.WithExternalConnecter(&connector{kube: mgr.GetClient()}),
+		// The external name of an OrganizationInvitation is the ID GitHub
+		// assigns the invitation when it is created.
+		managed.WithInitializers(),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1beta1.OrganizationInvitation{}).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect produces an ExternalClient that uses the credentials of the managed
+// resource's ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1beta1.OrganizationInvitation)
+	if !ok {
+		return nil, errors.New(errNotInvitation)
+	}
+	svc, err := kcgitclient.UseProviderConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errCreateService)
+	}
+	org, err := kcgitclient.Organization(ctx, c.kube, mg, cr.Spec.ForProvider.Org)
+	if err != nil {
+		return nil, err
+	}
+	return &external{service: svc, org: org}, nil
+}
+
+// An ExternalClient observes, creates and cancels invitations to an
+// organization.
+type external struct {
+	service *github.Client
+
+	// The organization of the managed resource, which may be the default
+	// organization of its ProviderConfig.
+	org string
+}
+
+// A listFn lists a page of an organization's invitations.
+type listFn func(ctx context.Context, org string, opts *github.ListOptions) ([]*github.Invitation, *github.Response, error)
+
+// find the invitation with the supplied ID, paging through the invitations
+// listed by the supplied function. It returns nil if there is no such
+// invitation.
+func (c *external) find(ctx context.Context, list listFn, id int64) (*github.Invitation, error) {
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		l, rsp, err := list(ctx, c.org, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, i := range l {
+			if i.GetID() == id {
+				return i, nil
+			}
+		}
+		if rsp.NextPage == 0 {
+			return nil, nil
+		}
+		opts.Page = rsp.NextPage
+	}
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1beta1.OrganizationInvitation)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotInvitation)
+	}
+
+	id, err := strconv.ParseInt(meta.GetExternalName(cr), 10, 64)
+	if err != nil {
+		// The invitation has yet to be created.
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	pending, err := c.find(ctx, c.service.Organizations.ListPendingOrgInvitations, id)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errListPending)
+	}
+	if pending != nil {
+		observe(cr, pending, v1beta1.InvitationStatePending)
+		cr.SetConditions(v1beta1.InvitationPending())
+		return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+	}
+
+	// Only a pending invitation can be cancelled. Deleting the
+	// OrganizationInvitation of any other invitation does not remove its
+	// invitee from the organization, so it no longer exists once it is being
+	// deleted.
+	if meta.WasDeleted(cr) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	failed, err := c.find(ctx, c.service.Organizations.ListFailedOrgInvitations, id)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errListFailed)
+	}
+	if failed != nil {
+		observe(cr, failed, v1beta1.InvitationStateFailed)
+		cr.SetConditions(v1beta1.InvitationExpired().WithMessage(failed.GetFailedReason()))
+		return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+	}
+
+	// An invitation that is neither pending nor failed was accepted if its
+	// invitee is a member of the organization. Otherwise it was cancelled, for
+	// example in the GitHub web UI, and is sent again.
+	accepted, err := c.accepted(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	if !accepted {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	cr.Status.AtProvider.State = v1beta1.InvitationStateAccepted
+	cr.SetConditions(xpv1.Available())
+	return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+}
+
+// accepted returns true if the invitee of the supplied OrganizationInvitation
+// is an active member of the organization. An invitee who was invited by
+// email is only known once their login was observed while the invitation was
+// pending.
+func (c *external) accepted(ctx context.Context, cr *v1beta1.OrganizationInvitation) (bool, error) {
+	login := cr.Status.AtProvider.Login
+	if id := cr.Spec.ForProvider.InviteeID; login == "" && id != nil {
+		u, _, err := c.service.Users.GetByID(ctx, *id)
+		if kcgitclient.IsNotFound(err) {
+			return false, nil
+		}
+		if err != nil {
+			return false, errors.Wrap(err, errGetInvitee)
+		}
+		login = u.GetLogin()
+	}
+	if login == "" {
+		return false, nil
+	}
+	m, _, err := c.service.Organizations.GetOrgMembership(ctx, login, c.org)
+	if kcgitclient.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrap(err, errGetMembership)
+	}
+	return m.GetState() == "active", nil
+}
+
+// observe the supplied invitation, in the supplied state.
+func observe(cr *v1beta1.OrganizationInvitation, i *github.Invitation, state string) {
+	cr.Status.AtProvider = v1beta1.OrganizationInvitationObservation{
+		State:        state,
+		Login:        i.GetLogin(),
+		FailedReason: i.GetFailedReason(),
+	}
+	if i.CreatedAt != nil {
+		cr.Status.AtProvider.CreatedAt = &metav1.Time{Time: *i.CreatedAt}
+	}
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1beta1.OrganizationInvitation)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotInvitation)
+	}
+
+	p := cr.Spec.ForProvider
+	if (p.Email == nil) == (p.InviteeID == nil) {
+		return managed.ExternalCreation{}, errors.New(errInvitee)
+	}
+
+	opts := &github.CreateOrgInvitationOptions{
+		Email:     p.Email,
+		InviteeID: p.InviteeID,
+		Role:      github.String(pointer.StringDeref(p.Role, v1beta1.InvitationRoleDirectMember)),
+		TeamID:    []int64{},
+	}
+	// Invitees are invited to teams by ID, rather than slug.
+	for _, slug := range p.Teams {
+		t, _, err := c.service.Teams.GetTeamBySlug(ctx, c.org, slug)
+		if err != nil {
+			return managed.ExternalCreation{}, errors.Wrapf(err, errFmtGetTeam, slug)
+		}
+		opts.TeamID = append(opts.TeamID, t.GetID())
+	}
+
+	i, _, err := c.service.Organizations.CreateOrgInvitation(ctx, c.org, opts)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreate)
+	}
+	meta.SetExternalName(cr, strconv.FormatInt(i.GetID(), 10))
+	return managed.ExternalCreation{}, nil
+}
+
+// Update does nothing, since an invitation cannot be changed once it is sent.
+func (c *external) Update(_ context.Context, _ resource.Managed) (managed.ExternalUpdate, error) {
+	return managed.ExternalUpdate{}, nil
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1beta1.OrganizationInvitation)
+	if !ok {
+		return errors.New(errNotInvitation)
+	}
+
+	id, err := strconv.ParseInt(meta.GetExternalName(cr), 10, 64)
+	if err != nil {
+		return nil
+	}
+	err = kcgitclient.CancelOrgInvitation(ctx, c.service, c.org, id)
+	return errors.Wrap(resource.Ignore(kcgitclient.IsNotFound, err), errCancel)
+}
@@ -0,0 +1,361 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package organizationinvitation
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/go-github/v45/github"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/hasheddan/kc-provider-github/apis/org/v1beta1"
+	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
+	"github.com/hasheddan/kc-provider-github/pkg/client/fake"
+)
+
+const (
+	org   = "crossplane"
+	slug  = "platform"
+	email = "new-hire@example.org"
+)
+
+type invitationModifier func(*v1beta1.OrganizationInvitation)
+
+func withEmail(e string) invitationModifier {
+	return func(cr *v1beta1.OrganizationInvitation) { cr.Spec.ForProvider.Email = &e }
+}
+
+func withInviteeID(id int64) invitationModifier {
+	return func(cr *v1beta1.OrganizationInvitation) { cr.Spec.ForProvider.InviteeID = &id }
+}
+
+func withTeams(t ...string) invitationModifier {
+	return func(cr *v1beta1.OrganizationInvitation) { cr.Spec.ForProvider.Teams = t }
+}
+
+func withExternalName(n string) invitationModifier {
+	return func(cr *v1beta1.OrganizationInvitation) { meta.SetExternalName(cr, n) }
+}
+
+func withDeletionTimestamp() invitationModifier {
+	return func(cr *v1beta1.OrganizationInvitation) {
+		ts := metav1.NewTime(time.Unix(0, 0))
+		cr.SetDeletionTimestamp(&ts)
+	}
+}
+
+func withObservation(o v1beta1.OrganizationInvitationObservation) invitationModifier {
+	return func(cr *v1beta1.OrganizationInvitation) { cr.Status.AtProvider = o }
+}
+
+func withConditions(c ...xpv1.Condition) invitationModifier {
+	return func(cr *v1beta1.OrganizationInvitation) { cr.SetConditions(c...) }
+}
+
+func invitation(m ...invitationModifier) *v1beta1.OrganizationInvitation {
+	cr := &v1beta1.OrganizationInvitation{
+		ObjectMeta: metav1.ObjectMeta{Name: "new-hire"},
+		Spec: v1beta1.OrganizationInvitationSpec{
+			ResourceSpec: xpv1.ResourceSpec{ProviderConfigReference: &xpv1.Reference{Name: "default"}},
+			ForProvider:  v1beta1.OrganizationInvitationParameters{Org: org},
+		},
+	}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+// newServer returns a fake server with an organization that has a team, and
+// a pending invitation of an email, whose ID is 3.
+func newServer(t *testing.T) *fake.Server {
+	t.Helper()
+	s := fake.NewServer()
+	s.AddOrg(org)
+	s.AddTeam(org, github.NewTeam{Name: slug})
+	i, _, err := s.Client().Organizations.CreateOrgInvitation(context.Background(), org, &github.CreateOrgInvitationOptions{Email: github.String(email)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if i.GetID() != 3 {
+		t.Fatalf("newServer(...): want invitation ID 3, got %d", i.GetID())
+	}
+	return s
+}
+
+func TestObserve(t *testing.T) {
+	type args struct {
+		server func(s *fake.Server)
+		mg     resource.Managed
+	}
+	type want struct {
+		mg  resource.Managed
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NotOrganizationInvitation": {
+			reason: "An error should be returned if the managed resource is not an OrganizationInvitation.",
+			args: args{
+				mg: &v1beta1.Team{},
+			},
+			want: want{
+				mg:  &v1beta1.Team{},
+				err: errors.New(errNotInvitation),
+			},
+		},
+		"NotCreated": {
+			reason: "An invitation that has yet to be created should be reported as not existing.",
+			args: args{
+				mg: invitation(withEmail(email)),
+			},
+			want: want{
+				mg: invitation(withEmail(email)),
+				o:  managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"Pending": {
+			reason: "A pending invitation should be reported as existing, but not ready.",
+			args: args{
+				mg: invitation(withEmail(email), withExternalName("3")),
+			},
+			want: want{
+				mg: invitation(withEmail(email), withExternalName("3"),
+					withObservation(v1beta1.OrganizationInvitationObservation{State: v1beta1.InvitationStatePending}),
+					withConditions(v1beta1.InvitationPending())),
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"Failed": {
+			reason: "An expired invitation should be reported as failed.",
+			args: args{
+				server: func(s *fake.Server) { s.ExpireOrgInvitation(org, email) },
+				mg:     invitation(withEmail(email), withExternalName("3")),
+			},
+			want: want{
+				mg: invitation(withEmail(email), withExternalName("3"),
+					withObservation(v1beta1.OrganizationInvitationObservation{State: v1beta1.InvitationStateFailed, FailedReason: "expired"}),
+					withConditions(v1beta1.InvitationExpired().WithMessage("expired"))),
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"Accepted": {
+			reason: "An invitation whose invitee, observed while it was pending, is a member of the organization should be reported as accepted.",
+			args: args{
+				server: func(s *fake.Server) { s.AcceptOrgInvitation(org, email, "new-hire") },
+				mg: invitation(withEmail(email), withExternalName("3"),
+					withObservation(v1beta1.OrganizationInvitationObservation{State: v1beta1.InvitationStatePending, Login: "new-hire"})),
+			},
+			want: want{
+				mg: invitation(withEmail(email), withExternalName("3"),
+					withObservation(v1beta1.OrganizationInvitationObservation{State: v1beta1.InvitationStateAccepted, Login: "new-hire"}),
+					withConditions(xpv1.Available())),
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"AcceptedByInvitee": {
+			reason: "An invitation whose invitee, invited by ID, is a member of the organization should be reported as accepted.",
+			args: args{
+				server: func(s *fake.Server) { s.AddOrgMember(org, "hubot", "member") },
+				mg:     invitation(withInviteeID(4), withExternalName("5")),
+			},
+			want: want{
+				mg: invitation(withInviteeID(4), withExternalName("5"),
+					withObservation(v1beta1.OrganizationInvitationObservation{State: v1beta1.InvitationStateAccepted}),
+					withConditions(xpv1.Available())),
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"Cancelled": {
+			reason: "An invitation that was cancelled outside the provider should be reported as not existing, so that it is sent again.",
+			args: args{
+				server: func(s *fake.Server) {
+					if err := kcgitclient.CancelOrgInvitation(context.Background(), s.Client(), org, 3); err != nil {
+						t.Fatal(err)
+					}
+				},
+				mg: invitation(withEmail(email), withExternalName("3")),
+			},
+			want: want{
+				mg: invitation(withEmail(email), withExternalName("3")),
+				o:  managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"CancelledInvitee": {
+			reason: "An invitation whose invitee is not a member of the organization should be reported as not existing.",
+			args: args{
+				server: func(s *fake.Server) { s.AddUser("hubot") },
+				mg:     invitation(withInviteeID(4), withExternalName("5")),
+			},
+			want: want{
+				mg: invitation(withInviteeID(4), withExternalName("5")),
+				o:  managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"AcceptedDeleting": {
+			reason: "An accepted invitation should be reported as not existing once it is being deleted.",
+			args: args{
+				server: func(s *fake.Server) { s.AcceptOrgInvitation(org, email, "new-hire") },
+				mg:     invitation(withEmail(email), withExternalName("3"), withDeletionTimestamp()),
+			},
+			want: want{
+				mg: invitation(withEmail(email), withExternalName("3"), withDeletionTimestamp()),
+				o:  managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := newServer(t)
+			defer s.Close()
+			if tc.args.server != nil {
+				tc.args.server(s)
+			}
+
+			e := &external{service: s.Client(), org: org}
+			got, err := e.Observe(context.Background(), tc.args.mg)
+			if diff := fake.DiffErrors(tc.want.err, err); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			// The fake server sends invitations at the time they are created.
+			if diff := cmp.Diff(tc.want.mg, tc.args.mg, test.EquateConditions(), cmpopts.IgnoreFields(v1beta1.OrganizationInvitationObservation{}, "CreatedAt")); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want managed resource, +got managed resource:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	type want struct {
+		invitation *github.Invitation
+		err        error
+	}
+
+	cases := map[string]struct {
+		reason string
+		mg     resource.Managed
+		key    string
+		want   want
+	}{
+		"NotOrganizationInvitation": {
+			reason: "An error should be returned if the managed resource is not an OrganizationInvitation.",
+			mg:     &v1beta1.Team{},
+			want: want{
+				err: errors.New(errNotInvitation),
+			},
+		},
+		"NoInvitee": {
+			reason: "An error should be returned if neither an email nor an invitee ID is set.",
+			mg:     invitation(),
+			want: want{
+				err: errors.New(errInvitee),
+			},
+		},
+		"Email": {
+			reason: "An invitation should be sent to the supplied email, inviting them to the supplied teams.",
+			mg:     invitation(withEmail("another-hire@example.org"), withTeams(slug)),
+			key:    "another-hire@example.org",
+			want: want{
+				invitation: &github.Invitation{
+					ID:        github.Int64(5),
+					Email:     github.String("another-hire@example.org"),
+					Role:      github.String(v1beta1.InvitationRoleDirectMember),
+					TeamCount: github.Int(1),
+				},
+			},
+		},
+		"InviteeID": {
+			reason: "An invitation should be sent to the supplied user.",
+			mg:     invitation(withInviteeID(4)),
+			key:    "hubot",
+			want: want{
+				invitation: &github.Invitation{
+					ID:        github.Int64(5),
+					Login:     github.String("hubot"),
+					Role:      github.String(v1beta1.InvitationRoleDirectMember),
+					TeamCount: github.Int(0),
+				},
+			},
+		},
+		"TeamDoesNotExist": {
+			reason: "An error should be returned if a team does not exist.",
+			mg:     invitation(withEmail("another-hire@example.org"), withTeams("nope")),
+			want: want{
+				err: cmpopts.AnyError,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := newServer(t)
+			defer s.Close()
+			s.AddUser("hubot")
+
+			e := &external{service: s.Client(), org: org}
+			_, err := e.Create(context.Background(), tc.mg)
+			if diff := fake.DiffErrors(tc.want.err, err); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+
+			var got *github.Invitation
+			if i, ok := s.OrgInvitation(org, tc.key); ok {
+				got = &i
+				if diff := cmp.Diff(strconv.FormatInt(i.GetID(), 10), meta.GetExternalName(tc.mg)); diff != "" {
+					t.Errorf("\n%s\ne.Create(...): the external name should be the invitation's ID: -want, +got:\n%s\n", tc.reason, diff)
+				}
+			}
+			if diff := cmp.Diff(tc.want.invitation, got, cmpopts.IgnoreFields(github.Invitation{}, "CreatedAt")); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want invitation, +got invitation:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	s := newServer(t)
+	defer s.Close()
+
+	e := &external{service: s.Client(), org: org}
+	if err := e.Delete(context.Background(), invitation(withEmail(email), withExternalName("3"))); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := s.OrgInvitation(org, email); ok {
+		t.Errorf("e.Delete(...): the invitation should be cancelled")
+	}
+}
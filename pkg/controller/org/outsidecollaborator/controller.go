@@ -0,0 +1,179 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package outsidecollaborator
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/go-github/v45/github"
+	"github.com/pkg/errors"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/hasheddan/kc-provider-github/apis/org/v1beta1"
+	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/options"
+)
+
+const (
+	errNotOutsideCollaborator = "managed resource is not an OutsideCollaborator custom resource"
+	errCreateService          = "failed to create client service"
+	errList                   = "cannot list outside collaborators"
+	errConvert                = "cannot convert member to outside collaborator"
+	errRemove                 = "cannot remove outside collaborator"
+)
+
+// SetupOutsideCollaborator adds a controller that reconciles
+// OutsideCollaborator managed resources.
+func SetupOutsideCollaborator(mgr ctrl.Manager, o options.Options) error {
+	name := managed.ControllerName(v1beta1.OutsideCollaboratorGroupKind)
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1beta1.OutsideCollaboratorGroupVersionKind),
+		managed.WithExternalConnecter(&connector{kube: mgr.GetClient()}),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1beta1.OutsideCollaborator{}).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect produces an ExternalClient that uses the credentials of the managed
+// resource's ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1beta1.OutsideCollaborator)
+	if !ok {
+		return nil, errors.New(errNotOutsideCollaborator)
+	}
+	svc, err := kcgitclient.UseProviderConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errCreateService)
+	}
+	org, err := kcgitclient.Organization(ctx, c.kube, mg, cr.Spec.ForProvider.Org)
+	if err != nil {
+		return nil, err
+	}
+	return &external{service: svc, org: org}, nil
+}
+
+// An ExternalClient observes, converts, and removes an outside collaborator of
+// an organization.
+type external struct {
+	service *github.Client
+
+	// The organization of the managed resource, which may be the default
+	// organization of its ProviderConfig.
+	org string
+}
+
+// find the outside collaborator with the supplied login, paging through the
+// organization's outside collaborators. It returns nil if there is no such
+// outside collaborator. Logins are case-insensitive.
+func (c *external) find(ctx context.Context, login string) (*github.User, error) {
+	opts := &github.ListOutsideCollaboratorsOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		l, rsp, err := c.service.Organizations.ListOutsideCollaborators(ctx, c.org, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, u := range l {
+			if strings.EqualFold(u.GetLogin(), login) {
+				return u, nil
+			}
+		}
+		if rsp.NextPage == 0 {
+			return nil, nil
+		}
+		opts.Page = rsp.NextPage
+	}
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1beta1.OutsideCollaborator)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotOutsideCollaborator)
+	}
+
+	// Unless it removes the collaborator, deleting the OutsideCollaborator
+	// leaves them as they are, so it no longer exists once it is being
+	// deleted.
+	if meta.WasDeleted(cr) && !pointer.BoolDeref(cr.Spec.ForProvider.RemoveOnDelete, false) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	u, err := c.find(ctx, cr.Spec.ForProvider.User)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errList)
+	}
+	if u == nil {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	cr.Status.AtProvider.ID = u.GetID()
+	cr.SetConditions(xpv1.Available())
+	return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+}
+
+// Create converts the user, who must be a member of the organization, to an
+// outside collaborator. They lose their membership of the organization's
+// teams, but keep access to the repositories they were granted it through.
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1beta1.OutsideCollaborator)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotOutsideCollaborator)
+	}
+	_, err := c.service.Organizations.ConvertMemberToOutsideCollaborator(ctx, c.org, cr.Spec.ForProvider.User)
+	return managed.ExternalCreation{}, errors.Wrap(err, errConvert)
+}
+
+// Update is never called, since an outside collaborator is always up to date.
+func (c *external) Update(_ context.Context, _ resource.Managed) (managed.ExternalUpdate, error) {
+	return managed.ExternalUpdate{}, nil
+}
+
+// Delete removes the outside collaborator from all of the organization's
+// repositories if removeOnDelete is set. Otherwise they keep their access.
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1beta1.OutsideCollaborator)
+	if !ok {
+		return errors.New(errNotOutsideCollaborator)
+	}
+	if !pointer.BoolDeref(cr.Spec.ForProvider.RemoveOnDelete, false) {
+		return nil
+	}
+	_, err := c.service.Organizations.RemoveOutsideCollaborator(ctx, c.org, cr.Spec.ForProvider.User)
+	return errors.Wrap(resource.Ignore(kcgitclient.IsNotFound, err), errRemove)
+}
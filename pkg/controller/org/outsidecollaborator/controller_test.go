@@ -0,0 +1,257 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package outsidecollaborator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/go-github/v45/github"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/hasheddan/kc-provider-github/apis/org/v1beta1"
+	"github.com/hasheddan/kc-provider-github/pkg/client/fake"
+)
+
+const (
+	org  = "crossplane"
+	team = "platform"
+)
+
+type collaboratorModifier func(*v1beta1.OutsideCollaborator)
+
+func withID(id int64) collaboratorModifier {
+	return func(cr *v1beta1.OutsideCollaborator) { cr.Status.AtProvider.ID = id }
+}
+
+func withConditions(c ...xpv1.Condition) collaboratorModifier {
+	return func(cr *v1beta1.OutsideCollaborator) { cr.SetConditions(c...) }
+}
+
+func withRemoveOnDelete() collaboratorModifier {
+	return func(cr *v1beta1.OutsideCollaborator) { cr.Spec.ForProvider.RemoveOnDelete = pointer.Bool(true) }
+}
+
+func withDeletionTimestamp() collaboratorModifier {
+	return func(cr *v1beta1.OutsideCollaborator) {
+		ts := metav1.NewTime(time.Unix(0, 0))
+		cr.SetDeletionTimestamp(&ts)
+	}
+}
+
+func collaborator(user string, m ...collaboratorModifier) *v1beta1.OutsideCollaborator {
+	cr := &v1beta1.OutsideCollaborator{
+		ObjectMeta: metav1.ObjectMeta{Name: user},
+		Spec: v1beta1.OutsideCollaboratorSpec{
+			ResourceSpec: xpv1.ResourceSpec{ProviderConfigReference: &xpv1.Reference{Name: "default"}},
+			ForProvider:  v1beta1.OutsideCollaboratorParameters{Org: org, User: user},
+		},
+	}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+// newServer returns a fake server with an organization, a member of one of its
+// teams whose login is member, and an outside collaborator whose login is
+// outsider and whose ID is 4.
+func newServer() *fake.Server {
+	s := fake.NewServer()
+	s.AddOrg(org)
+	s.AddTeam(org, github.NewTeam{Name: team})
+	s.AddOrgMember(org, "member", "member")
+	s.AddTeamMember(org, team, "member", "member", "active")
+	s.AddOutsideCollaborator(org, "outsider")
+	return s
+}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		mg  resource.Managed
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		mg     resource.Managed
+		want   want
+	}{
+		"NotOutsideCollaborator": {
+			reason: "An error should be returned if the managed resource is not an OutsideCollaborator.",
+			mg:     &v1beta1.Team{},
+			want: want{
+				mg:  &v1beta1.Team{},
+				err: errors.New(errNotOutsideCollaborator),
+			},
+		},
+		"Member": {
+			reason: "A member of the organization is not yet an outside collaborator.",
+			mg:     collaborator("member"),
+			want: want{
+				mg: collaborator("member"),
+				o:  managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"OutsideCollaborator": {
+			reason: "An outside collaborator should be reported as existing and available.",
+			mg:     collaborator("outsider"),
+			want: want{
+				mg: collaborator("outsider", withID(4), withConditions(xpv1.Available())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"MixedCase": {
+			reason: "An outside collaborator should be found regardless of the case of their login.",
+			mg:     collaborator("Outsider"),
+			want: want{
+				mg: collaborator("Outsider", withID(4), withConditions(xpv1.Available())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"Deleting": {
+			reason: "An outside collaborator who is not removed on delete should no longer exist once the OutsideCollaborator is being deleted.",
+			mg:     collaborator("outsider", withDeletionTimestamp()),
+			want: want{
+				mg: collaborator("outsider", withDeletionTimestamp()),
+				o:  managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"DeletingRemoveOnDelete": {
+			reason: "An outside collaborator who is removed on delete should exist until they are removed.",
+			mg:     collaborator("outsider", withRemoveOnDelete(), withDeletionTimestamp()),
+			want: want{
+				mg: collaborator("outsider", withRemoveOnDelete(), withDeletionTimestamp(), withID(4), withConditions(xpv1.Available())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := newServer()
+			defer s.Close()
+
+			got, err := (&external{service: s.Client(), org: org}).Observe(context.Background(), tc.mg)
+			if diff := fake.DiffErrors(tc.want.err, err); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want managed resource, +got managed resource:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		user   string
+		err    error
+	}{
+		"Member": {
+			reason: "A member of the organization should be converted to an outside collaborator, and removed from its teams.",
+			user:   "member",
+		},
+		"NotMember": {
+			reason: "An error should be returned if the user is not a member of the organization.",
+			user:   "nope",
+			err:    cmpopts.AnyError,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := newServer()
+			defer s.Close()
+
+			_, err := (&external{service: s.Client(), org: org}).Create(context.Background(), collaborator(tc.user))
+			if diff := fake.DiffErrors(tc.err, err); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if tc.err != nil {
+				return
+			}
+			if !s.IsOutsideCollaborator(org, tc.user) {
+				t.Errorf("\n%s\ne.Create(...): user is not an outside collaborator", tc.reason)
+			}
+			if _, ok := s.OrgMembership(org, tc.user); ok {
+				t.Errorf("\n%s\ne.Create(...): user is still a member of the organization", tc.reason)
+			}
+			if _, ok := s.TeamMembership(org, team, tc.user); ok {
+				t.Errorf("\n%s\ne.Create(...): user is still a member of the team", tc.reason)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	type want struct {
+		err          error
+		collaborator bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		mg     *v1beta1.OutsideCollaborator
+		want   want
+	}{
+		"OutsideCollaborator": {
+			reason: "An outside collaborator should keep their access unless they are removed on delete.",
+			mg:     collaborator("outsider"),
+			want:   want{collaborator: true},
+		},
+		"RemoveOnDelete": {
+			reason: "An outside collaborator who is removed on delete should be removed from the organization.",
+			mg:     collaborator("outsider", withRemoveOnDelete()),
+		},
+		"Member": {
+			reason: "An error should be returned if the user is a member of the organization, who cannot be removed as an outside collaborator.",
+			mg:     collaborator("member", withRemoveOnDelete()),
+			want:   want{err: cmpopts.AnyError},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := newServer()
+			defer s.Close()
+
+			err := (&external{service: s.Client(), org: org}).Delete(context.Background(), tc.mg)
+			if diff := fake.DiffErrors(tc.want.err, err); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if got := s.IsOutsideCollaborator(org, tc.mg.Spec.ForProvider.User); got != tc.want.collaborator {
+				t.Errorf("\n%s\ne.Delete(...): want outside collaborator %t, got %t", tc.reason, tc.want.collaborator, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,289 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repository
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/go-github/v45/github"
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/hasheddan/kc-provider-github/apis/org/v1beta1"
+	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/options"
+	"github.com/hasheddan/kc-provider-github/pkg/receiver"
+)
+
+const (
+	errNotRepository   = "managed resource is not a Repository custom resource"
+	errCreateService   = "failed to create client service"
+	errGetRepo         = "cannot get repository"
+	errListBranches    = "cannot list branches of repository"
+	errCreateRepo      = "cannot create repository"
+	errGenerateRepo    = "cannot generate repository from template"
+	errForkRepo        = "cannot fork repository"
+	errEditRepo        = "cannot edit repository"
+	errDeleteRepo      = "cannot delete repository"
+	errTemplateAndFork = "a repository cannot be both generated from a template and forked"
+	errFmtFullName     = "%q is not the full name of a repository, for example my-org/my-repo"
+	errListRepos       = "cannot list Repositories"
+)
+
+// SetupRepository adds a controller that reconciles Repository managed
+// resources.
+func SetupRepository(mgr ctrl.Manager, o options.Options) error {
+	name := managed.ControllerName(v1beta1.RepositoryGroupKind)
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1beta1.RepositoryGroupVersionKind),
+		managed.WithExternalConnecter(&connector{kube: mgr.GetClient()}),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+
+	b := ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1beta1.Repository{})
+	if o.Receiver != nil {
+		b = b.Watches(o.Receiver.Subscribe(mapRepositories(mgr.GetClient()), receiver.EventRepository), &handler.EnqueueRequestForObject{})
+	}
+	return b.Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// mapRepositories returns a receiver.MapFunc that maps events to the
+// Repositories whose external name is the name of the repository they
+// concern.
+func mapRepositories(c client.Reader) receiver.MapFunc {
+	return func(ctx context.Context, e receiver.Event) ([]client.Object, error) {
+		l := &v1beta1.RepositoryList{}
+		if err := c.List(ctx, l); err != nil {
+			return nil, errors.Wrap(err, errListRepos)
+		}
+		var objs []client.Object
+		for i := range l.Items {
+			r := &l.Items[i]
+			if meta.GetExternalName(r) != e.Repository {
+				continue
+			}
+			// Repositories whose organization cannot be resolved cannot be
+			// affected by the event.
+			if org, err := kcgitclient.Organization(ctx, c, r, r.Spec.ForProvider.Org); err != nil || org != e.Org {
+				continue
+			}
+			objs = append(objs, r)
+		}
+		return objs, nil
+	}
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect produces an ExternalClient that uses the credentials of the managed
+// resource's ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1beta1.Repository)
+	if !ok {
+		return nil, errors.New(errNotRepository)
+	}
+	svc, err := kcgitclient.UseProviderConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errCreateService)
+	}
+	org, err := kcgitclient.Organization(ctx, c.kube, mg, cr.Spec.ForProvider.Org)
+	if err != nil {
+		return nil, err
+	}
+	return &external{service: svc, org: org}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes a
+// repository of an organization.
+type external struct {
+	service *github.Client
+
+	// The organization of the managed resource, which may be the default
+	// organization of its ProviderConfig.
+	org string
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1beta1.Repository)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotRepository)
+	}
+	p := cr.Spec.ForProvider
+	name := meta.GetExternalName(cr)
+
+	r, _, err := c.service.Repositories.Get(ctx, c.org, name)
+	if kcgitclient.IsNotFound(err) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetRepo)
+	}
+	cr.Status.AtProvider = observation(r)
+
+	// GitHub generates and forks repositories asynchronously; the repository
+	// exists before its branches have been copied to it. It is not updated
+	// until they have been, which need only be checked until it is available.
+	copying := cr.GetCondition(xpv1.TypeReady).Reason != xpv1.ReasonAvailable
+	if copying && (p.Template != nil || p.Fork != nil) {
+		b, _, err := c.service.Repositories.ListBranches(ctx, c.org, name, &github.BranchListOptions{ListOptions: github.ListOptions{PerPage: 1}})
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errListBranches)
+		}
+		if len(b) == 0 {
+			cr.SetConditions(xpv1.Creating())
+			return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+		}
+	}
+
+	cr.SetConditions(xpv1.Available())
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: upToDate(p, r),
+	}, nil
+}
+
+// observation returns the observable fields of the supplied repository.
+func observation(r *github.Repository) v1beta1.RepositoryObservation {
+	return v1beta1.RepositoryObservation{
+		ID:            r.GetID(),
+		NodeID:        r.GetNodeID(),
+		FullName:      r.GetFullName(),
+		HTMLURL:       r.GetHTMLURL(),
+		DefaultBranch: r.GetDefaultBranch(),
+		Template:      r.GetTemplateRepository().GetFullName(),
+		Parent:        r.GetParent().GetFullName(),
+	}
+}
+
+// upToDate returns true if the supplied repository matches the supplied
+// parameters. The template or parent of a repository cannot be changed, so
+// they are not considered.
+func upToDate(p v1beta1.RepositoryParameters, r *github.Repository) bool {
+	if p.Description != nil && r.GetDescription() != *p.Description {
+		return false
+	}
+	// A fork has the visibility of the repository it forks.
+	if p.Fork == nil && p.Visibility != nil && r.GetVisibility() != *p.Visibility {
+		return false
+	}
+	return true
+}
+
+// fullName splits the supplied full name of a repository into its owner and
+// name.
+func fullName(s string) (string, string, error) {
+	parts := strings.Split(s, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", errors.Errorf(errFmtFullName, s)
+	}
+	return parts[0], parts[1], nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1beta1.Repository)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotRepository)
+	}
+	p := cr.Spec.ForProvider
+	name := meta.GetExternalName(cr)
+
+	switch {
+	case p.Template != nil && p.Fork != nil:
+		return managed.ExternalCreation{}, errors.New(errTemplateAndFork)
+
+	case p.Template != nil:
+		owner, repo, err := fullName(p.Template.Repository)
+		if err != nil {
+			return managed.ExternalCreation{}, errors.Wrap(err, errGenerateRepo)
+		}
+		// Repositories can only be generated as public or private. An
+		// internal repository is generated private, then updated, and a
+		// repository whose visibility is unset is generated private rather
+		// than exposing the template's content.
+		_, _, err = c.service.Repositories.CreateFromTemplate(ctx, owner, repo, &github.TemplateRepoRequest{
+			Name:               github.String(name),
+			Owner:              github.String(c.org),
+			Description:        p.Description,
+			IncludeAllBranches: p.Template.IncludeAllBranches,
+			Private:            github.Bool(p.Visibility == nil || *p.Visibility != v1beta1.VisibilityPublic),
+		})
+		return managed.ExternalCreation{}, errors.Wrap(err, errGenerateRepo)
+
+	case p.Fork != nil:
+		owner, repo, err := fullName(p.Fork.Repository)
+		if err != nil {
+			return managed.ExternalCreation{}, errors.Wrap(err, errForkRepo)
+		}
+		r, err := kcgitclient.CreateFork(ctx, c.service, owner, repo, c.org, name)
+		if err != nil {
+			return managed.ExternalCreation{}, errors.Wrap(err, errForkRepo)
+		}
+		// GitHub suffixes the name of the fork if the organization already
+		// has a repository of that name.
+		meta.SetExternalName(cr, r.GetName())
+		return managed.ExternalCreation{}, nil
+	}
+
+	_, _, err := c.service.Repositories.Create(ctx, c.org, &github.Repository{
+		Name:        github.String(name),
+		Description: p.Description,
+		Visibility:  p.Visibility,
+	})
+	return managed.ExternalCreation{}, errors.Wrap(err, errCreateRepo)
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1beta1.Repository)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotRepository)
+	}
+	p := cr.Spec.ForProvider
+	e := &github.Repository{Description: p.Description}
+	if p.Fork == nil {
+		e.Visibility = p.Visibility
+	}
+	_, _, err := c.service.Repositories.Edit(ctx, c.org, meta.GetExternalName(cr), e)
+	return managed.ExternalUpdate{}, errors.Wrap(err, errEditRepo)
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1beta1.Repository)
+	if !ok {
+		return errors.New(errNotRepository)
+	}
+	_, err := c.service.Repositories.Delete(ctx, c.org, meta.GetExternalName(cr))
+	return errors.Wrap(resource.Ignore(kcgitclient.IsNotFound, err), errDeleteRepo)
+}
@@ -0,0 +1,422 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repository
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/go-github/v45/github"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/hasheddan/kc-provider-github/apis/org/v1beta1"
+	"github.com/hasheddan/kc-provider-github/pkg/client/fake"
+)
+
+const org = "crossplane"
+
+type repositoryModifier func(*v1beta1.Repository)
+
+func withDescription(d string) repositoryModifier {
+	return func(cr *v1beta1.Repository) { cr.Spec.ForProvider.Description = github.String(d) }
+}
+
+func withVisibility(v string) repositoryModifier {
+	return func(cr *v1beta1.Repository) { cr.Spec.ForProvider.Visibility = github.String(v) }
+}
+
+func withTemplate(repo string, allBranches bool) repositoryModifier {
+	return func(cr *v1beta1.Repository) {
+		cr.Spec.ForProvider.Template = &v1beta1.RepositoryTemplateSource{Repository: repo, IncludeAllBranches: github.Bool(allBranches)}
+	}
+}
+
+func withFork(repo string) repositoryModifier {
+	return func(cr *v1beta1.Repository) {
+		cr.Spec.ForProvider.Fork = &v1beta1.RepositoryForkSource{Repository: repo}
+	}
+}
+
+func withExternalName(n string) repositoryModifier {
+	return func(cr *v1beta1.Repository) { meta.SetExternalName(cr, n) }
+}
+
+func withObservation(o v1beta1.RepositoryObservation) repositoryModifier {
+	return func(cr *v1beta1.Repository) { cr.Status.AtProvider = o }
+}
+
+func withConditions(c ...xpv1.Condition) repositoryModifier {
+	return func(cr *v1beta1.Repository) { cr.SetConditions(c...) }
+}
+
+func repository(name string, m ...repositoryModifier) *v1beta1.Repository {
+	cr := &v1beta1.Repository{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: v1beta1.RepositorySpec{
+			ResourceSpec: xpv1.ResourceSpec{ProviderConfigReference: &xpv1.Reference{Name: "default"}},
+			ForProvider:  v1beta1.RepositoryParameters{Org: org},
+		},
+	}
+	meta.SetExternalName(cr, name)
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+// observed returns the observation of a repository of the organization
+// with the supplied name and ID.
+func observed(name string, id int64) v1beta1.RepositoryObservation {
+	return v1beta1.RepositoryObservation{
+		ID:            id,
+		NodeID:        fmt.Sprintf("R_%d", id),
+		FullName:      org + "/" + name,
+		HTMLURL:       "https://github.com/" + org + "/" + name,
+		DefaultBranch: fake.DefaultBranch,
+	}
+}
+
+// newServer returns a fake server with an organization and three
+// repositories: template, whose ID is 2, which is a template repository with
+// a main and a develop branch; upstream, whose ID is 3; and service, whose ID
+// is 4, which was generated from template but whose branches have not yet been
+// copied.
+func newServer(t *testing.T) *fake.Server {
+	t.Helper()
+	s := fake.NewServer()
+	s.AddOrg(org)
+	s.AddRepo(org, "template")
+	s.AddBranch(org, "template", "develop")
+	s.EditRepo(org, "template", func(r *github.Repository) { r.IsTemplate = github.Bool(true) })
+	s.AddRepo(org, "upstream")
+	if _, _, err := s.Client().Repositories.CreateFromTemplate(context.Background(), org, "template", &github.TemplateRepoRequest{Name: github.String("service")}); err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		mg  resource.Managed
+		o   managed.ExternalObservation
+		err error
+	}
+
+	generated := observed("service", 4)
+	generated.Template = org + "/template"
+
+	cases := map[string]struct {
+		reason string
+		copied bool
+		mg     resource.Managed
+		want   want
+	}{
+		"NotRepository": {
+			reason: "An error should be returned if the managed resource is not a Repository.",
+			mg:     &v1beta1.Team{},
+			want: want{
+				mg:  &v1beta1.Team{},
+				err: errors.New(errNotRepository),
+			},
+		},
+		"NotFound": {
+			reason: "A repository that does not exist should be reported as not existing.",
+			mg:     repository("nope"),
+			want: want{
+				mg: repository("nope"),
+				o:  managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"UpToDate": {
+			reason: "A repository that matches its parameters should be reported as existing, available and up to date.",
+			mg:     repository("upstream", withVisibility(v1beta1.VisibilityPublic)),
+			want: want{
+				mg: repository("upstream", withVisibility(v1beta1.VisibilityPublic), withObservation(observed("upstream", 3)), withConditions(xpv1.Available())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"NeedsUpdate": {
+			reason: "A repository whose description differs from its parameters should be reported as needing an update.",
+			mg:     repository("upstream", withDescription("The upstream service.")),
+			want: want{
+				mg: repository("upstream", withDescription("The upstream service."), withObservation(observed("upstream", 3)), withConditions(xpv1.Available())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+		"Copying": {
+			reason: "A generated repository whose branches have not yet been copied should be reported as existing, up to date and creating.",
+			mg:     repository("service", withTemplate("crossplane/template", false), withDescription("A service.")),
+			want: want{
+				mg: repository("service", withTemplate("crossplane/template", false), withDescription("A service."), withObservation(generated), withConditions(xpv1.Creating())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"Copied": {
+			reason: "A generated repository whose branches have been copied should be reported as available.",
+			copied: true,
+			mg:     repository("service", withTemplate("crossplane/template", false)),
+			want: want{
+				mg: repository("service", withTemplate("crossplane/template", false), withObservation(generated), withConditions(xpv1.Available())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"Available": {
+			reason: "The branches of a generated repository that is already available should not be checked.",
+			mg:     repository("service", withTemplate("crossplane/template", false), withConditions(xpv1.Available())),
+			want: want{
+				mg: repository("service", withTemplate("crossplane/template", false), withObservation(generated), withConditions(xpv1.Available())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := newServer(t)
+			defer s.Close()
+			if tc.copied {
+				s.CopyRepo(org, "service")
+			}
+
+			got, err := (&external{service: s.Client(), org: org}).Observe(context.Background(), tc.mg)
+			if diff := fake.DiffErrors(tc.want.err, err); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want managed resource, +got managed resource:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	type want struct {
+		externalName string
+		repo         github.Repository
+		branches     []string
+		err          error
+	}
+
+	cases := map[string]struct {
+		reason string
+		mg     *v1beta1.Repository
+		want   want
+	}{
+		"Empty": {
+			reason: "A repository should be created with the supplied description and visibility.",
+			mg:     repository("api", withDescription("An API."), withVisibility(v1beta1.VisibilityInternal)),
+			want: want{
+				externalName: "api",
+				repo:         github.Repository{Description: github.String("An API."), Visibility: github.String(v1beta1.VisibilityInternal)},
+			},
+		},
+		"Template": {
+			reason: "A repository should be generated from the default branch of its template.",
+			mg:     repository("api", withTemplate("crossplane/template", false), withVisibility(v1beta1.VisibilityPrivate)),
+			want: want{
+				externalName: "api",
+				repo:         github.Repository{Visibility: github.String(v1beta1.VisibilityPrivate)},
+				branches:     []string{"main"},
+			},
+		},
+		"TemplateAllBranches": {
+			reason: "A repository should be generated from all branches of its template if requested.",
+			mg:     repository("api", withTemplate("crossplane/template", true), withVisibility(v1beta1.VisibilityPublic)),
+			want: want{
+				externalName: "api",
+				repo:         github.Repository{Visibility: github.String(v1beta1.VisibilityPublic)},
+				branches:     []string{"develop", "main"},
+			},
+		},
+		"TemplateDefaultVisibility": {
+			reason: "A repository generated from a template should be private if its visibility is unset.",
+			mg:     repository("api", withTemplate("crossplane/template", false)),
+			want: want{
+				externalName: "api",
+				repo:         github.Repository{Visibility: github.String(v1beta1.VisibilityPrivate)},
+				branches:     []string{"main"},
+			},
+		},
+		"NotTemplate": {
+			reason: "An error should be returned if the template is not a template repository.",
+			mg:     repository("api", withTemplate("crossplane/upstream", false)),
+			want: want{
+				externalName: "api",
+				err:          cmpopts.AnyError,
+			},
+		},
+		"Fork": {
+			reason: "A fork should be created with the external name.",
+			mg:     repository("api", withFork("crossplane/upstream")),
+			want: want{
+				externalName: "api",
+				repo:         github.Repository{Visibility: github.String(v1beta1.VisibilityPublic)},
+				branches:     []string{"main"},
+			},
+		},
+		"ForkNameTaken": {
+			reason: "A fork whose name GitHub suffixed because it was taken should have its external name set to its name.",
+			mg:     repository("upstream", withFork("crossplane/upstream")),
+			want: want{
+				externalName: "upstream-1",
+				repo:         github.Repository{Visibility: github.String(v1beta1.VisibilityPublic)},
+				branches:     []string{"main"},
+			},
+		},
+		"TemplateAndFork": {
+			reason: "An error should be returned if both a template and a fork are specified.",
+			mg:     repository("api", withTemplate("crossplane/template", false), withFork("crossplane/upstream")),
+			want: want{
+				externalName: "api",
+				err:          errors.New(errTemplateAndFork),
+			},
+		},
+		"InvalidFullName": {
+			reason: "An error should be returned if the fork is not the full name of a repository.",
+			mg:     repository("api", withFork("upstream")),
+			want: want{
+				externalName: "api",
+				err:          errors.Wrap(errors.Errorf(errFmtFullName, "upstream"), errForkRepo),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := newServer(t)
+			defer s.Close()
+
+			_, err := (&external{service: s.Client(), org: org}).Create(context.Background(), tc.mg)
+			if diff := fake.DiffErrors(tc.want.err, err); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			n := meta.GetExternalName(tc.mg)
+			if diff := cmp.Diff(tc.want.externalName, n); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want external name, +got external name:\n%s\n", tc.reason, diff)
+			}
+			if tc.want.err != nil {
+				return
+			}
+			r, ok := s.Repo(org, n)
+			if !ok {
+				t.Fatalf("\n%s\ne.Create(...): repository %q does not exist", tc.reason, n)
+			}
+			got := github.Repository{Description: r.Description, Visibility: r.Visibility}
+			if diff := cmp.Diff(tc.want.repo, got); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want repository, +got repository:\n%s\n", tc.reason, diff)
+			}
+
+			// Generated and forked repositories have no branches until GitHub
+			// has copied them.
+			s.CopyRepo(org, n)
+			b, _, err := s.Client().Repositories.ListBranches(context.Background(), org, n, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			branches := []string{}
+			for _, br := range b {
+				branches = append(branches, br.GetName())
+			}
+			if diff := cmp.Diff(tc.want.branches, branches, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want branches, +got branches:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		mg     *v1beta1.Repository
+		want   github.Repository
+		err    error
+	}{
+		"Edited": {
+			reason: "The description and visibility of the repository should be edited.",
+			mg:     repository("upstream", withDescription("The upstream service."), withVisibility(v1beta1.VisibilityPrivate)),
+			want:   github.Repository{Description: github.String("The upstream service."), Visibility: github.String(v1beta1.VisibilityPrivate)},
+		},
+		"NotFound": {
+			reason: "An error should be returned if the repository does not exist.",
+			mg:     repository("nope", withDescription("Nope.")),
+			err:    cmpopts.AnyError,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := newServer(t)
+			defer s.Close()
+
+			_, err := (&external{service: s.Client(), org: org}).Update(context.Background(), tc.mg)
+			if diff := fake.DiffErrors(tc.err, err); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if tc.err != nil {
+				return
+			}
+			r, _ := s.Repo(org, meta.GetExternalName(tc.mg))
+			got := github.Repository{Description: r.Description, Visibility: r.Visibility}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want repository, +got repository:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		mg     *v1beta1.Repository
+	}{
+		"Deleted": {
+			reason: "The repository should be deleted.",
+			mg:     repository("upstream"),
+		},
+		"NotFound": {
+			reason: "No error should be returned if the repository no longer exists.",
+			mg:     repository("nope"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := newServer(t)
+			defer s.Close()
+
+			if err := (&external{service: s.Client(), org: org}).Delete(context.Background(), tc.mg); err != nil {
+				t.Errorf("\n%s\ne.Delete(...): %s", tc.reason, err)
+			}
+			if _, ok := s.Repo(org, meta.GetExternalName(tc.mg)); ok {
+				t.Errorf("\n%s\ne.Delete(...): repository still exists", tc.reason)
+			}
+		})
+	}
+}
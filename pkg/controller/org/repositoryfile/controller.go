@@ -0,0 +1,271 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repositoryfile
+
+import (
+	"context"
+
+	"github.com/google/go-github/v45/github"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/hasheddan/kc-provider-github/apis/org/v1beta1"
+	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/options"
+)
+
+const (
+	errNotRepositoryFile = "managed resource is not a RepositoryFile custom resource"
+	errCreateService     = "failed to create client service"
+	errContent           = "exactly one of content or contentConfigMapRef must be set"
+	errGetConfigMap      = "cannot get ConfigMap"
+	errFmtNoKey          = "ConfigMap %s/%s has no key %q"
+	errGetFile           = "cannot get file"
+	errNotFile           = "path is not a file"
+	errCreateFile        = "cannot create file"
+	errUpdateFile        = "cannot update file"
+	errDeleteFile        = "cannot delete file"
+)
+
+// SetupRepositoryFile adds a controller that reconciles RepositoryFile
+// managed resources.
+func SetupRepositoryFile(mgr ctrl.Manager, o options.Options) error {
+	name := managed.ControllerName(v1beta1.RepositoryFileGroupKind)
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1beta1.RepositoryFileGroupVersionKind),
+		managed.WithExternalConnecter(&connector{kube: mgr.GetClient()}),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1beta1.RepositoryFile{}).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect produces an ExternalClient that uses the credentials of the managed
+// resource's ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1beta1.RepositoryFile)
+	if !ok {
+		return nil, errors.New(errNotRepositoryFile)
+	}
+	svc, err := kcgitclient.UseProviderConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errCreateService)
+	}
+	org, err := kcgitclient.Organization(ctx, c.kube, mg, cr.Spec.ForProvider.Org)
+	if err != nil {
+		return nil, err
+	}
+	return &external{kube: c.kube, service: svc, org: org}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes a file
+// in a repository.
+type external struct {
+	// The content of a file may be read from a ConfigMap.
+	kube client.Reader
+
+	service *github.Client
+
+	// The organization of the managed resource, which may be the default
+	// organization of its ProviderConfig.
+	org string
+}
+
+// content returns the desired content of the supplied RepositoryFile's file.
+func (c *external) content(ctx context.Context, p v1beta1.RepositoryFileParameters) (string, error) {
+	switch {
+	case p.Content != nil && p.ContentConfigMapRef == nil:
+		return *p.Content, nil
+	case p.ContentConfigMapRef != nil && p.Content == nil:
+		ref := p.ContentConfigMapRef
+		cm := &corev1.ConfigMap{}
+		if err := c.kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, cm); err != nil {
+			return "", errors.Wrap(err, errGetConfigMap)
+		}
+		v, ok := cm.Data[ref.Key]
+		if !ok {
+			return "", errors.Errorf(errFmtNoKey, ref.Namespace, ref.Name, ref.Key)
+		}
+		return v, nil
+	default:
+		return "", errors.New(errContent)
+	}
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1beta1.RepositoryFile)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotRepositoryFile)
+	}
+
+	p := cr.Spec.ForProvider
+	content, err := c.content(ctx, p)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	f, _, _, err := c.service.Repositories.GetContents(ctx, c.org, p.Repository, p.Path, &github.RepositoryContentGetOptions{Ref: pointer.StringDeref(p.Branch, "")})
+	if kcgitclient.IsNotFound(err) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetFile)
+	}
+	if f == nil {
+		return managed.ExternalObservation{}, errors.New(errNotFile)
+	}
+
+	cr.Status.AtProvider.SHA = f.GetSHA()
+	cr.SetConditions(xpv1.Available())
+
+	// Files are compared by their blob SHA, so their content need not be
+	// decoded. A file that was changed outside the provider is left alone
+	// unless it should be overwritten.
+	upToDate := f.GetSHA() == kcgitclient.BlobSHA([]byte(content))
+
+	// A file with the desired content is recorded as applied here, because
+	// the status Create records is not persisted.
+	if upToDate {
+		cr.Status.AtProvider.AppliedSHA = f.GetSHA()
+	}
+	modified := f.GetSHA() != cr.Status.AtProvider.AppliedSHA
+	if !upToDate && modified && !pointer.BoolDeref(p.Overwrite, true) {
+		upToDate = true
+	}
+
+	return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: upToDate}, nil
+}
+
+// fileOptions returns the options with which to commit the supplied content to
+// the supplied RepositoryFile's file, with the supplied default message.
+func fileOptions(p v1beta1.RepositoryFileParameters, content []byte, message string) *github.RepositoryContentFileOptions {
+	o := &github.RepositoryContentFileOptions{
+		Message: github.String(pointer.StringDeref(p.CommitMessage, message)),
+		Content: content,
+		Branch:  p.Branch,
+	}
+	if a := p.CommitAuthor; a != nil {
+		o.Author = &github.CommitAuthor{Name: github.String(a.Name), Email: github.String(a.Email)}
+	}
+	return o
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1beta1.RepositoryFile)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotRepositoryFile)
+	}
+
+	p := cr.Spec.ForProvider
+	content, err := c.content(ctx, p)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	rsp, _, err := c.service.Repositories.CreateFile(ctx, c.org, p.Repository, p.Path, fileOptions(p, []byte(content), "Create "+p.Path))
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateFile)
+	}
+	applied(cr, rsp)
+	return managed.ExternalCreation{}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1beta1.RepositoryFile)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotRepositoryFile)
+	}
+
+	p := cr.Spec.ForProvider
+	content, err := c.content(ctx, p)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	o := fileOptions(p, []byte(content), "Update "+p.Path)
+	// The file is updated only if it is still as it was observed.
+	o.SHA = github.String(cr.Status.AtProvider.SHA)
+	rsp, _, err := c.service.Repositories.UpdateFile(ctx, c.org, p.Repository, p.Path, o)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateFile)
+	}
+	applied(cr, rsp)
+	return managed.ExternalUpdate{}, nil
+}
+
+// applied records the file the supplied response reports was committed.
+func applied(cr *v1beta1.RepositoryFile, rsp *github.RepositoryContentResponse) {
+	cr.Status.AtProvider.SHA = rsp.GetContent().GetSHA()
+	cr.Status.AtProvider.AppliedSHA = rsp.GetContent().GetSHA()
+	cr.Status.AtProvider.CommitSHA = rsp.Commit.GetSHA()
+}
+
+// Delete deletes the file, regardless of whether it was changed outside the
+// provider. Set a deletionPolicy of Orphan to stop managing a file without
+// deleting it.
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1beta1.RepositoryFile)
+	if !ok {
+		return errors.New(errNotRepositoryFile)
+	}
+
+	p := cr.Spec.ForProvider
+	sha := cr.Status.AtProvider.SHA
+	if sha == "" {
+		// The file was not observed, for example because the RepositoryFile
+		// was deleted before it was first observed, so its SHA is read.
+		f, _, _, err := c.service.Repositories.GetContents(ctx, c.org, p.Repository, p.Path, &github.RepositoryContentGetOptions{Ref: pointer.StringDeref(p.Branch, "")})
+		if kcgitclient.IsNotFound(err) {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrap(err, errGetFile)
+		}
+		if f == nil {
+			return errors.New(errNotFile)
+		}
+		sha = f.GetSHA()
+	}
+
+	// The commit message of a RepositoryFile applies only to the commits that
+	// create or update its file.
+	o := fileOptions(p, nil, "")
+	o.Message = github.String("Delete " + p.Path)
+	o.SHA = github.String(sha)
+	_, _, err := c.service.Repositories.DeleteFile(ctx, c.org, p.Repository, p.Path, o)
+	return errors.Wrap(resource.Ignore(kcgitclient.IsNotFound, err), errDeleteFile)
+}
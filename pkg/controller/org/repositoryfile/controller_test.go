@@ -0,0 +1,419 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repositoryfile
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/go-github/v45/github"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/hasheddan/kc-provider-github/apis/org/v1beta1"
+	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
+	"github.com/hasheddan/kc-provider-github/pkg/client/fake"
+)
+
+const (
+	org     = "crossplane"
+	repo    = "infra"
+	path    = ".github/dependabot.yml"
+	content = "version: 2\n"
+)
+
+type fileModifier func(*v1beta1.RepositoryFile)
+
+func withContent(c string) fileModifier {
+	return func(cr *v1beta1.RepositoryFile) { cr.Spec.ForProvider.Content = &c }
+}
+
+func withConfigMapRef(key string) fileModifier {
+	return func(cr *v1beta1.RepositoryFile) {
+		cr.Spec.ForProvider.Content = nil
+		cr.Spec.ForProvider.ContentConfigMapRef = &v1beta1.ConfigMapKeySelector{Name: "seed", Namespace: "default", Key: key}
+	}
+}
+
+func withBranch(b string) fileModifier {
+	return func(cr *v1beta1.RepositoryFile) { cr.Spec.ForProvider.Branch = &b }
+}
+
+func withOverwrite(o bool) fileModifier {
+	return func(cr *v1beta1.RepositoryFile) { cr.Spec.ForProvider.Overwrite = &o }
+}
+
+func withObservation(o v1beta1.RepositoryFileObservation) fileModifier {
+	return func(cr *v1beta1.RepositoryFile) { cr.Status.AtProvider = o }
+}
+
+func withConditions(c ...xpv1.Condition) fileModifier {
+	return func(cr *v1beta1.RepositoryFile) { cr.SetConditions(c...) }
+}
+
+func file(m ...fileModifier) *v1beta1.RepositoryFile {
+	cr := &v1beta1.RepositoryFile{
+		ObjectMeta: metav1.ObjectMeta{Name: "dependabot"},
+		Spec: v1beta1.RepositoryFileSpec{
+			ResourceSpec: xpv1.ResourceSpec{ProviderConfigReference: &xpv1.Reference{Name: "default"}},
+			ForProvider: v1beta1.RepositoryFileParameters{
+				Org:        org,
+				Repository: repo,
+				Path:       path,
+				Content:    github.String(content),
+			},
+		},
+	}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+// kube returns a client that gets a ConfigMap whose content key holds the
+// file's content.
+func kube() client.Reader {
+	return &test.MockClient{MockGet: test.NewMockGetFn(nil, func(o client.Object) error {
+		o.(*corev1.ConfigMap).Data = map[string]string{"content": content}
+		return nil
+	})}
+}
+
+// newServer returns a fake server with an organization and a repository with
+// a release branch.
+func newServer() *fake.Server {
+	s := fake.NewServer()
+	s.AddOrg(org)
+	s.AddRepo(org, repo)
+	s.AddBranch(org, repo, "release")
+	return s
+}
+
+func TestObserve(t *testing.T) {
+	type args struct {
+		server func(s *fake.Server)
+		mg     resource.Managed
+	}
+	type want struct {
+		mg  resource.Managed
+		o   managed.ExternalObservation
+		err error
+	}
+
+	sha := kcgitclient.BlobSHA([]byte(content))
+	edited := kcgitclient.BlobSHA([]byte("edited"))
+	commit := func(c string) func(s *fake.Server) {
+		return func(s *fake.Server) { s.CommitFile(org, repo, fake.DefaultBranch, path, c) }
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NotRepositoryFile": {
+			reason: "An error should be returned if the managed resource is not a RepositoryFile.",
+			args: args{
+				mg: &v1beta1.Team{},
+			},
+			want: want{
+				mg:  &v1beta1.Team{},
+				err: errors.New(errNotRepositoryFile),
+			},
+		},
+		"NoContent": {
+			reason: "An error should be returned if neither content nor a ConfigMap is set.",
+			args: args{
+				mg: file(func(cr *v1beta1.RepositoryFile) { cr.Spec.ForProvider.Content = nil }),
+			},
+			want: want{
+				mg:  file(func(cr *v1beta1.RepositoryFile) { cr.Spec.ForProvider.Content = nil }),
+				err: errors.New(errContent),
+			},
+		},
+		"NoConfigMapKey": {
+			reason: "An error should be returned if the ConfigMap has no such key.",
+			args: args{
+				mg: file(withConfigMapRef("nope")),
+			},
+			want: want{
+				mg:  file(withConfigMapRef("nope")),
+				err: errors.Errorf(errFmtNoKey, "default", "seed", "nope"),
+			},
+		},
+		"NotFound": {
+			reason: "A file that does not exist should be reported as not existing.",
+			args: args{
+				mg: file(),
+			},
+			want: want{
+				mg: file(),
+				o:  managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"UpToDate": {
+			reason: "A file with the desired content should be reported as up to date.",
+			args: args{
+				server: commit(content),
+				mg:     file(withObservation(v1beta1.RepositoryFileObservation{AppliedSHA: sha})),
+			},
+			want: want{
+				mg: file(withObservation(v1beta1.RepositoryFileObservation{SHA: sha, AppliedSHA: sha}), withConditions(xpv1.Available())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"ConfigMap": {
+			reason: "A file with the content of a ConfigMap should be reported as up to date.",
+			args: args{
+				server: commit(content),
+				mg:     file(withConfigMapRef("content")),
+			},
+			want: want{
+				mg: file(withConfigMapRef("content"), withObservation(v1beta1.RepositoryFileObservation{SHA: sha, AppliedSHA: sha}), withConditions(xpv1.Available())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"Branch": {
+			reason: "A file should be observed on the desired branch.",
+			args: args{
+				server: func(s *fake.Server) { s.CommitFile(org, repo, "release", path, content) },
+				mg:     file(withBranch("release")),
+			},
+			want: want{
+				mg: file(withBranch("release"), withObservation(v1beta1.RepositoryFileObservation{SHA: sha, AppliedSHA: sha}), withConditions(xpv1.Available())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"ContentChanged": {
+			reason: "A file whose desired content changed should be reported as not up to date, even if it should not be overwritten.",
+			args: args{
+				server: commit("edited"),
+				mg:     file(withOverwrite(false), withObservation(v1beta1.RepositoryFileObservation{AppliedSHA: edited})),
+			},
+			want: want{
+				mg: file(withOverwrite(false), withObservation(v1beta1.RepositoryFileObservation{SHA: edited, AppliedSHA: edited}), withConditions(xpv1.Available())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+		"NotApplied": {
+			reason: "A file with the desired content should be recorded as applied, since the status recorded when it was created is not persisted.",
+			args: args{
+				server: commit(content),
+				mg:     file(withOverwrite(false)),
+			},
+			want: want{
+				mg: file(withOverwrite(false), withObservation(v1beta1.RepositoryFileObservation{SHA: sha, AppliedSHA: sha}), withConditions(xpv1.Available())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"EditedOverwrite": {
+			reason: "A file that was edited outside the provider should be reported as not up to date.",
+			args: args{
+				server: commit("edited"),
+				mg:     file(withObservation(v1beta1.RepositoryFileObservation{AppliedSHA: sha})),
+			},
+			want: want{
+				mg: file(withObservation(v1beta1.RepositoryFileObservation{SHA: edited, AppliedSHA: sha}), withConditions(xpv1.Available())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+		"EditedLeftAlone": {
+			reason: "A file that was edited outside the provider should be reported as up to date if it should not be overwritten.",
+			args: args{
+				server: commit("edited"),
+				mg:     file(withOverwrite(false), withObservation(v1beta1.RepositoryFileObservation{AppliedSHA: sha})),
+			},
+			want: want{
+				mg: file(withOverwrite(false), withObservation(v1beta1.RepositoryFileObservation{SHA: edited, AppliedSHA: sha}), withConditions(xpv1.Available())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := newServer()
+			defer s.Close()
+			if tc.args.server != nil {
+				tc.args.server(s)
+			}
+
+			e := &external{kube: kube(), service: s.Client(), org: org}
+			got, err := e.Observe(context.Background(), tc.args.mg)
+			if diff := fake.DiffErrors(tc.want.err, err); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.args.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want managed resource, +got managed resource:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	s := newServer()
+	defer s.Close()
+
+	cr := file(withBranch("release"), func(cr *v1beta1.RepositoryFile) {
+		cr.Spec.ForProvider.CommitMessage = github.String("Seed dependabot configuration")
+		cr.Spec.ForProvider.CommitAuthor = &v1beta1.CommitAuthor{Name: "Crossplane", Email: "crossplane@example.org"}
+	})
+	if _, err := (&external{kube: kube(), service: s.Client(), org: org}).Create(context.Background(), cr); err != nil {
+		t.Fatal(err)
+	}
+
+	sha := kcgitclient.BlobSHA([]byte(content))
+	want := fake.RepoFile{
+		Content: content,
+		SHA:     sha,
+		Message: "Seed dependabot configuration",
+		Author:  &github.CommitAuthor{Name: github.String("Crossplane"), Email: github.String("crossplane@example.org")},
+	}
+	got, _ := s.File(org, repo, "release", path)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("e.Create(...): -want file, +got file:\n%s\n", diff)
+	}
+	if cr.Status.AtProvider.AppliedSHA != sha || cr.Status.AtProvider.CommitSHA == "" {
+		t.Errorf("e.Create(...): should record the committed file: got %+v", cr.Status.AtProvider)
+	}
+}
+
+func TestChangeAfterCreate(t *testing.T) {
+	s := newServer()
+	defer s.Close()
+	e := &external{kube: kube(), service: s.Client(), org: org}
+
+	if _, err := e.Create(context.Background(), file(withOverwrite(false))); err != nil {
+		t.Fatal(err)
+	}
+
+	// The managed reconciler does not persist the status recorded by Create,
+	// so the file is next observed by a RepositoryFile without one.
+	cr := file(withOverwrite(false))
+	if _, err := e.Observe(context.Background(), cr); err != nil {
+		t.Fatal(err)
+	}
+
+	cr.Spec.ForProvider.Content = github.String("version: 3\n")
+	o, err := e.Observe(context.Background(), cr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.ResourceUpToDate {
+		t.Fatal("e.Observe(...): a file whose desired content changed should not be up to date")
+	}
+	if _, err := e.Update(context.Background(), cr); err != nil {
+		t.Fatal(err)
+	}
+	got, _ := s.File(org, repo, fake.DefaultBranch, path)
+	if diff := cmp.Diff("version: 3\n", got.Content); diff != "" {
+		t.Errorf("e.Update(...): -want content, +got content:\n%s\n", diff)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		sha    func(committed string) string
+		want   string
+		err    error
+	}{
+		"Updated": {
+			reason: "A file that is as it was observed should be updated.",
+			sha:    func(committed string) string { return committed },
+			want:   content,
+		},
+		"Conflict": {
+			reason: "An error should be returned if the file changed since it was observed.",
+			sha:    func(string) string { return kcgitclient.BlobSHA([]byte("stale")) },
+			want:   "edited",
+			err:    cmpopts.AnyError,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := newServer()
+			defer s.Close()
+			committed := s.CommitFile(org, repo, fake.DefaultBranch, path, "edited")
+
+			cr := file(withObservation(v1beta1.RepositoryFileObservation{SHA: tc.sha(committed)}))
+			_, err := (&external{kube: kube(), service: s.Client(), org: org}).Update(context.Background(), cr)
+			if diff := fake.DiffErrors(tc.err, err); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			got, _ := s.File(org, repo, fake.DefaultBranch, path)
+			if diff := cmp.Diff(tc.want, got.Content); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want content, +got content:\n%s\n", tc.reason, diff)
+			}
+			if tc.err == nil && got.Message != "Update "+path {
+				t.Errorf("\n%s\ne.Update(...): commit message should default to %q, got %q", tc.reason, "Update "+path, got.Message)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		server func(s *fake.Server) string
+	}{
+		"Deleted": {
+			reason: "The file should be deleted.",
+			server: func(s *fake.Server) string { return s.CommitFile(org, repo, fake.DefaultBranch, path, content) },
+		},
+		"NotObserved": {
+			reason: "A file that was never observed should be read, then deleted.",
+			server: func(s *fake.Server) string {
+				s.CommitFile(org, repo, fake.DefaultBranch, path, content)
+				return ""
+			},
+		},
+		"AlreadyDeleted": {
+			reason: "No error should be returned if the file was already deleted.",
+			server: func(s *fake.Server) string { return "" },
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := newServer()
+			defer s.Close()
+			sha := tc.server(s)
+
+			cr := file(withObservation(v1beta1.RepositoryFileObservation{SHA: sha}))
+			if err := (&external{kube: kube(), service: s.Client(), org: org}).Delete(context.Background(), cr); err != nil {
+				t.Errorf("\n%s\ne.Delete(...): %s", tc.reason, err)
+			}
+			if _, ok := s.File(org, repo, fake.DefaultBranch, path); ok {
+				t.Errorf("\n%s\ne.Delete(...): file still exists", tc.reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package securitymanagerteam
+
+import (
+	"context"
+
+	"github.com/google/go-github/v45/github"
+	"github.com/pkg/errors"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/hasheddan/kc-provider-github/apis/org/v1beta1"
+	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/options"
+)
+
+const (
+	errNotSecurityManagerTeam = "managed resource is not a SecurityManagerTeam custom resource"
+	errCreateService          = "failed to create client service"
+	errList                   = "cannot list security manager teams"
+	errAdd                    = "cannot assign the security manager role to the team"
+	errRemove                 = "cannot remove the security manager role from the team"
+)
+
+// SetupSecurityManagerTeam adds a controller that reconciles
+// SecurityManagerTeam managed resources.
+func SetupSecurityManagerTeam(mgr ctrl.Manager, o options.Options) error {
+	name := managed.ControllerName(v1beta1.SecurityManagerTeamGroupKind)
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1beta1.SecurityManagerTeamGroupVersionKind),
+		managed.WithExternalConnecter(&connector{kube: mgr.GetClient()}),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1beta1.SecurityManagerTeam{}).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect produces an ExternalClient that uses the credentials of the managed
+// resource's ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1beta1.SecurityManagerTeam)
+	if !ok {
+		return nil, errors.New(errNotSecurityManagerTeam)
+	}
+	svc, err := kcgitclient.UseProviderConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errCreateService)
+	}
+	org, err := kcgitclient.Organization(ctx, c.kube, mg, cr.Spec.ForProvider.Org)
+	if err != nil {
+		return nil, err
+	}
+	return &external{service: svc, org: org}, nil
+}
+
+// An ExternalClient assigns and removes the security manager role of an
+// organization to and from a team.
+type external struct {
+	service *github.Client
+
+	// The organization of the managed resource, which may be the default
+	// organization of its ProviderConfig.
+	org string
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1beta1.SecurityManagerTeam)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotSecurityManagerTeam)
+	}
+
+	teams, err := kcgitclient.ListSecurityManagerTeams(ctx, c.service, c.org)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errList)
+	}
+	slug := pointer.StringDeref(cr.Spec.ForProvider.Team, "")
+	for _, t := range teams {
+		if t.GetSlug() != slug {
+			continue
+		}
+		cr.Status.AtProvider.ID = t.GetID()
+		cr.SetConditions(xpv1.Available())
+		return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+	}
+	return managed.ExternalObservation{ResourceExists: false}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1beta1.SecurityManagerTeam)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotSecurityManagerTeam)
+	}
+	err := kcgitclient.AddSecurityManagerTeam(ctx, c.service, c.org, pointer.StringDeref(cr.Spec.ForProvider.Team, ""))
+	return managed.ExternalCreation{}, errors.Wrap(err, errAdd)
+}
+
+// Update is never called, since a team that is assigned the security manager
+// role is always up to date.
+func (c *external) Update(_ context.Context, _ resource.Managed) (managed.ExternalUpdate, error) {
+	return managed.ExternalUpdate{}, nil
+}
+
+// Delete removes the security manager role from the team. A team that no
+// longer exists has no role to remove.
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1beta1.SecurityManagerTeam)
+	if !ok {
+		return errors.New(errNotSecurityManagerTeam)
+	}
+	err := kcgitclient.RemoveSecurityManagerTeam(ctx, c.service, c.org, pointer.StringDeref(cr.Spec.ForProvider.Team, ""))
+	return errors.Wrap(resource.Ignore(kcgitclient.IsNotFound, err), errRemove)
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package securitymanagerteam
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/go-github/v45/github"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/hasheddan/kc-provider-github/apis/org/v1beta1"
+	"github.com/hasheddan/kc-provider-github/pkg/client/fake"
+)
+
+const org = "crossplane"
+
+type smtModifier func(*v1beta1.SecurityManagerTeam)
+
+func withID(id int64) smtModifier {
+	return func(cr *v1beta1.SecurityManagerTeam) { cr.Status.AtProvider.ID = id }
+}
+
+func withConditions(c ...xpv1.Condition) smtModifier {
+	return func(cr *v1beta1.SecurityManagerTeam) { cr.SetConditions(c...) }
+}
+
+func securityManagerTeam(team string, m ...smtModifier) *v1beta1.SecurityManagerTeam {
+	cr := &v1beta1.SecurityManagerTeam{
+		ObjectMeta: metav1.ObjectMeta{Name: team},
+		Spec: v1beta1.SecurityManagerTeamSpec{
+			ResourceSpec: xpv1.ResourceSpec{ProviderConfigReference: &xpv1.Reference{Name: "default"}},
+			ForProvider:  v1beta1.SecurityManagerTeamParameters{Org: org, Team: github.String(team)},
+		},
+	}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+// newServer returns a fake server with an organization and two teams:
+// security, whose ID is 2 and which is assigned the security manager role,
+// and platform, whose ID is 3 and which is not.
+func newServer() *fake.Server {
+	s := fake.NewServer()
+	s.AddOrg(org)
+	s.AddTeam(org, github.NewTeam{Name: "security"})
+	s.AddTeam(org, github.NewTeam{Name: "platform"})
+	s.SetSecurityManagerTeam(org, "security", true)
+	return s
+}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		mg  resource.Managed
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		mg     resource.Managed
+		want   want
+	}{
+		"NotSecurityManagerTeam": {
+			reason: "An error should be returned if the managed resource is not a SecurityManagerTeam.",
+			mg:     &v1beta1.Team{},
+			want: want{
+				mg:  &v1beta1.Team{},
+				err: errors.New(errNotSecurityManagerTeam),
+			},
+		},
+		"Assigned": {
+			reason: "A team that is assigned the security manager role should be reported as existing and available.",
+			mg:     securityManagerTeam("security"),
+			want: want{
+				mg: securityManagerTeam("security", withID(2), withConditions(xpv1.Available())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"NotAssigned": {
+			reason: "A team that is not assigned the security manager role should be reported as not existing.",
+			mg:     securityManagerTeam("platform"),
+			want: want{
+				mg: securityManagerTeam("platform"),
+				o:  managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := newServer()
+			defer s.Close()
+
+			got, err := (&external{service: s.Client(), org: org}).Observe(context.Background(), tc.mg)
+			if diff := fake.DiffErrors(tc.want.err, err); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want managed resource, +got managed resource:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		team   string
+		err    error
+	}{
+		"Assigned": {
+			reason: "The security manager role should be assigned to the team.",
+			team:   "platform",
+		},
+		"NoSuchTeam": {
+			reason: "An error should be returned if the team does not exist.",
+			team:   "nope",
+			err:    cmpopts.AnyError,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := newServer()
+			defer s.Close()
+
+			_, err := (&external{service: s.Client(), org: org}).Create(context.Background(), securityManagerTeam(tc.team))
+			if diff := fake.DiffErrors(tc.err, err); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if tc.err == nil && !s.IsSecurityManagerTeam(org, tc.team) {
+				t.Errorf("\n%s\ne.Create(...): team is not assigned the security manager role", tc.reason)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		team   string
+	}{
+		"Removed": {
+			reason: "The security manager role should be removed from the team.",
+			team:   "security",
+		},
+		"NoSuchTeam": {
+			reason: "No error should be returned if the team no longer exists.",
+			team:   "nope",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := newServer()
+			defer s.Close()
+
+			if err := (&external{service: s.Client(), org: org}).Delete(context.Background(), securityManagerTeam(tc.team)); err != nil {
+				t.Errorf("\n%s\ne.Delete(...): %s", tc.reason, err)
+			}
+			if s.IsSecurityManagerTeam(org, tc.team) {
+				t.Errorf("\n%s\ne.Delete(...): team is still assigned the security manager role", tc.reason)
+			}
+		})
+	}
+}
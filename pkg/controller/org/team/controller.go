@@ -22,41 +22,80 @@ import (
 
 	"github.com/google/go-github/v45/github"
 	"github.com/pkg/errors"
+	"k8s.io/utils/pointer"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 
 	"github.com/crossplane/crossplane-runtime/pkg/event"
-	"github.com/crossplane/crossplane-runtime/pkg/logging"
 	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
-	"github.com/hasheddan/kc-provider-github/apis/org/v1alpha1"
+	"github.com/hasheddan/kc-provider-github/apis/org/v1beta1"
 	apisv1alpha1 "github.com/hasheddan/kc-provider-github/apis/v1alpha1"
 	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/options"
+	"github.com/hasheddan/kc-provider-github/pkg/receiver"
 )
 
 const (
 	errNotTeam       = "managed resource is not a Team custom resource"
 	errCreateService = "failed to create client service"
+	errListTeams     = "cannot list Teams"
+	errGetParent     = "cannot get parent team"
+	errUpdateSlug    = "cannot update external name to the team's new slug"
+	errLDAPDN        = "ldapDN can only be set when the ProviderConfig targets a GitHub Enterprise Server"
 )
 
 // Setup adds a controller that reconciles MyType managed resources.
-func SetupTeam(mgr ctrl.Manager, l logging.Logger) error {
-	name := managed.ControllerName(v1alpha1.TeamGroupKind)
+func SetupTeam(mgr ctrl.Manager, o options.Options) error {
+	name := managed.ControllerName(v1beta1.TeamGroupKind)
 
 	r := managed.NewReconciler(mgr,
-		resource.ManagedKind(v1alpha1.TeamGroupVersionKind),
+		resource.ManagedKind(v1beta1.TeamGroupVersionKind),
 		managed.WithExternalConnecter(&connector{
 			kube:  mgr.GetClient(),
-			usage: resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{})}),
-		managed.WithLogger(l.WithValues("controller", name)),
+			usage: resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+			cache: kcgitclient.NewObservationCache(kcgitclient.DefaultObservationTTL)}),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
 		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
 
-	return ctrl.NewControllerManagedBy(mgr).
+	b := ctrl.NewControllerManagedBy(mgr).
 		Named(name).
-		For(&v1alpha1.Team{}).
-		Complete(r)
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1beta1.Team{})
+	if o.Receiver != nil {
+		b = b.Watches(o.Receiver.Subscribe(mapTeams(mgr.GetClient()), receiver.EventTeam), &handler.EnqueueRequestForObject{})
+	}
+	return b.Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// mapTeams returns a receiver.MapFunc that maps events to the Teams whose
+// external name is the slug of the team they concern.
+func mapTeams(c client.Reader) receiver.MapFunc {
+	return func(ctx context.Context, e receiver.Event) ([]client.Object, error) {
+		l := &v1beta1.TeamList{}
+		if err := c.List(ctx, l); err != nil {
+			return nil, errors.Wrap(err, errListTeams)
+		}
+		var objs []client.Object
+		for i := range l.Items {
+			t := &l.Items[i]
+			if meta.GetExternalName(t) != e.Team {
+				continue
+			}
+			// Teams whose organization cannot be resolved cannot be
+			// affected by the event.
+			if org, err := kcgitclient.Organization(ctx, c, t, t.Spec.ForProvider.Org); err != nil || org != e.Org {
+				continue
+			}
+			objs = append(objs, t)
+		}
+		return objs, nil
+	}
 }
 
 // A connector is expected to produce an ExternalClient when its Connect method
@@ -64,6 +103,7 @@ func SetupTeam(mgr ctrl.Manager, l logging.Logger) error {
 type connector struct {
 	kube  client.Client
 	usage resource.Tracker
+	cache *kcgitclient.ObservationCache
 }
 
 // Connect typically produces an ExternalClient by:
@@ -72,7 +112,7 @@ type connector struct {
 // 3. Getting the ProviderConfig's credentials secret.
 // 4. Using the credentials secret to form a client.
 func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
-	_, ok := mg.(*v1alpha1.Team)
+	cr, ok := mg.(*v1beta1.Team)
 	if !ok {
 		return nil, errors.New(errNotTeam)
 	}
@@ -80,36 +120,91 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 	if err != nil {
 		return nil, errors.Wrap(err, errCreateService)
 	}
-	return &external{service: svc}, nil
+	org, err := kcgitclient.Organization(ctx, c.kube, mg, cr.Spec.ForProvider.Org)
+	if err != nil {
+		return nil, err
+	}
+	return &external{
+		kube:       c.kube,
+		service:    svc,
+		enterprise: kcgitclient.IsEnterpriseServer(svc),
+		org:        org,
+		graphql:    kcgitclient.NewGraphQLClient(svc),
+		cache:      c.cache,
+		scope:      mg.GetProviderConfigReference().Name,
+	}, nil
 }
 
 // An ExternalClient observes, then either creates, updates, or deletes an
 // external resource to ensure it reflects the managed resource's desired state.
 type external struct {
+	// Used to record the new slug of a renamed team.
+	kube client.Client
+
 	// A 'client' used to connect to the external resource API. In practice this
 	// would be something like an AWS SDK client.
 	service *github.Client
+
+	// Whether the service is a GitHub Enterprise Server, which supports
+	// synchronizing teams with LDAP.
+	enterprise bool
+
+	// The organization of the managed resource, which may be the default
+	// organization of its ProviderConfig.
+	org string
+
+	// Teams are observed in batches via the GraphQL API, shared between all
+	// Teams that use the same ProviderConfig (the scope).
+	graphql *kcgitclient.GraphQLClient
+	cache   *kcgitclient.ObservationCache
+	scope   string
+}
+
+// getTeam returns the supplied team. It is read from the organization's
+// cached teams if possible, falling back to the REST API if the team is not
+// cached or the teams cannot be fetched.
+func (c *external) getTeam(ctx context.Context, org, slug string) (*kcgitclient.Team, error) {
+	if teams, err := c.cache.Teams(ctx, c.graphql, c.scope, org); err == nil {
+		if t, ok := teams[slug]; ok {
+			return t, nil
+		}
+	}
+	return kcgitclient.GetTeamBySlug(ctx, c.service, org, slug)
 }
 
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
-	cr, ok := mg.(*v1alpha1.Team)
+	cr, ok := mg.(*v1beta1.Team)
 	if !ok {
 		return managed.ExternalObservation{}, errors.New(errNotTeam)
 	}
+	// Report a Team that can never be synchronized with LDAP, rather than
+	// only failing to create or update it.
+	if cr.Spec.ForProvider.LDAPDN != nil && !c.enterprise {
+		return managed.ExternalObservation{}, errors.New(errLDAPDN)
+	}
 
-	team, _, err := c.service.Teams.GetTeamBySlug(ctx, cr.Spec.ForProvider.Org, meta.GetExternalName(cr))
+	team, err := c.getTeam(ctx, c.org, meta.GetExternalName(cr))
+	if err == nil && ((cr.Spec.ForProvider.LDAPDN != nil && team.LDAPDN == nil) || (cr.Spec.ForProvider.NotificationSetting != nil && team.NotificationSetting == nil)) {
+		// Teams observed via the GraphQL API do not include their LDAP DN or
+		// notification setting.
+		team, err = kcgitclient.GetTeamBySlug(ctx, c.service, c.org, meta.GetExternalName(cr))
+	}
 	if err != nil {
 		return managed.ExternalObservation{
 			ResourceExists: false,
 		}, nil
 	}
 
-	if team.NodeID != nil {
-		cr.Status.AtProvider.NodeID = *team.NodeID
-	}
+	cr.Status.AtProvider.NodeID = team.GetNodeID()
+	cr.Status.AtProvider.ID = team.GetID()
 
 	upToDate := true
 	if team != nil {
+		if cr.Spec.ForProvider.Name != nil {
+			if team.GetName() != *cr.Spec.ForProvider.Name {
+				upToDate = false
+			}
+		}
 		if cr.Spec.ForProvider.Description != nil {
 			if team.Description == nil || *team.Description != *cr.Spec.ForProvider.Description {
 				upToDate = false
@@ -120,6 +215,23 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 				upToDate = false
 			}
 		}
+		if cr.Spec.ForProvider.Parent != nil {
+			if team.Parent == nil || team.Parent.GetSlug() != *cr.Spec.ForProvider.Parent {
+				upToDate = false
+			}
+		}
+		if cr.Spec.ForProvider.LDAPDN != nil {
+			if team.LDAPDN == nil || *team.LDAPDN != *cr.Spec.ForProvider.LDAPDN {
+				upToDate = false
+			}
+		}
+		// GitHub Enterprise Server versions that do not support notification
+		// settings do not return them.
+		if cr.Spec.ForProvider.NotificationSetting != nil && team.NotificationSetting != nil {
+			if *team.NotificationSetting != *cr.Spec.ForProvider.NotificationSetting {
+				upToDate = false
+			}
+		}
 	}
 	return managed.ExternalObservation{
 		// Return false when the external resource does not exist. This lets
@@ -134,49 +246,93 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	}, nil
 }
 
+// newTeam returns the desired state of the supplied Team's team. Its name
+// defaults to its slug, which is the Team's external name.
+func (c *external) newTeam(ctx context.Context, cr *v1beta1.Team) (kcgitclient.NewTeam, error) {
+	if cr.Spec.ForProvider.LDAPDN != nil && !c.enterprise {
+		return kcgitclient.NewTeam{}, errors.New(errLDAPDN)
+	}
+	nt := kcgitclient.NewTeam{
+		NewTeam: github.NewTeam{
+			Name:        pointer.StringDeref(cr.Spec.ForProvider.Name, meta.GetExternalName(cr)),
+			Description: cr.Spec.ForProvider.Description,
+			Privacy:     cr.Spec.ForProvider.Privacy,
+			LDAPDN:      cr.Spec.ForProvider.LDAPDN,
+		},
+		NotificationSetting: cr.Spec.ForProvider.NotificationSetting,
+	}
+	if cr.Spec.ForProvider.Parent != nil {
+		parent, err := c.getTeam(ctx, c.org, *cr.Spec.ForProvider.Parent)
+		if err != nil {
+			return kcgitclient.NewTeam{}, errors.Wrap(err, errGetParent)
+		}
+		nt.ParentTeamID = parent.ID
+	}
+	return nt, nil
+}
+
 func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
-	cr, ok := mg.(*v1alpha1.Team)
+	cr, ok := mg.(*v1beta1.Team)
 	if !ok {
 		return managed.ExternalCreation{}, errors.New(errNotTeam)
 	}
 
 	fmt.Printf("Creating: %+v", cr)
 
-	_, _, err := c.service.Teams.CreateTeam(ctx, cr.Spec.ForProvider.Org, github.NewTeam{
-		Name:        meta.GetExternalName(cr),
-		Description: cr.Spec.ForProvider.Description,
-		Privacy:     cr.Spec.ForProvider.Privacy,
-	})
+	nt, err := c.newTeam(ctx, cr)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	team, err := kcgitclient.CreateTeam(ctx, c.service, c.org, nt)
+	c.cache.InvalidateTeams(c.scope, c.org)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
 
-	return managed.ExternalCreation{}, err
+	// GitHub derives the team's slug from its name.
+	meta.SetExternalName(cr, team.GetSlug())
+	return managed.ExternalCreation{}, nil
 }
 
 func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
-	cr, ok := mg.(*v1alpha1.Team)
+	cr, ok := mg.(*v1beta1.Team)
 	if !ok {
 		return managed.ExternalUpdate{}, errors.New(errNotTeam)
 	}
 
 	fmt.Printf("Updating: %+v", cr)
 
-	_, _, err := c.service.Teams.EditTeamBySlug(ctx, cr.Spec.ForProvider.Org, meta.GetExternalName(cr), github.NewTeam{
-		Name:        meta.GetExternalName(cr),
-		Description: cr.Spec.ForProvider.Description,
-		Privacy:     cr.Spec.ForProvider.Privacy,
-	}, false)
+	nt, err := c.newTeam(ctx, cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	team, err := kcgitclient.EditTeamBySlug(ctx, c.service, c.org, meta.GetExternalName(cr), nt)
+	c.cache.InvalidateTeams(c.scope, c.org)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
 
-	return managed.ExternalUpdate{}, err
+	// Renaming a team changes its slug. The managed reconciler does not
+	// persist changes to the external name made while updating, so we do.
+	if team.GetSlug() != meta.GetExternalName(cr) {
+		meta.SetExternalName(cr, team.GetSlug())
+		if err := c.kube.Update(ctx, cr); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateSlug)
+		}
+	}
+	return managed.ExternalUpdate{}, nil
 }
 
 func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
-	cr, ok := mg.(*v1alpha1.Team)
+	cr, ok := mg.(*v1beta1.Team)
 	if !ok {
 		return errors.New(errNotTeam)
 	}
 
 	fmt.Printf("Deleting: %+v", cr)
 
-	_, err := c.service.Teams.DeleteTeamBySlug(ctx, cr.Spec.ForProvider.Org, meta.GetExternalName(cr))
+	_, err := c.service.Teams.DeleteTeamBySlug(ctx, c.org, meta.GetExternalName(cr))
+	c.cache.InvalidateTeams(c.scope, c.org)
 
 	return err
 }
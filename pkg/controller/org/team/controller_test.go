@@ -0,0 +1,587 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package team
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/go-github/v45/github"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/hasheddan/kc-provider-github/apis/org/v1beta1"
+	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
+	"github.com/hasheddan/kc-provider-github/pkg/client/fake"
+)
+
+const (
+	org  = "crossplane"
+	slug = "platform"
+)
+
+type teamModifier func(*v1beta1.Team)
+
+func withDescription(d string) teamModifier {
+	return func(cr *v1beta1.Team) { cr.Spec.ForProvider.Description = &d }
+}
+
+func withPrivacy(p string) teamModifier {
+	return func(cr *v1beta1.Team) { cr.Spec.ForProvider.Privacy = &p }
+}
+
+func withParent(p string) teamModifier {
+	return func(cr *v1beta1.Team) { cr.Spec.ForProvider.Parent = &p }
+}
+
+func withName(n string) teamModifier {
+	return func(cr *v1beta1.Team) { cr.Spec.ForProvider.Name = &n }
+}
+
+func withNotificationSetting(n string) teamModifier {
+	return func(cr *v1beta1.Team) { cr.Spec.ForProvider.NotificationSetting = &n }
+}
+
+func withLDAPDN(dn string) teamModifier {
+	return func(cr *v1beta1.Team) { cr.Spec.ForProvider.LDAPDN = &dn }
+}
+
+func withExternalName(n string) teamModifier {
+	return func(cr *v1beta1.Team) { meta.SetExternalName(cr, n) }
+}
+
+// withID sets the IDs the fake server assigns to the team with the supplied
+// ID.
+func withID(id int64) teamModifier {
+	return func(cr *v1beta1.Team) {
+		cr.Status.AtProvider.NodeID = fmt.Sprintf("T_%d", id)
+		cr.Status.AtProvider.ID = id
+	}
+}
+
+func team(m ...teamModifier) *v1beta1.Team {
+	cr := &v1beta1.Team{
+		ObjectMeta: metav1.ObjectMeta{Name: "cool-team"},
+		Spec: v1beta1.TeamSpec{
+			ResourceSpec: xpv1.ResourceSpec{ProviderConfigReference: &xpv1.Reference{Name: "default"}},
+			ForProvider:  v1beta1.TeamParameters{Org: org},
+		},
+	}
+	meta.SetExternalName(cr, slug)
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+func newExternal(s *fake.Server) *external {
+	c := s.Client()
+	return &external{
+		kube:    &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+		service: c,
+		org:     org,
+		graphql: kcgitclient.NewGraphQLClient(c),
+		cache:   kcgitclient.NewObservationCache(0),
+		scope:   "default",
+	}
+}
+
+func TestObserve(t *testing.T) {
+	type args struct {
+		server     func(s *fake.Server)
+		enterprise bool
+		mg         resource.Managed
+	}
+	type want struct {
+		mg  resource.Managed
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NotTeam": {
+			reason: "An error should be returned if the managed resource is not a Team.",
+			args: args{
+				mg: &v1beta1.Membership{},
+			},
+			want: want{
+				mg:  &v1beta1.Membership{},
+				err: errors.New(errNotTeam),
+			},
+		},
+		"DoesNotExist": {
+			reason: "A team that does not exist should be reported as such.",
+			args: args{
+				mg: team(withDescription("cool")),
+			},
+			want: want{
+				mg: team(withDescription("cool")),
+				o:  managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"UpToDate": {
+			reason: "A team that matches the desired state should be reported as up to date.",
+			args: args{
+				server: func(s *fake.Server) {
+					s.AddTeam(org, github.NewTeam{Name: slug, Description: github.String("cool"), Privacy: github.String("closed")})
+				},
+				mg: team(withDescription("cool"), withPrivacy("closed")),
+			},
+			want: want{
+				mg: team(withDescription("cool"), withPrivacy("closed"), withID(2)),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"DescriptionChanged": {
+			reason: "A team whose description differs from the desired state should be reported as out of date.",
+			args: args{
+				server: func(s *fake.Server) {
+					s.AddTeam(org, github.NewTeam{Name: slug, Description: github.String("lame")})
+				},
+				mg: team(withDescription("cool")),
+			},
+			want: want{
+				mg: team(withDescription("cool"), withID(2)),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+		"PrivacyChanged": {
+			reason: "A team whose privacy differs from the desired state should be reported as out of date.",
+			args: args{
+				server: func(s *fake.Server) {
+					s.AddTeam(org, github.NewTeam{Name: slug, Privacy: github.String("secret")})
+				},
+				mg: team(withPrivacy("closed")),
+			},
+			want: want{
+				mg: team(withPrivacy("closed"), withID(2)),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+		"ParentChanged": {
+			reason: "A team that is not nested under the desired parent team should be reported as out of date.",
+			args: args{
+				server: func(s *fake.Server) {
+					s.AddTeam(org, github.NewTeam{Name: slug})
+					s.AddTeam(org, github.NewTeam{Name: "engineering"})
+				},
+				mg: team(withParent("engineering")),
+			},
+			want: want{
+				mg: team(withParent("engineering"), withID(2)),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+		"NameChanged": {
+			reason: "A team whose name differs from the desired state should be reported as out of date.",
+			args: args{
+				server: func(s *fake.Server) {
+					s.AddTeam(org, github.NewTeam{Name: slug})
+				},
+				mg: team(withName("Platform")),
+			},
+			want: want{
+				mg: team(withName("Platform"), withID(2)),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+		"LDAPDNChanged": {
+			reason: "A team whose LDAP DN differs from the desired state should be reported as out of date.",
+			args: args{
+				server: func(s *fake.Server) {
+					s.AddTeam(org, github.NewTeam{Name: slug, LDAPDN: github.String("cn=old,dc=example,dc=org")})
+				},
+				enterprise: true,
+				mg:         team(withLDAPDN("cn=platform,dc=example,dc=org")),
+			},
+			want: want{
+				mg: team(withLDAPDN("cn=platform,dc=example,dc=org"), withID(2)),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+		"LDAPDNNotEnterprise": {
+			reason: "An error should be returned if an LDAP DN is desired but the ProviderConfig does not target an Enterprise Server.",
+			args: args{
+				server: func(s *fake.Server) {
+					s.AddTeam(org, github.NewTeam{Name: slug})
+				},
+				mg: team(withLDAPDN("cn=platform,dc=example,dc=org")),
+			},
+			want: want{
+				mg:  team(withLDAPDN("cn=platform,dc=example,dc=org")),
+				err: errors.New(errLDAPDN),
+			},
+		},
+		"NotificationSettingChanged": {
+			reason: "A team whose notification setting differs from the desired state should be reported as out of date.",
+			args: args{
+				server: func(s *fake.Server) {
+					s.AddTeam(org, github.NewTeam{Name: slug})
+				},
+				mg: team(withNotificationSetting(v1beta1.NotificationsDisabled)),
+			},
+			want: want{
+				mg: team(withNotificationSetting(v1beta1.NotificationsDisabled), withID(2)),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := fake.NewServer()
+			defer s.Close()
+			s.AddOrg(org)
+			if tc.args.server != nil {
+				tc.args.server(s)
+			}
+
+			e := newExternal(s)
+			e.enterprise = tc.args.enterprise
+			got, err := e.Observe(context.Background(), tc.args.mg)
+			if diff := fake.DiffErrors(tc.want.err, err); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.args.mg); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want managed resource, +got managed resource:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	type args struct {
+		server     func(s *fake.Server)
+		enterprise bool
+		mg         resource.Managed
+	}
+	type want struct {
+		team         *github.Team
+		notification string
+		externalName string
+		err          error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NotTeam": {
+			reason: "An error should be returned if the managed resource is not a Team.",
+			args: args{
+				mg: &v1beta1.Membership{},
+			},
+			want: want{
+				err: errors.New(errNotTeam),
+			},
+		},
+		"Named": {
+			reason: "A team should be created with the desired name and notification setting, and its slug should become its external name.",
+			args: args{
+				mg: team(withExternalName("cool-team"), withName("Platform"), withNotificationSetting(v1beta1.NotificationsDisabled)),
+			},
+			want: want{
+				team:         &github.Team{Slug: github.String(slug), Privacy: github.String("secret")},
+				notification: v1beta1.NotificationsDisabled,
+				externalName: slug,
+			},
+		},
+		"LDAPDN": {
+			reason: "A team should be created with the desired LDAP DN when the ProviderConfig targets an Enterprise Server.",
+			args: args{
+				enterprise: true,
+				mg:         team(withLDAPDN("cn=platform,dc=example,dc=org")),
+			},
+			want: want{
+				team: &github.Team{Slug: github.String(slug), Privacy: github.String("secret"), LDAPDN: github.String("cn=platform,dc=example,dc=org")},
+			},
+		},
+		"LDAPDNNotEnterprise": {
+			reason: "An error should be returned if an LDAP DN is desired but the ProviderConfig does not target an Enterprise Server.",
+			args: args{
+				mg: team(withLDAPDN("cn=platform,dc=example,dc=org")),
+			},
+			want: want{
+				err: errors.New(errLDAPDN),
+			},
+		},
+		"Created": {
+			reason: "A team should be created with the desired description and privacy.",
+			args: args{
+				mg: team(withDescription("cool"), withPrivacy("closed")),
+			},
+			want: want{
+				team: &github.Team{Slug: github.String(slug), Description: github.String("cool"), Privacy: github.String("closed")},
+			},
+		},
+		"Nested": {
+			reason: "A team should be created under the desired parent team.",
+			args: args{
+				server: func(s *fake.Server) {
+					s.AddTeam(org, github.NewTeam{Name: "engineering"})
+				},
+				mg: team(withParent("engineering")),
+			},
+			want: want{
+				team: &github.Team{Slug: github.String(slug), Privacy: github.String("closed"), Parent: &github.Team{Slug: github.String("engineering")}},
+			},
+		},
+		"ParentDoesNotExist": {
+			reason: "An error should be returned if the desired parent team does not exist.",
+			args: args{
+				mg: team(withParent("engineering")),
+			},
+			want: want{
+				err: cmpopts.AnyError,
+			},
+		},
+		"AlreadyExists": {
+			reason: "An error should be returned if a team with the same name already exists.",
+			args: args{
+				server: func(s *fake.Server) {
+					s.AddTeam(org, github.NewTeam{Name: slug})
+				},
+				mg: team(withDescription("cool")),
+			},
+			want: want{
+				team: &github.Team{Slug: github.String(slug), Privacy: github.String("secret")},
+				err:  cmpopts.AnyError,
+			},
+		},
+		"RateLimited": {
+			reason: "An error should be returned if the API rate limit was exceeded.",
+			args: args{
+				server: func(s *fake.Server) {
+					s.SetRateLimit(0)
+				},
+				mg: team(),
+			},
+			want: want{
+				err: cmpopts.AnyError,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := fake.NewServer()
+			defer s.Close()
+			s.AddOrg(org)
+			if tc.args.server != nil {
+				tc.args.server(s)
+			}
+
+			e := newExternal(s)
+			e.enterprise = tc.args.enterprise
+			_, err := e.Create(context.Background(), tc.args.mg)
+			if diff := fake.DiffErrors(tc.want.err, err); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+
+			var got *github.Team
+			if t, ok := s.Team(org, slug); ok {
+				got = &github.Team{Slug: t.Slug, Description: t.Description, Privacy: t.Privacy, LDAPDN: t.LDAPDN}
+				if t.Parent != nil {
+					got.Parent = &github.Team{Slug: t.Parent.Slug}
+				}
+			}
+			if diff := cmp.Diff(tc.want.team, got); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want team, +got team:\n%s\n", tc.reason, diff)
+			}
+			if tc.want.notification != "" {
+				n, _ := s.TeamNotificationSetting(org, slug)
+				if diff := cmp.Diff(tc.want.notification, n); diff != "" {
+					t.Errorf("\n%s\ne.Create(...): -want notification setting, +got notification setting:\n%s\n", tc.reason, diff)
+				}
+			}
+			if tc.want.externalName != "" {
+				if diff := cmp.Diff(tc.want.externalName, meta.GetExternalName(tc.args.mg)); diff != "" {
+					t.Errorf("\n%s\ne.Create(...): -want external name, +got external name:\n%s\n", tc.reason, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	type args struct {
+		server func(s *fake.Server)
+		mg     resource.Managed
+	}
+	type want struct {
+		team *github.Team
+		err  error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NotTeam": {
+			reason: "An error should be returned if the managed resource is not a Team.",
+			args: args{
+				mg: &v1beta1.Membership{},
+			},
+			want: want{
+				err: errors.New(errNotTeam),
+			},
+		},
+		"Updated": {
+			reason: "A team's description and privacy should be updated to the desired state.",
+			args: args{
+				server: func(s *fake.Server) {
+					s.AddTeam(org, github.NewTeam{Name: slug, Description: github.String("lame"), Privacy: github.String("secret")})
+				},
+				mg: team(withDescription("cool"), withPrivacy("closed")),
+			},
+			want: want{
+				team: &github.Team{Slug: github.String(slug), Description: github.String("cool"), Privacy: github.String("closed")},
+			},
+		},
+		"Renamed": {
+			reason: "A renamed team's new slug should become its external name.",
+			args: args{
+				server: func(s *fake.Server) {
+					s.AddTeam(org, github.NewTeam{Name: slug})
+				},
+				mg: team(withName("Platform Team")),
+			},
+			want: want{
+				team: &github.Team{Slug: github.String("platform-team"), Privacy: github.String("secret")},
+			},
+		},
+		"DoesNotExist": {
+			reason: "An error should be returned if the team does not exist.",
+			args: args{
+				mg: team(withDescription("cool")),
+			},
+			want: want{
+				err: cmpopts.AnyError,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := fake.NewServer()
+			defer s.Close()
+			s.AddOrg(org)
+			if tc.args.server != nil {
+				tc.args.server(s)
+			}
+
+			_, err := newExternal(s).Update(context.Background(), tc.args.mg)
+			if diff := fake.DiffErrors(tc.want.err, err); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+
+			var got *github.Team
+			if t, ok := s.Team(org, meta.GetExternalName(tc.args.mg)); ok {
+				got = &github.Team{Slug: t.Slug, Description: t.Description, Privacy: t.Privacy}
+			}
+			if diff := cmp.Diff(tc.want.team, got); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want team, +got team:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	type args struct {
+		server func(s *fake.Server)
+		mg     resource.Managed
+	}
+	type want struct {
+		exists bool
+		err    error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NotTeam": {
+			reason: "An error should be returned if the managed resource is not a Team.",
+			args: args{
+				mg: &v1beta1.Membership{},
+			},
+			want: want{
+				err: errors.New(errNotTeam),
+			},
+		},
+		"Deleted": {
+			reason: "The team should be deleted.",
+			args: args{
+				server: func(s *fake.Server) {
+					s.AddTeam(org, github.NewTeam{Name: slug})
+				},
+				mg: team(),
+			},
+			want: want{
+				exists: false,
+			},
+		},
+		"DoesNotExist": {
+			reason: "An error should be returned if the team does not exist.",
+			args: args{
+				mg: team(),
+			},
+			want: want{
+				err: cmpopts.AnyError,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := fake.NewServer()
+			defer s.Close()
+			s.AddOrg(org)
+			if tc.args.server != nil {
+				tc.args.server(s)
+			}
+
+			err := newExternal(s).Delete(context.Background(), tc.args.mg)
+			if diff := fake.DiffErrors(tc.want.err, err); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			_, exists := s.Team(org, slug)
+			if diff := cmp.Diff(tc.want.exists, exists); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want exists, +got exists:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,257 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package teamidpgroupmapping
+
+import (
+	"context"
+	"sort"
+	"strconv"
+
+	"github.com/google/go-github/v45/github"
+	"github.com/pkg/errors"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/hasheddan/kc-provider-github/apis/org/v1beta1"
+	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/options"
+)
+
+const (
+	errNotMapping         = "managed resource is not a TeamIdPGroupMapping custom resource"
+	errCreateService      = "failed to create client service"
+	errListAvailable      = "cannot list the organization's identity provider groups"
+	errListConnected      = "cannot list the team's identity provider groups"
+	errConnect            = "cannot connect identity provider groups to the team"
+	errDisconnect         = "cannot disconnect identity provider groups from the team"
+	errFmtUnknownIdPGroup = "identity provider group %s is not available to the organization"
+)
+
+// SetupTeamIdPGroupMapping adds a controller that reconciles
+// TeamIdPGroupMapping managed resources.
+func SetupTeamIdPGroupMapping(mgr ctrl.Manager, o options.Options) error {
+	name := managed.ControllerName(v1beta1.TeamIdPGroupMappingGroupKind)
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1beta1.TeamIdPGroupMappingGroupVersionKind),
+		managed.WithExternalConnecter(&connector{kube: mgr.GetClient()}),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1beta1.TeamIdPGroupMapping{}).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect produces an ExternalClient that uses the credentials of the managed
+// resource's ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1beta1.TeamIdPGroupMapping)
+	if !ok {
+		return nil, errors.New(errNotMapping)
+	}
+	svc, err := kcgitclient.UseProviderConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errCreateService)
+	}
+	org, err := kcgitclient.Organization(ctx, c.kube, mg, cr.Spec.ForProvider.Org)
+	if err != nil {
+		return nil, err
+	}
+	return &external{service: svc, org: org}, nil
+}
+
+// An ExternalClient observes and updates the identity provider groups that are
+// connected to a team.
+type external struct {
+	service *github.Client
+
+	// The organization of the managed resource, which may be the default
+	// organization of its ProviderConfig.
+	org string
+}
+
+// available returns the identity provider groups that may be connected to the
+// organization's teams.
+func (c *external) available(ctx context.Context) ([]*github.IDPGroup, error) {
+	var groups []*github.IDPGroup
+	opts := &github.ListCursorOptions{PerPage: 100}
+	for {
+		l, rsp, err := c.service.Teams.ListIDPGroupsInOrganization(ctx, c.org, opts)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, l.Groups...)
+		// Pages of groups are identified by an opaque token on github.com.
+		switch {
+		case rsp.NextPageToken != "":
+			opts.Page = rsp.NextPageToken
+		case rsp.NextPage != 0:
+			opts.Page = strconv.Itoa(rsp.NextPage)
+		default:
+			return groups, nil
+		}
+	}
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1beta1.TeamIdPGroupMapping)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotMapping)
+	}
+
+	connected, _, err := c.service.Teams.ListIDPGroupsForTeamBySlug(ctx, c.org, pointer.StringDeref(cr.Spec.ForProvider.Team, ""))
+	if err != nil {
+		if kcgitclient.IsNotFound(err) {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		return managed.ExternalObservation{}, errors.Wrap(err, errListConnected)
+	}
+	available, err := c.available(ctx)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errListAvailable)
+	}
+
+	cr.Status.AtProvider.Groups = fromGitHub(connected.Groups)
+	cr.Status.AtProvider.AvailableGroups = fromGitHub(available)
+
+	// A team that is connected to no groups is not synchronized.
+	if len(connected.Groups) == 0 {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	cr.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: sameIDs(cr.Spec.ForProvider.Groups, cr.Status.AtProvider.Groups),
+	}, nil
+}
+
+// fromGitHub converts the supplied groups to their API representation.
+func fromGitHub(groups []*github.IDPGroup) []v1beta1.IdPGroup {
+	if len(groups) == 0 {
+		return nil
+	}
+	out := make([]v1beta1.IdPGroup, len(groups))
+	for i, g := range groups {
+		out[i] = v1beta1.IdPGroup{ID: g.GetGroupID(), Name: g.GetGroupName(), Description: g.GetGroupDescription()}
+	}
+	return out
+}
+
+// sameIDs returns true if the supplied groups have the same IDs, regardless of
+// their order.
+func sameIDs(a, b []v1beta1.IdPGroup) bool {
+	ids := func(groups []v1beta1.IdPGroup) []string {
+		out := make([]string, 0, len(groups))
+		seen := map[string]bool{}
+		for _, g := range groups {
+			if !seen[g.ID] {
+				out = append(out, g.ID)
+				seen[g.ID] = true
+			}
+		}
+		sort.Strings(out)
+		return out
+	}
+	x, y := ids(a), ids(b)
+	if len(x) != len(y) {
+		return false
+	}
+	for i := range x {
+		if x[i] != y[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// desired returns the groups that should be connected to the team. GitHub
+// requires the name and description of each group, which default to those of
+// the available group with the same ID.
+func desired(groups []v1beta1.IdPGroup, available []v1beta1.IdPGroup) ([]*github.IDPGroup, error) {
+	byID := map[string]v1beta1.IdPGroup{}
+	for _, g := range available {
+		byID[g.ID] = g
+	}
+	out := make([]*github.IDPGroup, len(groups))
+	for i, g := range groups {
+		a, ok := byID[g.ID]
+		if !ok {
+			return nil, errors.Errorf(errFmtUnknownIdPGroup, g.ID)
+		}
+		if g.Name == "" {
+			g.Name = a.Name
+		}
+		if g.Description == "" {
+			g.Description = a.Description
+		}
+		out[i] = &github.IDPGroup{GroupID: github.String(g.ID), GroupName: github.String(g.Name), GroupDescription: github.String(g.Description)}
+	}
+	return out, nil
+}
+
+func (c *external) connect(ctx context.Context, cr *v1beta1.TeamIdPGroupMapping) error {
+	groups, err := desired(cr.Spec.ForProvider.Groups, cr.Status.AtProvider.AvailableGroups)
+	if err != nil {
+		return err
+	}
+	_, _, err = c.service.Teams.CreateOrUpdateIDPGroupConnectionsBySlug(ctx, c.org, pointer.StringDeref(cr.Spec.ForProvider.Team, ""), github.IDPGroupList{Groups: groups})
+	return errors.Wrap(err, errConnect)
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1beta1.TeamIdPGroupMapping)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotMapping)
+	}
+	return managed.ExternalCreation{}, c.connect(ctx, cr)
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1beta1.TeamIdPGroupMapping)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotMapping)
+	}
+	return managed.ExternalUpdate{}, c.connect(ctx, cr)
+}
+
+// Delete disconnects all identity provider groups from the team.
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1beta1.TeamIdPGroupMapping)
+	if !ok {
+		return errors.New(errNotMapping)
+	}
+	_, _, err := c.service.Teams.CreateOrUpdateIDPGroupConnectionsBySlug(ctx, c.org, pointer.StringDeref(cr.Spec.ForProvider.Team, ""), github.IDPGroupList{Groups: []*github.IDPGroup{}})
+	return errors.Wrap(err, errDisconnect)
+}
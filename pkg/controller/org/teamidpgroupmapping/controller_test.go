@@ -0,0 +1,286 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package teamidpgroupmapping
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v45/github"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/hasheddan/kc-provider-github/apis/org/v1beta1"
+	"github.com/hasheddan/kc-provider-github/pkg/client/fake"
+)
+
+const (
+	org  = "crossplane"
+	slug = "platform"
+)
+
+var (
+	engineers = v1beta1.IdPGroup{ID: "1", Name: "Engineers", Description: "All engineers"}
+	sre       = v1beta1.IdPGroup{ID: "2", Name: "SRE", Description: "Site reliability engineers"}
+)
+
+type mappingModifier func(*v1beta1.TeamIdPGroupMapping)
+
+func withTeam(t string) mappingModifier {
+	return func(cr *v1beta1.TeamIdPGroupMapping) { cr.Spec.ForProvider.Team = &t }
+}
+
+func withObservation(o v1beta1.TeamIdPGroupMappingObservation) mappingModifier {
+	return func(cr *v1beta1.TeamIdPGroupMapping) { cr.Status.AtProvider = o }
+}
+
+func withConditions(c ...xpv1.Condition) mappingModifier {
+	return func(cr *v1beta1.TeamIdPGroupMapping) { cr.SetConditions(c...) }
+}
+
+func mapping(groups []v1beta1.IdPGroup, m ...mappingModifier) *v1beta1.TeamIdPGroupMapping {
+	cr := &v1beta1.TeamIdPGroupMapping{
+		ObjectMeta: metav1.ObjectMeta{Name: "platform-groups"},
+		Spec: v1beta1.TeamIdPGroupMappingSpec{
+			ResourceSpec: xpv1.ResourceSpec{ProviderConfigReference: &xpv1.Reference{Name: "default"}},
+			ForProvider: v1beta1.TeamIdPGroupMappingParameters{
+				Org:    org,
+				Team:   github.String(slug),
+				Groups: groups,
+			},
+		},
+	}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+// newServer returns a fake server with an organization that has a team and
+// two identity provider groups.
+func newServer() *fake.Server {
+	s := fake.NewServer()
+	s.AddOrg(org)
+	s.AddTeam(org, github.NewTeam{Name: slug})
+	for _, g := range []v1beta1.IdPGroup{engineers, sre} {
+		s.AddIdPGroup(org, g.ID, g.Name, g.Description)
+	}
+	return s
+}
+
+func TestObserve(t *testing.T) {
+	type args struct {
+		server func(s *fake.Server)
+		mg     resource.Managed
+	}
+	type want struct {
+		mg  resource.Managed
+		o   managed.ExternalObservation
+		err error
+	}
+
+	available := []v1beta1.IdPGroup{engineers, sre}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NotTeamIdPGroupMapping": {
+			reason: "An error should be returned if the managed resource is not a TeamIdPGroupMapping.",
+			args: args{
+				mg: &v1beta1.Team{},
+			},
+			want: want{
+				mg:  &v1beta1.Team{},
+				err: errors.New(errNotMapping),
+			},
+		},
+		"TeamDoesNotExist": {
+			reason: "A mapping of a team that does not exist should be reported as not existing.",
+			args: args{
+				mg: mapping([]v1beta1.IdPGroup{{ID: "1"}}, withTeam("nope")),
+			},
+			want: want{
+				mg: mapping([]v1beta1.IdPGroup{{ID: "1"}}, withTeam("nope")),
+				o:  managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"NotConnected": {
+			reason: "A mapping of a team that is connected to no groups should be reported as not existing, with the available groups.",
+			args: args{
+				mg: mapping([]v1beta1.IdPGroup{{ID: "1"}}),
+			},
+			want: want{
+				mg: mapping([]v1beta1.IdPGroup{{ID: "1"}}, withObservation(v1beta1.TeamIdPGroupMappingObservation{AvailableGroups: available})),
+				o:  managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"UpToDate": {
+			reason: "A mapping of a team that is connected to the desired groups should be up to date.",
+			args: args{
+				server: func(s *fake.Server) {
+					s.ConnectIdPGroups(org, slug, "2", "1")
+				},
+				mg: mapping([]v1beta1.IdPGroup{{ID: "1"}, {ID: "2"}}),
+			},
+			want: want{
+				mg: mapping([]v1beta1.IdPGroup{{ID: "1"}, {ID: "2"}},
+					withObservation(v1beta1.TeamIdPGroupMappingObservation{Groups: []v1beta1.IdPGroup{sre, engineers}, AvailableGroups: available}),
+					withConditions(xpv1.Available())),
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"GroupsChanged": {
+			reason: "A mapping of a team that is connected to groups other than the desired groups should not be up to date.",
+			args: args{
+				server: func(s *fake.Server) {
+					s.ConnectIdPGroups(org, slug, "2")
+				},
+				mg: mapping([]v1beta1.IdPGroup{{ID: "1"}}),
+			},
+			want: want{
+				mg: mapping([]v1beta1.IdPGroup{{ID: "1"}},
+					withObservation(v1beta1.TeamIdPGroupMappingObservation{Groups: []v1beta1.IdPGroup{sre}, AvailableGroups: available}),
+					withConditions(xpv1.Available())),
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := newServer()
+			defer s.Close()
+			if tc.args.server != nil {
+				tc.args.server(s)
+			}
+
+			e := &external{service: s.Client(), org: org}
+			got, err := e.Observe(context.Background(), tc.args.mg)
+			if diff := fake.DiffErrors(tc.want.err, err); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.args.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want managed resource, +got managed resource:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserveAvailablePages(t *testing.T) {
+	s := fake.NewServer()
+	defer s.Close()
+	s.AddOrg(org)
+	s.AddTeam(org, github.NewTeam{Name: slug})
+	for i := 0; i < 150; i++ {
+		s.AddIdPGroup(org, fmt.Sprint(i), fmt.Sprintf("group-%d", i), "")
+	}
+
+	cr := mapping([]v1beta1.IdPGroup{{ID: "1"}})
+	e := &external{service: s.Client(), org: org}
+	if _, err := e.Observe(context.Background(), cr); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(150, len(cr.Status.AtProvider.AvailableGroups)); diff != "" {
+		t.Errorf("e.Observe(...): every page of available groups should be observed: -want, +got:\n%s\n", diff)
+	}
+}
+
+func TestCreate(t *testing.T) {
+	type want struct {
+		groups []github.IDPGroup
+		err    error
+	}
+
+	cases := map[string]struct {
+		reason string
+		mg     resource.Managed
+		want   want
+	}{
+		"NotTeamIdPGroupMapping": {
+			reason: "An error should be returned if the managed resource is not a TeamIdPGroupMapping.",
+			mg:     &v1beta1.Team{},
+			want: want{
+				groups: []github.IDPGroup{},
+				err:    errors.New(errNotMapping),
+			},
+		},
+		"Connected": {
+			reason: "The desired groups should be connected, with the names and descriptions of the available groups unless they are specified.",
+			mg: mapping([]v1beta1.IdPGroup{{ID: "1"}, {ID: "2", Name: "Reliability"}},
+				withObservation(v1beta1.TeamIdPGroupMappingObservation{AvailableGroups: []v1beta1.IdPGroup{engineers, sre}})),
+			want: want{
+				groups: []github.IDPGroup{
+					{GroupID: github.String("1"), GroupName: github.String("Engineers"), GroupDescription: github.String("All engineers")},
+					{GroupID: github.String("2"), GroupName: github.String("Reliability"), GroupDescription: github.String("Site reliability engineers")},
+				},
+			},
+		},
+		"UnknownGroup": {
+			reason: "An error should be returned if a desired group is not available to the organization.",
+			mg: mapping([]v1beta1.IdPGroup{{ID: "3"}},
+				withObservation(v1beta1.TeamIdPGroupMappingObservation{AvailableGroups: []v1beta1.IdPGroup{engineers, sre}})),
+			want: want{
+				groups: []github.IDPGroup{},
+				err:    errors.Errorf(errFmtUnknownIdPGroup, "3"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := newServer()
+			defer s.Close()
+
+			e := &external{service: s.Client(), org: org}
+			_, err := e.Create(context.Background(), tc.mg)
+			if diff := fake.DiffErrors(tc.want.err, err); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			got, _ := s.TeamIdPGroups(org, slug)
+			if diff := cmp.Diff(tc.want.groups, got); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want groups, +got groups:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	s := newServer()
+	defer s.Close()
+	s.ConnectIdPGroups(org, slug, "1", "2")
+
+	e := &external{service: s.Client(), org: org}
+	if err := e.Delete(context.Background(), mapping([]v1beta1.IdPGroup{{ID: "1"}})); err != nil {
+		t.Fatal(err)
+	}
+	got, _ := s.TeamIdPGroups(org, slug)
+	if diff := cmp.Diff([]github.IDPGroup{}, got); diff != "" {
+		t.Errorf("e.Delete(...): all groups should be disconnected: -want groups, +got groups:\n%s\n", diff)
+	}
+}
@@ -0,0 +1,401 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package teammembers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/go-github/v45/github"
+	"github.com/pkg/errors"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/hasheddan/kc-provider-github/apis/org/v1beta1"
+	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/options"
+	"github.com/hasheddan/kc-provider-github/pkg/receiver"
+)
+
+const (
+	errNotTeamMembers  = "managed resource is not a TeamMembers custom resource"
+	errCreateService   = "failed to create client service"
+	errListTeamMembers = "cannot list TeamMembers"
+	errListMembers     = "cannot list the team's members"
+	errListPending     = "cannot list the team's pending invitations"
+	errFmtAdd          = "cannot add %s to the team"
+	errFmtRemove       = "cannot remove %s from the team"
+
+	reasonAdded   event.Reason = "AddedMembers"
+	reasonRemoved event.Reason = "RemovedMembers"
+)
+
+// SetupTeamMembers adds a controller that reconciles TeamMembers managed
+// resources.
+func SetupTeamMembers(mgr ctrl.Manager, o options.Options) error {
+	name := managed.ControllerName(v1beta1.TeamMembersGroupKind)
+	record := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1beta1.TeamMembersGroupVersionKind),
+		managed.WithExternalConnecter(&connector{kube: mgr.GetClient(), record: record}),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(record))
+
+	b := ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1beta1.TeamMembers{})
+	if o.Receiver != nil {
+		src := o.Receiver.Subscribe(mapTeamMembers(mgr.GetClient()), receiver.EventMembership, receiver.EventTeam, receiver.EventOrganization)
+		b = b.Watches(src, &handler.EnqueueRequestForObject{})
+	}
+	return b.Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// mapTeamMembers returns a receiver.MapFunc that maps events to the
+// TeamMembers they may affect. An event that concerns no particular team, such
+// as a user leaving the organization, maps to all TeamMembers of the
+// organization.
+func mapTeamMembers(c client.Reader) receiver.MapFunc {
+	return func(ctx context.Context, e receiver.Event) ([]client.Object, error) {
+		l := &v1beta1.TeamMembersList{}
+		if err := c.List(ctx, l); err != nil {
+			return nil, errors.Wrap(err, errListTeamMembers)
+		}
+		var objs []client.Object
+		for i := range l.Items {
+			tm := &l.Items[i]
+			// TeamMembers whose organization cannot be resolved cannot be
+			// affected by the event.
+			if org, err := kcgitclient.Organization(ctx, c, tm, tm.Spec.ForProvider.Org); err != nil || org != e.Org {
+				continue
+			}
+			if e.Team != "" && pointer.StringDeref(tm.Spec.ForProvider.Team, "") != e.Team {
+				continue
+			}
+			objs = append(objs, tm)
+		}
+		return objs, nil
+	}
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube   client.Client
+	record event.Recorder
+}
+
+// Connect produces an ExternalClient that uses the credentials of the managed
+// resource's ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1beta1.TeamMembers)
+	if !ok {
+		return nil, errors.New(errNotTeamMembers)
+	}
+	svc, err := kcgitclient.UseProviderConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errCreateService)
+	}
+	org, err := kcgitclient.Organization(ctx, c.kube, mg, cr.Spec.ForProvider.Org)
+	if err != nil {
+		return nil, err
+	}
+	return &external{service: svc, graphql: kcgitclient.NewGraphQLClient(svc), org: org, record: c.record}, nil
+}
+
+// An ExternalClient observes and updates the roster of a team.
+type external struct {
+	service *github.Client
+
+	// The REST API lists the members of child teams as members of their
+	// parent, so members are listed via the GraphQL API, which can list only
+	// a team's immediate members.
+	graphql *kcgitclient.GraphQLClient
+
+	// The organization of the managed resource, which may be the default
+	// organization of its ProviderConfig.
+	org string
+
+	// Users who are added to or removed from the team are reported as events.
+	record event.Recorder
+}
+
+// A roster maps usernames to their role in a team. GitHub usernames are
+// case-insensitive, so they are lower-cased.
+type roster map[string]string
+
+// users returns the sorted usernames of the roster that have the supplied
+// role, or all of its usernames if no role is supplied.
+func (r roster) users(role string) []string {
+	var users []string
+	for u, ur := range r {
+		if role == "" || ur == role {
+			users = append(users, u)
+		}
+	}
+	sort.Strings(users)
+	return users
+}
+
+// desired returns the roster declared by the supplied TeamMembers. Users who
+// are declared as both members and maintainers are maintainers.
+func desired(cr *v1beta1.TeamMembers) roster {
+	r := roster{}
+	for _, u := range cr.Spec.ForProvider.Members {
+		r[strings.ToLower(u)] = v1beta1.RoleMember
+	}
+	for _, u := range cr.Spec.ForProvider.Maintainers {
+		r[strings.ToLower(u)] = v1beta1.RoleMaintainer
+	}
+	return r
+}
+
+// pending returns the lower-cased usernames of the users who were invited to
+// the supplied team, but have not yet accepted an invitation to the
+// organization.
+func (c *external) pending(ctx context.Context, team string) ([]string, error) {
+	var users []string
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		l, rsp, err := c.service.Teams.ListPendingTeamInvitationsBySlug(ctx, c.org, team, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, i := range l {
+			users = append(users, strings.ToLower(i.GetLogin()))
+		}
+		if rsp.NextPage == 0 {
+			sort.Strings(users)
+			return users, nil
+		}
+		opts.Page = rsp.NextPage
+	}
+}
+
+// observe returns the active roster of the supplied team, and the users who
+// are pending membership of it. Members of the team's child teams are not
+// part of its roster.
+func (c *external) observe(ctx context.Context, team string) (roster, []string, error) {
+	// Pending invitations are listed first because the REST API reports a
+	// team that does not exist as not found.
+	pending, err := c.pending(ctx, team)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, errListPending)
+	}
+	members, err := c.graphql.TeamMembers(ctx, c.org, team)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, errListMembers)
+	}
+	active := roster{}
+	for u, m := range members {
+		active[strings.ToLower(u)] = m.GetRole()
+	}
+	return active, pending, nil
+}
+
+// diff returns the users who must be added to the team, with their desired
+// role, and the users who must be removed from it in order for the observed
+// roster to match the desired roster. Pending users are assumed to have the
+// desired role, since their invitation does not report it. Users are only
+// removed if the desired roster is exclusive.
+func diff(want, active roster, pending []string, exclusive bool) (add roster, remove []string) {
+	isPending := map[string]bool{}
+	for _, u := range pending {
+		isPending[u] = true
+	}
+	add = roster{}
+	for u, role := range want {
+		if isPending[u] {
+			continue
+		}
+		if active[u] != role {
+			add[u] = role
+		}
+	}
+	if !exclusive {
+		return add, nil
+	}
+	for _, u := range active.users("") {
+		if _, ok := want[u]; !ok {
+			remove = append(remove, u)
+		}
+	}
+	for _, u := range pending {
+		if _, ok := want[u]; !ok {
+			remove = append(remove, u)
+		}
+	}
+	sort.Strings(remove)
+	return add, remove
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1beta1.TeamMembers)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotTeamMembers)
+	}
+
+	active, pending, err := c.observe(ctx, pointer.StringDeref(cr.Spec.ForProvider.Team, ""))
+	if kcgitclient.IsNotFound(err) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	cr.Status.AtProvider = v1beta1.TeamMembersObservation{
+		Members:     active.users(v1beta1.RoleMember),
+		Maintainers: active.users(v1beta1.RoleMaintainer),
+		Pending:     pending,
+	}
+
+	want := desired(cr)
+
+	// A TeamMembers that is being deleted exists until none of its declared
+	// users remain members of the team.
+	if meta.WasDeleted(cr) {
+		exists := false
+		for _, u := range append(active.users(""), pending...) {
+			if _, ok := want[u]; ok {
+				exists = true
+			}
+		}
+		return managed.ExternalObservation{ResourceExists: exists}, nil
+	}
+
+	add, remove := diff(want, active, pending, pointer.BoolDeref(cr.Spec.ForProvider.Exclusive, true))
+	cr.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: len(add) == 0 && len(remove) == 0,
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1beta1.TeamMembers)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotTeamMembers)
+	}
+	return managed.ExternalCreation{}, c.sync(ctx, cr)
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1beta1.TeamMembers)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotTeamMembers)
+	}
+	return managed.ExternalUpdate{}, c.sync(ctx, cr)
+}
+
+// sync adds and removes users from the team until its roster matches the
+// supplied TeamMembers, recording an event for the users it added or removed.
+func (c *external) sync(ctx context.Context, cr *v1beta1.TeamMembers) error {
+	team := pointer.StringDeref(cr.Spec.ForProvider.Team, "")
+	active, pending, err := c.observe(ctx, team)
+	if err != nil {
+		return err
+	}
+	add, remove := diff(desired(cr), active, pending, pointer.BoolDeref(cr.Spec.ForProvider.Exclusive, true))
+
+	added := make([]string, 0, len(add))
+	for _, u := range add.users("") {
+		// Adding an existing member to a team updates their role.
+		if _, _, err := c.service.Teams.AddTeamMembershipBySlug(ctx, c.org, team, u, &github.TeamAddTeamMembershipOptions{Role: add[u]}); err != nil {
+			c.recordAdded(cr, added, add)
+			return errors.Wrapf(err, errFmtAdd, u)
+		}
+		added = append(added, u)
+	}
+	c.recordAdded(cr, added, add)
+
+	removed := make([]string, 0, len(remove))
+	for _, u := range remove {
+		_, err := c.service.Teams.RemoveTeamMembershipBySlug(ctx, c.org, team, u)
+		if kcgitclient.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			c.recordRemoved(cr, removed)
+			return errors.Wrapf(err, errFmtRemove, u)
+		}
+		removed = append(removed, u)
+	}
+	c.recordRemoved(cr, removed)
+	return nil
+}
+
+// recordAdded records an event for the supplied users, who were added to the
+// team with the roles of the supplied roster.
+func (c *external) recordAdded(cr *v1beta1.TeamMembers, users []string, r roster) {
+	if len(users) == 0 {
+		return
+	}
+	added := make([]string, len(users))
+	for i, u := range users {
+		added[i] = fmt.Sprintf("%s (%s)", u, r[u])
+	}
+	c.record.Event(cr, event.Normal(reasonAdded, "Added users to the team: "+strings.Join(added, ", ")))
+}
+
+// recordRemoved records an event for the supplied users, who were removed from
+// the team.
+func (c *external) recordRemoved(cr *v1beta1.TeamMembers, users []string) {
+	if len(users) == 0 {
+		return
+	}
+	c.record.Event(cr, event.Normal(reasonRemoved, "Removed users from the team: "+strings.Join(users, ", ")))
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1beta1.TeamMembers)
+	if !ok {
+		return errors.New(errNotTeamMembers)
+	}
+
+	// Only the declared users are removed from the team, regardless of
+	// whether the TeamMembers is exclusive.
+	team := pointer.StringDeref(cr.Spec.ForProvider.Team, "")
+	removed := make([]string, 0, len(cr.Spec.ForProvider.Members)+len(cr.Spec.ForProvider.Maintainers))
+	for _, u := range desired(cr).users("") {
+		_, err := c.service.Teams.RemoveTeamMembershipBySlug(ctx, c.org, team, u)
+		if kcgitclient.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			c.recordRemoved(cr, removed)
+			return errors.Wrapf(err, errFmtRemove, u)
+		}
+		removed = append(removed, u)
+	}
+	c.recordRemoved(cr, removed)
+	return nil
+}
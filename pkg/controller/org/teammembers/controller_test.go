@@ -0,0 +1,424 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package teammembers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v45/github"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/hasheddan/kc-provider-github/apis/org/v1beta1"
+	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
+	"github.com/hasheddan/kc-provider-github/pkg/client/fake"
+)
+
+const (
+	org  = "crossplane"
+	slug = "platform"
+)
+
+// A recorder records the events it is asked to record.
+type recorder struct {
+	events []event.Event
+}
+
+func (r *recorder) Event(_ runtime.Object, e event.Event) { r.events = append(r.events, e) }
+
+func (r *recorder) WithAnnotations(_ ...string) event.Recorder { return r }
+
+type teamMembersModifier func(*v1beta1.TeamMembers)
+
+func withTeam(t string) teamMembersModifier {
+	return func(cr *v1beta1.TeamMembers) { cr.Spec.ForProvider.Team = &t }
+}
+
+func withMembers(u ...string) teamMembersModifier {
+	return func(cr *v1beta1.TeamMembers) { cr.Spec.ForProvider.Members = u }
+}
+
+func withMaintainers(u ...string) teamMembersModifier {
+	return func(cr *v1beta1.TeamMembers) { cr.Spec.ForProvider.Maintainers = u }
+}
+
+func withExclusive(e bool) teamMembersModifier {
+	return func(cr *v1beta1.TeamMembers) { cr.Spec.ForProvider.Exclusive = &e }
+}
+
+func withDeletionTimestamp() teamMembersModifier {
+	return func(cr *v1beta1.TeamMembers) {
+		ts := metav1.NewTime(time.Unix(0, 0))
+		cr.SetDeletionTimestamp(&ts)
+	}
+}
+
+func withObservation(o v1beta1.TeamMembersObservation) teamMembersModifier {
+	return func(cr *v1beta1.TeamMembers) { cr.Status.AtProvider = o }
+}
+
+func withConditions(c ...xpv1.Condition) teamMembersModifier {
+	return func(cr *v1beta1.TeamMembers) { cr.SetConditions(c...) }
+}
+
+func teamMembers(m ...teamMembersModifier) *v1beta1.TeamMembers {
+	cr := &v1beta1.TeamMembers{
+		ObjectMeta: metav1.ObjectMeta{Name: "platform-members"},
+		Spec: v1beta1.TeamMembersSpec{
+			ResourceSpec: xpv1.ResourceSpec{ProviderConfigReference: &xpv1.Reference{Name: "default"}},
+			ForProvider: v1beta1.TeamMembersParameters{
+				Org:  org,
+				Team: github.String(slug),
+			},
+		},
+	}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+// newServer returns a fake server with an organization that has a team.
+func newServer() *fake.Server {
+	s := fake.NewServer()
+	s.AddOrg(org)
+	s.AddTeam(org, github.NewTeam{Name: slug})
+	return s
+}
+
+func newExternal(s *fake.Server, r event.Recorder) *external {
+	return &external{service: s.Client(), graphql: kcgitclient.NewGraphQLClient(s.Client()), org: org, record: r}
+}
+
+func TestObserve(t *testing.T) {
+	type args struct {
+		server func(s *fake.Server)
+		mg     resource.Managed
+	}
+	type want struct {
+		mg  resource.Managed
+		o   managed.ExternalObservation
+		err error
+	}
+
+	roster := func(s *fake.Server) {
+		s.AddTeamMember(org, slug, "hubot", "member", "active")
+		s.AddTeamMember(org, slug, "octocat", "maintainer", "active")
+		s.AddTeamMember(org, slug, "monalisa", "member", "pending")
+	}
+	observed := v1beta1.TeamMembersObservation{
+		Members:     []string{"hubot"},
+		Maintainers: []string{"octocat"},
+		Pending:     []string{"monalisa"},
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NotTeamMembers": {
+			reason: "An error should be returned if the managed resource is not a TeamMembers.",
+			args: args{
+				mg: &v1beta1.Team{},
+			},
+			want: want{
+				mg:  &v1beta1.Team{},
+				err: errors.New(errNotTeamMembers),
+			},
+		},
+		"TeamDoesNotExist": {
+			reason: "The members of a team that does not exist should be reported as not existing.",
+			args: args{
+				mg: teamMembers(withTeam("nope"), withMembers("hubot")),
+			},
+			want: want{
+				mg: teamMembers(withTeam("nope"), withMembers("hubot")),
+				o:  managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"UpToDate": {
+			reason: "A team whose roster matches the declared roster should be reported as up to date. Pending users count as members.",
+			args: args{
+				server: roster,
+				mg:     teamMembers(withMembers("hubot", "monalisa"), withMaintainers("octocat")),
+			},
+			want: want{
+				mg: teamMembers(withMembers("hubot", "monalisa"), withMaintainers("octocat"),
+					withObservation(observed), withConditions(xpv1.Available())),
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"ChildTeamMember": {
+			reason: "Members of a child team should not be part of the team's roster, even if the declared roster is exclusive.",
+			args: args{
+				server: func(s *fake.Server) {
+					roster(s)
+					parent, _ := s.Team(org, slug)
+					s.AddTeam(org, github.NewTeam{Name: "oncall", ParentTeamID: parent.ID})
+					s.AddTeamMember(org, "oncall", "pagerbot", "member", "active")
+				},
+				mg: teamMembers(withMembers("hubot", "monalisa"), withMaintainers("octocat")),
+			},
+			want: want{
+				mg: teamMembers(withMembers("hubot", "monalisa"), withMaintainers("octocat"),
+					withObservation(observed), withConditions(xpv1.Available())),
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"MixedCase": {
+			reason: "Usernames should be compared case-insensitively, so that an exclusive roster does not remove declared users.",
+			args: args{
+				server: roster,
+				mg:     teamMembers(withMembers("HuBot", "MonaLisa"), withMaintainers("OctoCat")),
+			},
+			want: want{
+				mg: teamMembers(withMembers("HuBot", "MonaLisa"), withMaintainers("OctoCat"),
+					withObservation(observed), withConditions(xpv1.Available())),
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"MemberMissing": {
+			reason: "A team that lacks a declared user should be reported as out of date.",
+			args: args{
+				server: roster,
+				mg:     teamMembers(withMembers("hubot", "monalisa", "mona"), withMaintainers("octocat")),
+			},
+			want: want{
+				mg: teamMembers(withMembers("hubot", "monalisa", "mona"), withMaintainers("octocat"),
+					withObservation(observed), withConditions(xpv1.Available())),
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+		"RoleChanged": {
+			reason: "A team whose members have a role other than the declared role should be reported as out of date.",
+			args: args{
+				server: roster,
+				mg:     teamMembers(withMembers("monalisa"), withMaintainers("hubot", "octocat")),
+			},
+			want: want{
+				mg: teamMembers(withMembers("monalisa"), withMaintainers("hubot", "octocat"),
+					withObservation(observed), withConditions(xpv1.Available())),
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+		"ExtraMember": {
+			reason: "A team that has undeclared members should be reported as out of date.",
+			args: args{
+				server: roster,
+				mg:     teamMembers(withMaintainers("octocat")),
+			},
+			want: want{
+				mg: teamMembers(withMaintainers("octocat"),
+					withObservation(observed), withConditions(xpv1.Available())),
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+		"ExtraMemberNotExclusive": {
+			reason: "Undeclared members should be ignored if the roster is not exclusive.",
+			args: args{
+				server: roster,
+				mg:     teamMembers(withMaintainers("octocat"), withExclusive(false)),
+			},
+			want: want{
+				mg: teamMembers(withMaintainers("octocat"), withExclusive(false),
+					withObservation(observed), withConditions(xpv1.Available())),
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"Deleting": {
+			reason: "A TeamMembers that is being deleted should exist while any of its declared users are members.",
+			args: args{
+				server: roster,
+				mg:     teamMembers(withMembers("monalisa"), withDeletionTimestamp()),
+			},
+			want: want{
+				mg: teamMembers(withMembers("monalisa"), withDeletionTimestamp(),
+					withObservation(observed)),
+				o: managed.ExternalObservation{ResourceExists: true},
+			},
+		},
+		"Deleted": {
+			reason: "A TeamMembers that is being deleted should not exist once none of its declared users are members.",
+			args: args{
+				server: roster,
+				mg:     teamMembers(withMembers("mona"), withDeletionTimestamp()),
+			},
+			want: want{
+				mg: teamMembers(withMembers("mona"), withDeletionTimestamp(),
+					withObservation(observed)),
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := newServer()
+			defer s.Close()
+			if tc.args.server != nil {
+				tc.args.server(s)
+			}
+
+			e := newExternal(s, &recorder{})
+			got, err := e.Observe(context.Background(), tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.args.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want managed resource, +got managed resource:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserveMemberPages(t *testing.T) {
+	s := newServer()
+	defer s.Close()
+	for i := 0; i < 150; i++ {
+		s.AddTeamMember(org, slug, fmt.Sprintf("user-%03d", i), "member", "active")
+	}
+
+	cr := teamMembers()
+	e := newExternal(s, &recorder{})
+	if _, err := e.Observe(context.Background(), cr); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(150, len(cr.Status.AtProvider.Members)); diff != "" {
+		t.Errorf("e.Observe(...): every page of members should be observed: -want, +got:\n%s\n", diff)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	type want struct {
+		members map[string]string // Roles keyed by username.
+		events  []event.Event
+		err     error
+	}
+
+	cases := map[string]struct {
+		reason string
+		mg     resource.Managed
+		want   want
+	}{
+		"NotTeamMembers": {
+			reason: "An error should be returned if the managed resource is not a TeamMembers.",
+			mg:     &v1beta1.Team{},
+			want: want{
+				members: map[string]string{"hubot": "member", "octocat": "maintainer"},
+				err:     errors.New(errNotTeamMembers),
+			},
+		},
+		"Exclusive": {
+			reason: "Declared users should be added with their declared role, and undeclared users removed.",
+			mg:     teamMembers(withMembers("octocat", "mona"), withMaintainers("hubot")),
+			want: want{
+				members: map[string]string{"hubot": "maintainer", "octocat": "member", "mona": "member"},
+				events: []event.Event{
+					event.Normal(reasonAdded, "Added users to the team: hubot (maintainer), mona (member), octocat (member)"),
+				},
+			},
+		},
+		"RemoveUndeclared": {
+			reason: "Undeclared users should be removed from an exclusive roster.",
+			mg:     teamMembers(withMaintainers("octocat")),
+			want: want{
+				members: map[string]string{"octocat": "maintainer"},
+				events: []event.Event{
+					event.Normal(reasonRemoved, "Removed users from the team: hubot"),
+				},
+			},
+		},
+		"NotExclusive": {
+			reason: "Undeclared users should not be removed from a roster that is not exclusive.",
+			mg:     teamMembers(withMembers("mona"), withExclusive(false)),
+			want: want{
+				members: map[string]string{"hubot": "member", "octocat": "maintainer", "mona": "member"},
+				events: []event.Event{
+					event.Normal(reasonAdded, "Added users to the team: mona (member)"),
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := newServer()
+			defer s.Close()
+			s.AddTeamMember(org, slug, "hubot", "member", "active")
+			s.AddTeamMember(org, slug, "octocat", "maintainer", "active")
+			s.AddOrgMember(org, "mona", "member")
+
+			r := &recorder{}
+			e := newExternal(s, r)
+			_, err := e.Update(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+
+			got := map[string]string{}
+			for _, u := range []string{"hubot", "octocat", "mona"} {
+				if m, ok := s.TeamMembership(org, slug, u); ok {
+					got[u] = m.GetRole()
+				}
+			}
+			if diff := cmp.Diff(tc.want.members, got); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want members, +got members:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.events, r.events); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want events, +got events:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	s := newServer()
+	defer s.Close()
+	s.AddTeamMember(org, slug, "hubot", "member", "active")
+	s.AddTeamMember(org, slug, "octocat", "maintainer", "active")
+
+	r := &recorder{}
+	e := newExternal(s, r)
+	if err := e.Delete(context.Background(), teamMembers(withMembers("hubot", "mona"))); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := s.TeamMembership(org, slug, "hubot"); ok {
+		t.Errorf("e.Delete(...): declared users should be removed from the team")
+	}
+	if _, ok := s.TeamMembership(org, slug, "octocat"); !ok {
+		t.Errorf("e.Delete(...): undeclared users should remain members of the team")
+	}
+	want := []event.Event{event.Normal(reasonRemoved, "Removed users from the team: hubot")}
+	if diff := cmp.Diff(want, r.events); diff != "" {
+		t.Errorf("e.Delete(...): -want events, +got events:\n%s\n", diff)
+	}
+}
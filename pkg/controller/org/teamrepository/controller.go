@@ -0,0 +1,178 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package teamrepository
+
+import (
+	"context"
+
+	"github.com/google/go-github/v45/github"
+	"github.com/pkg/errors"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/hasheddan/kc-provider-github/apis/org/v1beta1"
+	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/options"
+)
+
+const (
+	errNotTeamRepository = "managed resource is not a TeamRepository custom resource"
+	errCreateService     = "failed to create client service"
+	errGet               = "cannot get the team's permission to the repository"
+	errAdd               = "cannot grant the team the permission to the repository"
+	errRemove            = "cannot remove the repository from the team"
+)
+
+// SetupTeamRepository adds a controller that reconciles TeamRepository
+// managed resources.
+func SetupTeamRepository(mgr ctrl.Manager, o options.Options) error {
+	name := managed.ControllerName(v1beta1.TeamRepositoryGroupKind)
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1beta1.TeamRepositoryGroupVersionKind),
+		managed.WithExternalConnecter(&connector{kube: mgr.GetClient()}),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1beta1.TeamRepository{}).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect produces an ExternalClient that uses the credentials of the managed
+// resource's ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1beta1.TeamRepository)
+	if !ok {
+		return nil, errors.New(errNotTeamRepository)
+	}
+	svc, err := kcgitclient.UseProviderConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errCreateService)
+	}
+	org, err := kcgitclient.Organization(ctx, c.kube, mg, cr.Spec.ForProvider.Org)
+	if err != nil {
+		return nil, err
+	}
+	return &external{service: svc, org: org}, nil
+}
+
+// An ExternalClient grants a team a permission to a repository, and removes
+// the repository from the team.
+type external struct {
+	service *github.Client
+
+	// The organization of the managed resource, which may be the default
+	// organization of its ProviderConfig.
+	org string
+}
+
+// permission returns the permission the supplied TeamRepository grants.
+func permission(cr *v1beta1.TeamRepository) string {
+	return pointer.StringDeref(cr.Spec.ForProvider.Permission, v1beta1.PermissionPush)
+}
+
+// roleName returns the name of the role a team that is granted the supplied
+// permission has in a repository. GitHub names the pull and push permissions
+// read and write, respectively, and custom repository roles by their name.
+func roleName(permission string) string {
+	switch permission {
+	case v1beta1.PermissionPull:
+		return "read"
+	case v1beta1.PermissionPush:
+		return "write"
+	default:
+		return permission
+	}
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1beta1.TeamRepository)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotTeamRepository)
+	}
+
+	p := cr.Spec.ForProvider
+	repo, _, err := c.service.Teams.IsTeamRepoBySlug(ctx, c.org, pointer.StringDeref(p.Team, ""), c.org, pointer.StringDeref(p.Repository, ""))
+	if kcgitclient.IsNotFound(err) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGet)
+	}
+
+	cr.Status.AtProvider.RoleName = repo.GetRoleName()
+	cr.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: repo.GetRoleName() == roleName(permission(cr)),
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1beta1.TeamRepository)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotTeamRepository)
+	}
+	return managed.ExternalCreation{}, errors.Wrap(c.add(ctx, cr), errAdd)
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1beta1.TeamRepository)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotTeamRepository)
+	}
+	return managed.ExternalUpdate{}, errors.Wrap(c.add(ctx, cr), errAdd)
+}
+
+// add grants the team the desired permission to the repository. Adding a
+// repository the team can already access updates its permission.
+func (c *external) add(ctx context.Context, cr *v1beta1.TeamRepository) error {
+	p := cr.Spec.ForProvider
+	_, err := c.service.Teams.AddTeamRepoBySlug(ctx, c.org, pointer.StringDeref(p.Team, ""), c.org, pointer.StringDeref(p.Repository, ""),
+		&github.TeamAddTeamRepoOptions{Permission: permission(cr)})
+	return err
+}
+
+// Delete removes the repository from the team. A team or repository that no
+// longer exists has no permission to remove.
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1beta1.TeamRepository)
+	if !ok {
+		return errors.New(errNotTeamRepository)
+	}
+	p := cr.Spec.ForProvider
+	_, err := c.service.Teams.RemoveTeamRepoBySlug(ctx, c.org, pointer.StringDeref(p.Team, ""), c.org, pointer.StringDeref(p.Repository, ""))
+	return errors.Wrap(resource.Ignore(kcgitclient.IsNotFound, err), errRemove)
+}
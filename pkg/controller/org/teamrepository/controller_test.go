@@ -0,0 +1,226 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package teamrepository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/go-github/v45/github"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/hasheddan/kc-provider-github/apis/org/v1beta1"
+	"github.com/hasheddan/kc-provider-github/pkg/client/fake"
+)
+
+const (
+	org  = "crossplane"
+	repo = "provider-github"
+)
+
+type teamRepositoryModifier func(*v1beta1.TeamRepository)
+
+func withPermission(p string) teamRepositoryModifier {
+	return func(cr *v1beta1.TeamRepository) { cr.Spec.ForProvider.Permission = &p }
+}
+
+func withRoleName(n string) teamRepositoryModifier {
+	return func(cr *v1beta1.TeamRepository) { cr.Status.AtProvider.RoleName = n }
+}
+
+func withConditions(c ...xpv1.Condition) teamRepositoryModifier {
+	return func(cr *v1beta1.TeamRepository) { cr.SetConditions(c...) }
+}
+
+func teamRepository(team string, m ...teamRepositoryModifier) *v1beta1.TeamRepository {
+	cr := &v1beta1.TeamRepository{
+		ObjectMeta: metav1.ObjectMeta{Name: team},
+		Spec: v1beta1.TeamRepositorySpec{
+			ResourceSpec: xpv1.ResourceSpec{ProviderConfigReference: &xpv1.Reference{Name: "default"}},
+			ForProvider: v1beta1.TeamRepositoryParameters{
+				Org:        org,
+				Team:       github.String(team),
+				Repository: github.String(repo),
+			},
+		},
+	}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+// newServer returns a fake server with an organization, a repository, a
+// custom repository role named release-manager, and two teams: platform,
+// which may push to the repository, and docs, which may not access it.
+func newServer() *fake.Server {
+	s := fake.NewServer()
+	s.AddOrg(org)
+	s.AddRepo(org, repo)
+	s.AddCustomRole(org, fake.CustomRole{Name: "release-manager", BaseRole: v1beta1.BaseRoleWrite})
+	s.AddTeam(org, github.NewTeam{Name: "platform"})
+	s.AddTeam(org, github.NewTeam{Name: "docs"})
+	s.AddTeamRepo(org, "platform", repo, v1beta1.PermissionPush)
+	return s
+}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		mg  resource.Managed
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		mg     resource.Managed
+		want   want
+	}{
+		"NotTeamRepository": {
+			reason: "An error should be returned if the managed resource is not a TeamRepository.",
+			mg:     &v1beta1.Team{},
+			want: want{
+				mg:  &v1beta1.Team{},
+				err: errors.New(errNotTeamRepository),
+			},
+		},
+		"UpToDate": {
+			reason: "A team that has the desired permission should be reported as up to date. Teams are granted push by default.",
+			mg:     teamRepository("platform"),
+			want: want{
+				mg: teamRepository("platform", withRoleName("write"), withConditions(xpv1.Available())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"PermissionChanged": {
+			reason: "A team whose permission differs from the desired permission should be reported as not up to date.",
+			mg:     teamRepository("platform", withPermission("release-manager")),
+			want: want{
+				mg: teamRepository("platform", withPermission("release-manager"), withRoleName("write"), withConditions(xpv1.Available())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+		"NoPermission": {
+			reason: "A team that may not access the repository should be reported as not existing.",
+			mg:     teamRepository("docs"),
+			want: want{
+				mg: teamRepository("docs"),
+				o:  managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := newServer()
+			defer s.Close()
+
+			got, err := (&external{service: s.Client(), org: org}).Observe(context.Background(), tc.mg)
+			if diff := fake.DiffErrors(tc.want.err, err); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want managed resource, +got managed resource:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		mg     *v1beta1.TeamRepository
+		want   string
+		err    error
+	}{
+		"CustomRole": {
+			reason: "A team should be granted the custom repository role named by its permission.",
+			mg:     teamRepository("platform", withPermission("release-manager")),
+			want:   "release-manager",
+		},
+		"Granted": {
+			reason: "A team that may not access the repository should be granted the desired permission.",
+			mg:     teamRepository("docs", withPermission(v1beta1.PermissionPull)),
+			want:   v1beta1.PermissionPull,
+		},
+		"NoSuchRole": {
+			reason: "An error should be returned if the permission is neither built in nor a custom repository role.",
+			mg:     teamRepository("docs", withPermission("nope")),
+			err:    cmpopts.AnyError,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := newServer()
+			defer s.Close()
+
+			_, err := (&external{service: s.Client(), org: org}).Update(context.Background(), tc.mg)
+			if diff := fake.DiffErrors(tc.err, err); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if tc.err != nil {
+				return
+			}
+			got, _ := s.TeamRepo(org, *tc.mg.Spec.ForProvider.Team, repo)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want permission, +got permission:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		team   string
+	}{
+		"Removed": {
+			reason: "The repository should be removed from the team.",
+			team:   "platform",
+		},
+		"NoSuchTeam": {
+			reason: "No error should be returned if the team no longer exists.",
+			team:   "nope",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := newServer()
+			defer s.Close()
+
+			if err := (&external{service: s.Client(), org: org}).Delete(context.Background(), teamRepository(tc.team)); err != nil {
+				t.Errorf("\n%s\ne.Delete(...): %s", tc.reason, err)
+			}
+			if _, ok := s.TeamRepo(org, tc.team, repo); ok {
+				t.Errorf("\n%s\ne.Delete(...): team may still access the repository", tc.reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,254 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package user
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-github/v45/github"
+	"github.com/pkg/errors"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/hasheddan/kc-provider-github/apis/org/v1beta1"
+	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/options"
+)
+
+const (
+	errNotUser       = "managed resource is not a User custom resource"
+	errCreateService = "failed to create client service"
+	errLookup        = "exactly one of login or nameID must be set"
+	errGetUser       = "cannot get user"
+	errGetNameID     = "cannot look up user by NameID"
+	errUserNotFound  = "cannot find user"
+
+	errFmtDrift = "user %d, whose login is %s, is no longer identified by the desired %s; the User keeps following them"
+)
+
+const reasonDrift event.Reason = "UserDrift"
+
+// SetupUser adds a controller that reconciles User managed resources.
+func SetupUser(mgr ctrl.Manager, o options.Options) error {
+	name := managed.ControllerName(v1beta1.UserGroupKind)
+	record := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1beta1.UserGroupVersionKind),
+		managed.WithExternalConnecter(&connector{
+			kube:   mgr.GetClient(),
+			cache:  kcgitclient.NewObservationCache(kcgitclient.DefaultObservationTTL),
+			record: record,
+		}),
+		// The external name of a User is the ID of the user it finds, rather
+		// than its name.
+		managed.WithInitializers(),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(record))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1beta1.User{}).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube   client.Client
+	cache  *kcgitclient.ObservationCache
+	record event.Recorder
+}
+
+// Connect produces an ExternalClient that uses the credentials of the managed
+// resource's ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1beta1.User)
+	if !ok {
+		return nil, errors.New(errNotUser)
+	}
+	svc, err := kcgitclient.UseProviderConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errCreateService)
+	}
+	org, err := kcgitclient.Organization(ctx, c.kube, mg, cr.Spec.ForProvider.Org)
+	if err != nil {
+		return nil, err
+	}
+	return &external{
+		service: svc,
+		org:     org,
+		graphql: kcgitclient.NewGraphQLClient(svc),
+		cache:   c.cache,
+		scope:   mg.GetProviderConfigReference().Name,
+		record:  c.record,
+	}, nil
+}
+
+// An ExternalClient observes a GitHub user. It never creates, updates, or
+// deletes them.
+type external struct {
+	service *github.Client
+
+	// The organization of the managed resource, which may be the default
+	// organization of its ProviderConfig.
+	org string
+
+	// Users are looked up by NameID via the GraphQL API. The identities of
+	// an organization are shared between all Users that use the same
+	// ProviderConfig (the scope).
+	graphql *kcgitclient.GraphQLClient
+	cache   *kcgitclient.ObservationCache
+	scope   string
+
+	// Users that are no longer identified by their login or NameID are
+	// reported as events.
+	record event.Recorder
+}
+
+// find the supplied User's user, or return nil if there is no such user. A
+// user that was found before is found by their ID, so that they are found
+// even if they change their username. If the User's login or NameID then
+// identifies a different user, for example because it was changed, that user
+// is found instead.
+func (c *external) find(ctx context.Context, cr *v1beta1.User) (*github.User, error) {
+	login, err := c.login(ctx, cr)
+	if err != nil {
+		return nil, err
+	}
+	if id, err := strconv.ParseInt(meta.GetExternalName(cr), 10, 64); err == nil {
+		u, err := c.get(c.service.Users.GetByID(ctx, id))
+		if err != nil || u == nil || strings.EqualFold(u.GetLogin(), login) {
+			return u, err
+		}
+		l, err := c.lookup(ctx, login)
+		if err != nil || l != nil {
+			return l, err
+		}
+		c.record.Event(cr, event.Warning(reasonDrift, errors.Errorf(errFmtDrift, u.GetID(), u.GetLogin(), identifier(cr))))
+		return u, nil
+	}
+	return c.lookup(ctx, login)
+}
+
+// login returns the login of the supplied User's user, as identified by its
+// login or NameID, or an empty string if its NameID is not linked to a user.
+func (c *external) login(ctx context.Context, cr *v1beta1.User) (string, error) {
+	p := cr.Spec.ForProvider
+	switch {
+	case p.Login != nil && p.NameID == nil:
+		return *p.Login, nil
+	case p.NameID != nil && p.Login == nil:
+		logins, err := c.cache.ExternalIdentities(ctx, c.graphql, c.scope, c.org)
+		return logins[*p.NameID], errors.Wrap(err, errGetNameID)
+	default:
+		return "", errors.New(errLookup)
+	}
+}
+
+// lookup returns the user with the supplied login, or nil if there is no such
+// user.
+func (c *external) lookup(ctx context.Context, login string) (*github.User, error) {
+	if login == "" {
+		return nil, nil
+	}
+	return c.get(c.service.Users.Get(ctx, login))
+}
+
+// identifier describes how the supplied User identifies its user.
+func identifier(cr *v1beta1.User) string {
+	if cr.Spec.ForProvider.NameID != nil {
+		return "nameID " + *cr.Spec.ForProvider.NameID
+	}
+	return "login " + pointer.StringDeref(cr.Spec.ForProvider.Login, "")
+}
+
+// get returns the supplied user, or nil if they were not found.
+func (c *external) get(u *github.User, _ *github.Response, err error) (*github.User, error) {
+	if kcgitclient.IsNotFound(err) {
+		return nil, nil
+	}
+	return u, errors.Wrap(err, errGetUser)
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1beta1.User)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotUser)
+	}
+
+	// Deleting a User does not delete its user.
+	if meta.WasDeleted(cr) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	u, err := c.find(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	if u == nil {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	cr.Status.AtProvider = v1beta1.UserObservation{
+		ID:    u.GetID(),
+		Login: u.GetLogin(),
+		Name:  u.GetName(),
+		Email: u.GetEmail(),
+	}
+	cr.SetConditions(xpv1.Available())
+
+	id := strconv.FormatInt(u.GetID(), 10)
+	lateInitialized := meta.GetExternalName(cr) != id
+	meta.SetExternalName(cr, id)
+
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceUpToDate:        true,
+		ResourceLateInitialized: lateInitialized,
+	}, nil
+}
+
+// Create is called when the user cannot be found. A User cannot create them.
+func (c *external) Create(_ context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	if _, ok := mg.(*v1beta1.User); !ok {
+		return managed.ExternalCreation{}, errors.New(errNotUser)
+	}
+	return managed.ExternalCreation{}, errors.New(errUserNotFound)
+}
+
+// Update is never called, since a User's user is always up to date.
+func (c *external) Update(_ context.Context, _ resource.Managed) (managed.ExternalUpdate, error) {
+	return managed.ExternalUpdate{}, nil
+}
+
+// Delete does nothing, since a User does not manage its user.
+func (c *external) Delete(_ context.Context, _ resource.Managed) error {
+	return nil
+}
@@ -0,0 +1,302 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package user
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v45/github"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/hasheddan/kc-provider-github/apis/org/v1beta1"
+	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
+	"github.com/hasheddan/kc-provider-github/pkg/client/fake"
+)
+
+const (
+	org   = "crossplane"
+	login = "hubot"
+)
+
+// A recorder records the events it is asked to record.
+type recorder struct {
+	events []event.Event
+}
+
+func (r *recorder) Event(_ runtime.Object, e event.Event) { r.events = append(r.events, e) }
+
+func (r *recorder) WithAnnotations(_ ...string) event.Recorder { return r }
+
+type userModifier func(*v1beta1.User)
+
+func withLogin(l string) userModifier {
+	return func(cr *v1beta1.User) { cr.Spec.ForProvider.Login = &l }
+}
+
+func withNameID(id string) userModifier {
+	return func(cr *v1beta1.User) { cr.Spec.ForProvider.NameID = &id }
+}
+
+func withExternalName(n string) userModifier {
+	return func(cr *v1beta1.User) { meta.SetExternalName(cr, n) }
+}
+
+func withDeletionTimestamp() userModifier {
+	return func(cr *v1beta1.User) {
+		ts := metav1.NewTime(time.Unix(0, 0))
+		cr.SetDeletionTimestamp(&ts)
+	}
+}
+
+func withObservation(o v1beta1.UserObservation) userModifier {
+	return func(cr *v1beta1.User) { cr.Status.AtProvider = o }
+}
+
+func withConditions(c ...xpv1.Condition) userModifier {
+	return func(cr *v1beta1.User) { cr.SetConditions(c...) }
+}
+
+func user(m ...userModifier) *v1beta1.User {
+	cr := &v1beta1.User{
+		ObjectMeta: metav1.ObjectMeta{Name: "hubot"},
+		Spec: v1beta1.UserSpec{
+			ResourceSpec: xpv1.ResourceSpec{ProviderConfigReference: &xpv1.Reference{Name: "default"}},
+			ForProvider:  v1beta1.UserParameters{Org: org},
+		},
+	}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+func newExternal(s *fake.Server) *external {
+	c := s.Client()
+	return &external{
+		service: c,
+		org:     org,
+		graphql: kcgitclient.NewGraphQLClient(c),
+		cache:   kcgitclient.NewObservationCache(0),
+		scope:   "default",
+		record:  &recorder{},
+	}
+}
+
+// newServer returns a fake server with an organization and a user, whose ID
+// is 2.
+func newServer() *fake.Server {
+	s := fake.NewServer()
+	s.AddOrg(org)
+	s.AddUser(login)
+	s.EditUser(login, func(u *github.User) {
+		u.Name = github.String("Hubot")
+		u.Email = github.String("hubot@example.org")
+	})
+	return s
+}
+
+func TestObserve(t *testing.T) {
+	type args struct {
+		server func(s *fake.Server)
+		mg     resource.Managed
+	}
+	type want struct {
+		mg     resource.Managed
+		o      managed.ExternalObservation
+		events []event.Event
+		err    error
+	}
+
+	observed := v1beta1.UserObservation{ID: 2, Login: login, Name: "Hubot", Email: "hubot@example.org"}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NotUser": {
+			reason: "An error should be returned if the managed resource is not a User.",
+			args: args{
+				mg: &v1beta1.Team{},
+			},
+			want: want{
+				mg:  &v1beta1.Team{},
+				err: errors.New(errNotUser),
+			},
+		},
+		"NoLookup": {
+			reason: "An error should be returned if neither a login nor a NameID is set.",
+			args: args{
+				mg: user(),
+			},
+			want: want{
+				mg:  user(),
+				err: errors.New(errLookup),
+			},
+		},
+		"Login": {
+			reason: "A user should be found by their login, and identified by their ID.",
+			args: args{
+				mg: user(withLogin(login)),
+			},
+			want: want{
+				mg: user(withLogin(login), withExternalName("2"), withObservation(observed), withConditions(xpv1.Available())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ResourceLateInitialized: true},
+			},
+		},
+		"NameID": {
+			reason: "A user should be found by their NameID in the organization's identity provider.",
+			args: args{
+				server: func(s *fake.Server) {
+					s.AddExternalIdentity(org, "hubot@example.org", login)
+				},
+				mg: user(withNameID("hubot@example.org")),
+			},
+			want: want{
+				mg: user(withNameID("hubot@example.org"), withExternalName("2"), withObservation(observed), withConditions(xpv1.Available())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ResourceLateInitialized: true},
+			},
+		},
+		"Renamed": {
+			reason: "A user who was found before should be found by their ID, even if they changed their login, which should be reported.",
+			args: args{
+				server: func(s *fake.Server) {
+					s.RenameUser(login, "hubot2")
+				},
+				mg: user(withLogin(login), withExternalName("2")),
+			},
+			want: want{
+				mg: user(withLogin(login), withExternalName("2"), withObservation(v1beta1.UserObservation{
+					ID: 2, Login: "hubot2", Name: "Hubot", Email: "hubot@example.org",
+				}), withConditions(xpv1.Available())),
+				o:      managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				events: []event.Event{event.Warning(reasonDrift, errors.Errorf(errFmtDrift, 2, "hubot2", "login hubot"))},
+			},
+		},
+		"LoginChanged": {
+			reason: "A user who was found before should no longer be found if the User's login identifies a different user.",
+			args: args{
+				server: func(s *fake.Server) {
+					s.AddUser("monalisa")
+				},
+				mg: user(withLogin("monalisa"), withExternalName("2")),
+			},
+			want: want{
+				mg: user(withLogin("monalisa"), withExternalName("3"), withObservation(v1beta1.UserObservation{
+					ID: 3, Login: "monalisa",
+				}), withConditions(xpv1.Available())),
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ResourceLateInitialized: true},
+			},
+		},
+		"NameIDChanged": {
+			reason: "A user who was found before should no longer be found if the User's NameID identifies a different user.",
+			args: args{
+				server: func(s *fake.Server) {
+					s.AddExternalIdentity(org, "hubot@example.org", login)
+					s.AddExternalIdentity(org, "monalisa@example.org", "monalisa")
+				},
+				mg: user(withNameID("monalisa@example.org"), withExternalName("2")),
+			},
+			want: want{
+				mg: user(withNameID("monalisa@example.org"), withExternalName("3"), withObservation(v1beta1.UserObservation{
+					ID: 3, Login: "monalisa",
+				}), withConditions(xpv1.Available())),
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ResourceLateInitialized: true},
+			},
+		},
+		"LoginNotFound": {
+			reason: "A user that cannot be found should be reported as not existing.",
+			args: args{
+				mg: user(withLogin("nope")),
+			},
+			want: want{
+				mg: user(withLogin("nope")),
+				o:  managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"NameIDNotFound": {
+			reason: "A user that cannot be found by NameID should be reported as not existing.",
+			args: args{
+				server: func(s *fake.Server) {
+					s.AddExternalIdentity(org, "hubot@example.org", login)
+				},
+				mg: user(withNameID("nope@example.org")),
+			},
+			want: want{
+				mg: user(withNameID("nope@example.org")),
+				o:  managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"Deleted": {
+			reason: "A User that is being deleted should be reported as not existing, since deleting it does not delete the user.",
+			args: args{
+				mg: user(withLogin(login), withDeletionTimestamp()),
+			},
+			want: want{
+				mg: user(withLogin(login), withDeletionTimestamp()),
+				o:  managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := newServer()
+			defer s.Close()
+			if tc.args.server != nil {
+				tc.args.server(s)
+			}
+
+			e := newExternal(s)
+			got, err := e.Observe(context.Background(), tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.args.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want managed resource, +got managed resource:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.events, e.record.(*recorder).events, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want events, +got events:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	s := newServer()
+	defer s.Close()
+
+	_, err := newExternal(s).Create(context.Background(), user(withLogin("nope")))
+	if diff := cmp.Diff(errors.New(errUserNotFound), err, test.EquateErrors()); diff != "" {
+		t.Errorf("e.Create(...): a User should not create its user: -want error, +got error:\n%s\n", diff)
+	}
+}
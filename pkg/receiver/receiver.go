@@ -0,0 +1,297 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package receiver receives GitHub webhook deliveries and triggers the
+// reconciliation of the managed resources they concern.
+package receiver
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v45/github"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+)
+
+// GitHub webhook event types understood by the Receiver.
+const (
+	EventTeam         = "team"
+	EventMembership   = "membership"
+	EventOrganization = "organization"
+	EventRepository   = "repository"
+
+	eventPing = "ping"
+)
+
+const (
+	errNoSignature      = "request has no " + github.SHA256SignatureHeader + " header"
+	errInvalidSignature = "request signature is invalid"
+	errReadBody         = "cannot read request body"
+	errParse            = "cannot parse webhook payload"
+	errMap              = "cannot map webhook event to managed resources"
+	errServe            = "cannot serve GitHub webhooks"
+
+	maxPayloadBytes = 25 << 20
+	shutdownTimeout = 10 * time.Second
+	eventBufferSize = 100
+)
+
+// An Event is a GitHub webhook delivery reduced to the fields that identify
+// the external resources it concerns. Fields that do not apply to the type of
+// event are empty.
+type Event struct {
+	// Type of the event, per the X-GitHub-Event header.
+	Type string
+
+	// Action that caused the event, for example 'edited'.
+	Action string
+
+	// Org is the login of the organization.
+	Org string
+
+	// Team is the slug of the team.
+	Team string
+
+	// User is the login of the user.
+	User string
+
+	// Repository is the name of the repository.
+	Repository string
+}
+
+// A MapFunc returns the managed resources affected by the supplied Event.
+type MapFunc func(ctx context.Context, e Event) ([]client.Object, error)
+
+type subscriber struct {
+	types map[string]bool
+	fn    MapFunc
+	ch    chan event.GenericEvent
+}
+
+// A Receiver is an http.Handler that accepts GitHub webhook deliveries and
+// enqueues the managed resources they concern for reconciliation.
+type Receiver struct {
+	address string
+	secret  []byte
+	log     logging.Logger
+
+	mu          sync.RWMutex
+	subscribers []subscriber
+}
+
+// An Option configures a Receiver.
+type Option func(r *Receiver)
+
+// WithLogger specifies how the Receiver should log messages.
+func WithLogger(l logging.Logger) Option {
+	return func(r *Receiver) {
+		r.log = l
+	}
+}
+
+// New returns a Receiver that serves GitHub webhooks at the supplied address,
+// verifying that each delivery was signed with the supplied secret.
+func New(address string, secret []byte, o ...Option) *Receiver {
+	r := &Receiver{
+		address: address,
+		secret:  secret,
+		log:     logging.NewNopLogger(),
+	}
+	for _, ro := range o {
+		ro(r)
+	}
+	return r
+}
+
+// Subscribe returns a source of the managed resources the supplied MapFunc
+// maps events of the supplied types to. The source is intended to be watched
+// by a controller using handler.EnqueueRequestForObject.
+func (r *Receiver) Subscribe(fn MapFunc, types ...string) source.Source {
+	s := subscriber{
+		types: make(map[string]bool, len(types)),
+		fn:    fn,
+		ch:    make(chan event.GenericEvent, eventBufferSize),
+	}
+	for _, t := range types {
+		s.types[t] = true
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.subscribers = append(r.subscribers, s)
+
+	return &source.Channel{Source: s.ch}
+}
+
+// ServeHTTP verifies and parses a GitHub webhook delivery, then enqueues the
+// managed resources it concerns.
+func (r *Receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	log := r.log.WithValues("delivery", github.DeliveryID(req), "event", github.WebHookType(req))
+
+	body, err := io.ReadAll(io.LimitReader(req.Body, maxPayloadBytes))
+	if err != nil {
+		log.Debug(errReadBody, "error", err)
+		http.Error(w, errReadBody, http.StatusBadRequest)
+		return
+	}
+
+	if err := r.verify(req.Header.Get(github.SHA256SignatureHeader), body); err != nil {
+		log.Debug("Rejected webhook delivery", "error", err)
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	if github.WebHookType(req) == eventPing {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	e, ok, err := Parse(github.WebHookType(req), body)
+	if err != nil {
+		log.Debug(errParse, "error", err)
+		http.Error(w, errParse, http.StatusBadRequest)
+		return
+	}
+	if !ok {
+		// We accept events we don't understand so that GitHub doesn't report
+		// the delivery as failed.
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	if err := r.dispatch(req.Context(), e); err != nil {
+		log.Info(errMap, "error", err)
+		http.Error(w, errMap, http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (r *Receiver) verify(signature string, body []byte) error {
+	if signature == "" {
+		return errors.New(errNoSignature)
+	}
+	// ValidateSignature also accepts the weaker SHA-1 signatures GitHub sends
+	// in the X-Hub-Signature header, so we require a SHA-256 one here.
+	if !strings.HasPrefix(signature, "sha256=") {
+		return errors.New(errInvalidSignature)
+	}
+	if err := github.ValidateSignature(signature, body, r.secret); err != nil {
+		return errors.New(errInvalidSignature)
+	}
+	return nil
+}
+
+func (r *Receiver) dispatch(ctx context.Context, e Event) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, s := range r.subscribers {
+		if !s.types[e.Type] {
+			continue
+		}
+		objs, err := s.fn(ctx, e)
+		if err != nil {
+			return err
+		}
+		for _, o := range objs {
+			select {
+			case s.ch <- event.GenericEvent{Object: o}:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+	return nil
+}
+
+// Start serves GitHub webhooks until the supplied context is done.
+func (r *Receiver) Start(ctx context.Context) error {
+	mux := http.NewServeMux()
+	mux.Handle("/", r)
+	srv := &http.Server{Addr: r.address, Handler: mux, ReadHeaderTimeout: shutdownTimeout}
+
+	errs := make(chan error, 1)
+	go func() {
+		r.log.Info("Serving GitHub webhooks", "address", r.address)
+		errs <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errs:
+		return errors.Wrap(err, errServe)
+	case <-ctx.Done():
+	}
+
+	sctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	return srv.Shutdown(sctx)
+}
+
+// Parse the supplied webhook payload of the supplied event type. It returns
+// false if the event type is not one the Receiver understands.
+func Parse(eventType string, payload []byte) (Event, bool, error) {
+	switch eventType {
+	case EventTeam, EventMembership, EventOrganization, EventRepository:
+	default:
+		return Event{}, false, nil
+	}
+
+	p, err := github.ParseWebHook(eventType, payload)
+	if err != nil {
+		return Event{}, false, err
+	}
+
+	e := Event{Type: eventType}
+	switch p := p.(type) {
+	case *github.TeamEvent:
+		e.Action = p.GetAction()
+		e.Org = p.GetOrg().GetLogin()
+		e.Team = p.GetTeam().GetSlug()
+		e.Repository = p.GetRepo().GetName()
+	case *github.MembershipEvent:
+		e.Action = p.GetAction()
+		e.Org = p.GetOrg().GetLogin()
+		e.Team = p.GetTeam().GetSlug()
+		e.User = p.GetMember().GetLogin()
+	case *github.OrganizationEvent:
+		e.Action = p.GetAction()
+		e.Org = p.GetOrganization().GetLogin()
+		e.User = p.GetMembership().GetUser().GetLogin()
+		if e.User == "" {
+			e.User = p.GetInvitation().GetLogin()
+		}
+	case *github.RepositoryEvent:
+		e.Action = p.GetAction()
+		e.Org = p.GetOrg().GetLogin()
+		e.Repository = p.GetRepo().GetName()
+	}
+	return e, true, nil
+}
@@ -0,0 +1,229 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package receiver
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v45/github"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const secret = "so-secret"
+
+func sign(key string, payload []byte) string {
+	m := hmac.New(sha256.New, []byte(key))
+	m.Write(payload)
+	return "sha256=" + hex.EncodeToString(m.Sum(nil))
+}
+
+func TestReceiver(t *testing.T) {
+	type args struct {
+		eventType string
+		payload   string
+		signature func(payload []byte) string
+	}
+	type want struct {
+		status int
+		events []Event
+	}
+
+	valid := func(payload []byte) string { return sign(secret, payload) }
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"MissingSignature": {
+			reason: "Deliveries without a signature should be rejected.",
+			args: args{
+				eventType: EventTeam,
+				payload:   "team_edited.json",
+				signature: func(_ []byte) string { return "" },
+			},
+			want: want{status: http.StatusUnauthorized},
+		},
+		"WrongSecret": {
+			reason: "Deliveries signed with the wrong secret should be rejected.",
+			args: args{
+				eventType: EventTeam,
+				payload:   "team_edited.json",
+				signature: func(payload []byte) string { return sign("wrong", payload) },
+			},
+			want: want{status: http.StatusUnauthorized},
+		},
+		"SHA1Signature": {
+			reason: "Deliveries signed only with SHA-1 should be rejected.",
+			args: args{
+				eventType: EventTeam,
+				payload:   "team_edited.json",
+				signature: func(_ []byte) string { return "sha1=0f3bd7fd8d7a3a1e0e5d0b4fd8b70f8c53fb3b32" },
+			},
+			want: want{status: http.StatusUnauthorized},
+		},
+		"Ping": {
+			reason: "Ping deliveries should be acknowledged without enqueueing anything.",
+			args: args{
+				eventType: eventPing,
+				payload:   "ping.json",
+				signature: valid,
+			},
+			want: want{status: http.StatusOK},
+		},
+		"TeamEdited": {
+			reason: "Team events should be mapped by organization and team slug.",
+			args: args{
+				eventType: EventTeam,
+				payload:   "team_edited.json",
+				signature: valid,
+			},
+			want: want{
+				status: http.StatusAccepted,
+				events: []Event{{Type: EventTeam, Action: "edited", Org: "crossplane-examples", Team: "platform"}},
+			},
+		},
+		"MembershipRemoved": {
+			reason: "Membership events should be mapped by organization, team slug and user.",
+			args: args{
+				eventType: EventMembership,
+				payload:   "membership_removed.json",
+				signature: valid,
+			},
+			want: want{
+				status: http.StatusAccepted,
+				events: []Event{{Type: EventMembership, Action: "removed", Org: "crossplane-examples", Team: "platform", User: "hubot"}},
+			},
+		},
+		"OrganizationMemberRemoved": {
+			reason: "Organization events should be mapped by organization and user.",
+			args: args{
+				eventType: EventOrganization,
+				payload:   "organization_member_removed.json",
+				signature: valid,
+			},
+			want: want{
+				status: http.StatusAccepted,
+				events: []Event{{Type: EventOrganization, Action: "member_removed", Org: "crossplane-examples", User: "hubot"}},
+			},
+		},
+		"RepositoryArchived": {
+			reason: "Repository events should be mapped by organization and repository name.",
+			args: args{
+				eventType: EventRepository,
+				payload:   "repository_archived.json",
+				signature: valid,
+			},
+			want: want{
+				status: http.StatusAccepted,
+				events: []Event{{Type: EventRepository, Action: "archived", Org: "crossplane-examples", Repository: "service-template"}},
+			},
+		},
+		"UnknownEvent": {
+			reason: "Events of types the receiver does not understand should be accepted and ignored.",
+			args: args{
+				eventType: "star",
+				payload:   "repository_archived.json",
+				signature: valid,
+			},
+			want: want{status: http.StatusAccepted},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			payload, err := os.ReadFile(filepath.Join("testdata", tc.args.payload))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got []Event
+			r := New("", []byte(secret))
+			src := r.Subscribe(func(_ context.Context, e Event) ([]client.Object, error) {
+				got = append(got, e)
+				return []client.Object{&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: e.Org}}}, nil
+			}, EventTeam, EventMembership, EventOrganization, EventRepository)
+
+			srv := httptest.NewServer(r)
+			defer srv.Close()
+
+			req, err := http.NewRequest(http.MethodPost, srv.URL, bytes.NewReader(payload))
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set(github.EventTypeHeader, tc.args.eventType)
+			if sig := tc.args.signature(payload); sig != "" {
+				req.Header.Set(github.SHA256SignatureHeader, sig)
+			}
+
+			rsp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			rsp.Body.Close()
+
+			if diff := cmp.Diff(tc.want.status, rsp.StatusCode); diff != "" {
+				t.Errorf("\n%s\nServeHTTP(...): -want status, +got status:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.events, got); diff != "" {
+				t.Errorf("\n%s\nServeHTTP(...): -want events, +got events:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(len(tc.want.events), len(src.(*source.Channel).Source)); diff != "" {
+				t.Errorf("\n%s\nServeHTTP(...): -want enqueued, +got enqueued:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	r := New("", []byte(secret))
+
+	var teams, memberships int
+	r.Subscribe(func(_ context.Context, _ Event) ([]client.Object, error) {
+		teams++
+		return nil, nil
+	}, EventTeam)
+	r.Subscribe(func(_ context.Context, _ Event) ([]client.Object, error) {
+		memberships++
+		return nil, nil
+	}, EventMembership, EventTeam)
+
+	if err := r.dispatch(context.Background(), Event{Type: EventMembership}); err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(0, teams); diff != "" {
+		t.Errorf("dispatch(...): subscribers should only receive the event types they subscribed to: -want, +got:\n%s", diff)
+	}
+	if diff := cmp.Diff(1, memberships); diff != "" {
+		t.Errorf("dispatch(...): subscribers should receive the event types they subscribed to: -want, +got:\n%s", diff)
+	}
+}
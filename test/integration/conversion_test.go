@@ -0,0 +1,98 @@
+//go:build integration
+// +build integration
+
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/hasheddan/kc-provider-github/apis/org/v1alpha1"
+	"github.com/hasheddan/kc-provider-github/apis/org/v1beta1"
+)
+
+func TestConversion(t *testing.T) {
+	ctx := context.Background()
+
+	// Orphan the team, since this test is only concerned with conversion.
+	old := &v1alpha1.Team{
+		ObjectMeta: metav1.ObjectMeta{Name: "legacy"},
+		Spec: v1alpha1.TeamSpec{
+			ResourceSpec: xpv1.ResourceSpec{
+				ProviderConfigReference: &xpv1.Reference{Name: providerConfig},
+				DeletionPolicy:          xpv1.DeletionOrphan,
+			},
+			ForProvider: v1alpha1.TeamParameters{
+				Org:         org,
+				Description: pointer.String("Created as v1alpha1"),
+			},
+		},
+	}
+	if err := kube.Create(ctx, old); err != nil {
+		t.Fatal(err)
+	}
+
+	cr := &v1beta1.Team{}
+	if err := kube.Get(ctx, client.ObjectKeyFromObject(old), cr); err != nil {
+		t.Fatal(err)
+	}
+	if got := pointer.StringDeref(cr.Spec.ForProvider.Description, ""); got != "Created as v1alpha1" {
+		t.Errorf("a v1alpha1 Team should be readable as v1beta1: got description %q", got)
+	}
+
+	// Parameters that v1alpha1 cannot represent should survive an update
+	// made using v1alpha1. The Team is updated concurrently by its
+	// reconciler, so updates are retried on conflict.
+	if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := kube.Get(ctx, client.ObjectKeyFromObject(cr), cr); err != nil {
+			return err
+		}
+		cr.Spec.ForProvider.NotificationSetting = pointer.String(v1beta1.NotificationsDisabled)
+		return kube.Update(ctx, cr)
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := kube.Get(ctx, client.ObjectKeyFromObject(old), old); err != nil {
+			return err
+		}
+		old.Spec.ForProvider.Description = pointer.String("Updated as v1alpha1")
+		return kube.Update(ctx, old)
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := kube.Get(ctx, client.ObjectKeyFromObject(old), cr); err != nil {
+		t.Fatal(err)
+	}
+	if got := pointer.StringDeref(cr.Spec.ForProvider.NotificationSetting, ""); got != v1beta1.NotificationsDisabled {
+		t.Errorf("a v1beta1 Team's notification setting should survive an update made using v1alpha1: got %q", got)
+	}
+
+	if err := kube.Delete(ctx, cr); err != nil {
+		t.Fatal(err)
+	}
+	eventually(t, "the Team should be deleted", func() bool { return !get(t, cr) })
+}
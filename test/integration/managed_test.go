@@ -0,0 +1,156 @@
+//go:build integration
+// +build integration
+
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-github/v45/github"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+
+	"github.com/hasheddan/kc-provider-github/apis/org/v1beta1"
+)
+
+func TestTeam(t *testing.T) {
+	ctx := context.Background()
+	cr := &v1beta1.Team{
+		ObjectMeta: metav1.ObjectMeta{Name: "platform"},
+		Spec: v1beta1.TeamSpec{
+			ResourceSpec: xpv1.ResourceSpec{ProviderConfigReference: &xpv1.Reference{Name: providerConfig}},
+			ForProvider: v1beta1.TeamParameters{
+				Org:         org,
+				Description: github.String("Platform engineers"),
+				Privacy:     github.String("closed"),
+			},
+		},
+	}
+	if err := kube.Create(ctx, cr); err != nil {
+		t.Fatal(err)
+	}
+
+	description := func(want string) func() bool {
+		return func() bool {
+			team, ok := server.Team(org, "platform")
+			return ok && team.GetDescription() == want && team.GetPrivacy() == "closed"
+		}
+	}
+
+	eventually(t, "the team should be created", description("Platform engineers"))
+	eventually(t, "the Team should be synced", func() bool { return synced(t, cr) })
+	if !meta.FinalizerExists(cr, finalizer) {
+		t.Errorf("the Team should have the managed resource finalizer")
+	}
+	if got := meta.GetExternalName(cr); got != "platform" {
+		t.Errorf("the Team's external name should default to its name: got %q", got)
+	}
+	eventually(t, "the Team's use of its ProviderConfig should be tracked", func() bool { return used(t, v1beta1.TeamKind, cr.GetName()) })
+
+	server.EditTeam(org, "platform", github.NewTeam{Description: github.String("Drifted")})
+	eventually(t, "drift from the desired description should be corrected", description("Platform engineers"))
+
+	if err := kube.Get(ctx, client.ObjectKeyFromObject(cr), cr); err != nil {
+		t.Fatal(err)
+	}
+	cr.Spec.ForProvider.Description = github.String("Platform and infrastructure engineers")
+	if err := kube.Update(ctx, cr); err != nil {
+		t.Fatal(err)
+	}
+	eventually(t, "the team should be updated", description("Platform and infrastructure engineers"))
+
+	if err := kube.Delete(ctx, cr); err != nil {
+		t.Fatal(err)
+	}
+	eventually(t, "the team should be deleted", func() bool {
+		_, ok := server.Team(org, "platform")
+		return !ok
+	})
+	eventually(t, "the Team's finalizer should be removed once the team is deleted", func() bool { return !get(t, cr) })
+}
+
+func TestMembership(t *testing.T) {
+	ctx := context.Background()
+	server.AddOrgMember(org, "hubot", "member")
+
+	team := &v1beta1.Team{
+		ObjectMeta: metav1.ObjectMeta{Name: "sre"},
+		Spec: v1beta1.TeamSpec{
+			ResourceSpec: xpv1.ResourceSpec{ProviderConfigReference: &xpv1.Reference{Name: providerConfig}},
+			ForProvider:  v1beta1.TeamParameters{Org: org},
+		},
+	}
+	if err := kube.Create(ctx, team); err != nil {
+		t.Fatal(err)
+	}
+	cr := &v1beta1.Membership{
+		ObjectMeta: metav1.ObjectMeta{Name: "sre-hubot"},
+		Spec: v1beta1.MembershipSpec{
+			ResourceSpec: xpv1.ResourceSpec{ProviderConfigReference: &xpv1.Reference{Name: providerConfig}},
+			ForProvider: v1beta1.MembershipParameters{
+				Org:     org,
+				User:    "hubot",
+				TeamRef: &xpv1.Reference{Name: "sre"},
+			},
+		},
+	}
+	if err := kube.Create(ctx, cr); err != nil {
+		t.Fatal(err)
+	}
+
+	member := func() bool {
+		m, ok := server.TeamMembership(org, "sre", "hubot")
+		return ok && m.GetState() == "active"
+	}
+
+	eventually(t, "the user should be added to the team", member)
+	eventually(t, "the Membership should be synced", func() bool { return synced(t, cr) })
+	if got := pointer.StringDeref(cr.Spec.ForProvider.Team, ""); got != "sre" {
+		t.Errorf("the Membership's team should be resolved from its TeamRef: got %q", got)
+	}
+	eventually(t, "the Membership's observed state should be reported", func() bool {
+		return get(t, cr) && cr.Status.AtProvider.State == "active"
+	})
+	if !meta.FinalizerExists(cr, finalizer) {
+		t.Errorf("the Membership should have the managed resource finalizer")
+	}
+	eventually(t, "the Membership's use of its ProviderConfig should be tracked", func() bool { return used(t, v1beta1.MembershipKind, cr.GetName()) })
+
+	server.RemoveTeamMember(org, "sre", "hubot")
+	eventually(t, "a user removed from the team outside the provider should be re-added", member)
+
+	if err := kube.Delete(ctx, cr); err != nil {
+		t.Fatal(err)
+	}
+	eventually(t, "the user should be removed from the team", func() bool {
+		_, ok := server.TeamMembership(org, "sre", "hubot")
+		return !ok
+	})
+	eventually(t, "the Membership's finalizer should be removed once the user is removed", func() bool { return !get(t, cr) })
+
+	if err := kube.Delete(ctx, team); err != nil {
+		t.Fatal(err)
+	}
+	eventually(t, "the Team should be deleted", func() bool { return !get(t, team) })
+}
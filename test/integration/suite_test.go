@@ -0,0 +1,245 @@
+//go:build integration
+// +build integration
+
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package integration tests the managed resource reconcilers against a real
+// API server, started by envtest, and a fake GitHub API. The API server calls
+// the provider's admission and conversion webhooks.
+//
+// The API server and etcd binaries are located via the KUBEBUILDER_ASSETS
+// environment variable, for example:
+//
+//	export KUBEBUILDER_ASSETS=$(setup-envtest use -p path 1.23.x)
+//	go test -tags integration ./test/integration/...
+package integration
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v45/github"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	xpcontroller "github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/hasheddan/kc-provider-github/apis"
+	orgv1beta1 "github.com/hasheddan/kc-provider-github/apis/org/v1beta1"
+	"github.com/hasheddan/kc-provider-github/apis/v1alpha1"
+	"github.com/hasheddan/kc-provider-github/pkg/client/fake"
+	"github.com/hasheddan/kc-provider-github/pkg/controller"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/options"
+)
+
+const (
+	org            = "crossplane"
+	providerConfig = "default"
+	namespace      = "crossplane-system"
+
+	// How often managed resources are polled for drift, and how long to
+	// wait for the reconcilers to converge.
+	pollInterval = time.Second
+	timeout      = 30 * time.Second
+	interval     = 250 * time.Millisecond
+
+	// The finalizer added by the managed resource reconciler.
+	finalizer = "finalizer.managedresource.crossplane.io"
+)
+
+var (
+	kube   client.Client
+	server *fake.Server
+)
+
+func TestMain(m *testing.M) {
+	if os.Getenv("KUBEBUILDER_ASSETS") == "" {
+		fmt.Println("Skipping integration tests: KUBEBUILDER_ASSETS is not set")
+		os.Exit(0)
+	}
+	os.Exit(run(m))
+}
+
+func run(m *testing.M) int {
+	// The scheme is used to determine which CRDs are converted by the
+	// conversion webhook, so our APIs must be added before envtest starts.
+	s := scheme.Scheme
+	if err := apis.AddToScheme(s); err != nil {
+		fmt.Fprintf(os.Stderr, "cannot add APIs to scheme: %s\n", err)
+		return 1
+	}
+
+	env := &envtest.Environment{
+		CRDDirectoryPaths:     []string{filepath.Join("..", "..", "package", "crds")},
+		ErrorIfCRDPathMissing: true,
+		WebhookInstallOptions: envtest.WebhookInstallOptions{
+			Paths: []string{filepath.Join("..", "..", "package", "webhookconfigurations")},
+		},
+	}
+	cfg, err := env.Start()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "cannot start test environment: %s\n", err)
+		return 1
+	}
+	defer env.Stop() //nolint:errcheck // Nothing useful to do with this error.
+
+	server = fake.NewServer()
+	defer server.Close()
+	server.AddOrg(org)
+	server.SetToken("crossplane-bot", []string{"admin:org"}, time.Time{})
+
+	wh := env.WebhookInstallOptions
+	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
+		Scheme:             s,
+		MetricsBindAddress: "0",
+		Host:               wh.LocalServingHost,
+		Port:               wh.LocalServingPort,
+		CertDir:            wh.LocalServingCertDir,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "cannot create controller manager: %s\n", err)
+		return 1
+	}
+	o := options.Options{
+		Options: xpcontroller.Options{
+			Logger:                  logging.NewNopLogger(),
+			MaxConcurrentReconciles: 1,
+			PollInterval:            pollInterval,
+			GlobalRateLimiter:       ratelimiter.NewGlobal(100),
+		},
+	}
+	if err := controller.Setup(mgr, o); err != nil {
+		fmt.Fprintf(os.Stderr, "cannot setup controllers: %s\n", err)
+		return 1
+	}
+	for _, wh := range []interface{ SetupWebhookWithManager(ctrl.Manager) error }{
+		&orgv1beta1.Team{}, &orgv1beta1.Membership{}, &orgv1beta1.Organization{},
+	} {
+		if err := wh.SetupWebhookWithManager(mgr); err != nil {
+			fmt.Fprintf(os.Stderr, "cannot setup webhooks: %s\n", err)
+			return 1
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		if err := mgr.Start(ctx); err != nil {
+			fmt.Fprintf(os.Stderr, "cannot start controller manager: %s\n", err)
+			os.Exit(1)
+		}
+	}()
+
+	kube, err = client.New(cfg, client.Options{Scheme: s})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "cannot create client: %s\n", err)
+		return 1
+	}
+	if err := setupProviderConfig(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "cannot create ProviderConfig: %s\n", err)
+		return 1
+	}
+
+	return m.Run()
+}
+
+// setupProviderConfig creates a ProviderConfig that makes requests to the fake
+// GitHub API using the credentials in a Secret.
+func setupProviderConfig(ctx context.Context) error {
+	if err := kube.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}}); err != nil {
+		return err
+	}
+	if err := kube.Create(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "github"},
+		StringData: map[string]string{"token": "fake"},
+	}); err != nil {
+		return err
+	}
+	return kube.Create(ctx, &v1alpha1.ProviderConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: providerConfig},
+		Spec: v1alpha1.ProviderConfigSpec{
+			Credentials: v1alpha1.ProviderCredentials{
+				Source: xpv1.CredentialsSourceSecret,
+				CommonCredentialSelectors: xpv1.CommonCredentialSelectors{
+					SecretRef: &xpv1.SecretKeySelector{
+						SecretReference: xpv1.SecretReference{Namespace: namespace, Name: "github"},
+						Key:             "token",
+					},
+				},
+			},
+			BaseURL: github.String(server.URL + "/"),
+		},
+	})
+}
+
+// eventually calls the supplied function until it returns true, failing the
+// test if it does not do so before the timeout.
+func eventually(t *testing.T, reason string, fn func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(timeout)
+	for !fn() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting: %s", reason)
+		}
+		time.Sleep(interval)
+	}
+}
+
+// get returns true if the supplied object exists, updating it in place.
+func get(t *testing.T, o client.Object) bool {
+	t.Helper()
+	err := kube.Get(context.Background(), client.ObjectKeyFromObject(o), o)
+	if client.IgnoreNotFound(err) != nil {
+		t.Fatal(err)
+	}
+	return err == nil
+}
+
+// synced returns true if the supplied managed resource exists and was
+// successfully reconciled.
+func synced(t *testing.T, mg resource.Managed) bool {
+	t.Helper()
+	return get(t, mg) && mg.GetCondition(xpv1.TypeSynced).Status == corev1.ConditionTrue
+}
+
+// used returns true if a ProviderConfigUsage records that the managed resource
+// of the supplied kind and name uses the ProviderConfig.
+func used(t *testing.T, kind, name string) bool {
+	t.Helper()
+	l := &v1alpha1.ProviderConfigUsageList{}
+	if err := kube.List(context.Background(), l); err != nil {
+		t.Fatal(err)
+	}
+	for _, u := range l.Items {
+		if u.ProviderConfigReference.Name == providerConfig && u.ResourceReference.Kind == kind && u.ResourceReference.Name == name {
+			return true
+		}
+	}
+	return false
+}